@@ -2,33 +2,44 @@ package controllers
 
 import (
 	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
 	"blog-api/Infrastructure/utils"
-	"bytes"
-	"encoding/json"
+	"errors"
 	"net/http"
-	"os"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-type AIRequest struct {
-	Keywords []string `json:"keywords"`
-	Tone     string   `json:"tone"`
-	Content  string   `json:"content"`
-}
-
 type AISuggestionRequest struct {
 	BlogContent string   `json:"blog_content"`
-	Keywords    []string `json:"keywords" binding:"required"`
+	Keywords    []string `json:"keywords"`
 	Tone        string   `json:"tone"`
 	Type        string   `json:"type"` // "improvement", "ideas", "title", "summary"
 }
 
+// AIStreamRequest asks for a streamed suggestion. With save set, the finished suggestion is
+// stored under input_topic, or under the generated title when no topic is given.
+type AIStreamRequest struct {
+	AISuggestionRequest
+	Save       bool   `json:"save"`
+	InputTopic string `json:"input_topic"`
+}
+
 type AISuggestionResponse struct {
-	Suggestions []string `json:"suggestions"`
-	Type        string   `json:"type"`
-	Message     string   `json:"message"`
+	Suggestions []string            `json:"suggestions"`
+	Type        string              `json:"type"`
+	Result      models.AIGeneration `json:"result"`
+	Meta        AIResponseMeta      `json:"meta"`
+	Message     string              `json:"message"`
+}
+
+// AIResponseMeta describes how a generation was served
+type AIResponseMeta struct {
+	// Cache is "hit", "miss" or "shared" (joined an identical request in flight); empty when
+	// the response cache is off
+	Cache string `json:"cache,omitempty"`
 }
 
 type AISuggestionController struct {
@@ -40,94 +51,140 @@ func NewAISuggestionController(aiSuggestionUC interfaces.AISuggestionUseCase) *A
 }
 
 // GenerateAISuggestion handles AI content suggestions for blog posts
-func GenerateAISuggestion(c *gin.Context) {
+func (ctrl *AISuggestionController) GenerateAISuggestion(c *gin.Context) {
 	var req AISuggestionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
 		return
 	}
 
-	// Validate request
-	if len(req.Keywords) == 0 {
-		utils.SendError(c, http.StatusBadRequest, "Keywords are required")
+	genReq, err := req.generationRequest()
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Set default tone if not provided
-	if req.Tone == "" {
-		req.Tone = "professional"
+	generation, err := ctrl.aiSuggestionUC.GenerateSuggestion(c.Request.Context(), genReq)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
 	}
 
-	// Set default type if not provided
-	if req.Type == "" {
-		req.Type = "improvement"
+	aiResponse := AISuggestionResponse{
+		Suggestions: generationToSuggestions(generation),
+		Type:        genReq.Type,
+		Result:      generation,
+		Meta:        responseMeta(c, generation),
+		Message:     "AI suggestions generated successfully",
 	}
 
-	// Prepare payload for AI service (matching the AI service interface)
-	aiPayload := map[string]interface{}{
-		"keywords": req.Keywords,
-		"tone":     req.Tone,
-	}
+	utils.SendSuccess(c, aiResponse.Message, aiResponse)
+}
 
-	// Marshal the input to JSON for sending to the AI microservice
-	payloadBytes, err := json.Marshal(aiPayload)
-	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to encode request")
+// StreamAISuggestion relays AI suggestions as server-sent events: a "token" event for every chunk
+// of text, then "done" with the assembled suggestions, or "error". A stream the AI backend fails or
+// cuts short ends in "error" and is never saved. Closing the connection cancels the upstream AI call.
+func (ctrl *AISuggestionController) StreamAISuggestion(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
-	// Get AI service URL from environment
-	aiURL := os.Getenv("AI_SERVICE_URL")
-	if aiURL == "" {
-		utils.SendError(c, http.StatusInternalServerError, "AI_SERVICE_URL not configured")
+	var req AIStreamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
 		return
 	}
 
-	// Make request to AI service
-	resp, err := http.Post(aiURL+"/generate", "application/json", bytes.NewBuffer(payloadBytes))
+	genReq, err := req.generationRequest()
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "AI service request failed: "+err.Error())
+		utils.SendError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check if AI service responded successfully
-	if resp.StatusCode != http.StatusOK {
-		utils.SendError(c, http.StatusInternalServerError, "AI service returned error status: "+resp.Status)
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	ctx := c.Request.Context()
+	generation, err := ctrl.aiSuggestionUC.StreamSuggestion(ctx, genReq, func(token string) error {
+		c.SSEvent("token", gin.H{"text": token})
+		c.Writer.Flush()
+		return ctx.Err()
+	})
+	if ctx.Err() != nil {
+		// Client went away; nobody is left to tell
 		return
 	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to parse AI response")
+	if err != nil {
+		c.SSEvent("error", gin.H{"message": err.Error()})
 		return
 	}
 
-	// Create response
-	aiResponse := AISuggestionResponse{
-		Suggestions: []string{},
-		Type:        req.Type,
-		Message:     "AI suggestions generated successfully",
-	}
+	suggestions := generationToSuggestions(generation)
+	done := gin.H{"suggestions": suggestions, "type": genReq.Type, "result": generation, "meta": AIResponseMeta{Cache: generation.Cache}}
+
+	if req.Save {
+		topic := req.InputTopic
+		if topic == "" {
+			topic = generation.Title
+		}
+		if topic == "" {
+			// Only ideas come with a title of their own
+			topic = strings.Join(genReq.Keywords, ", ")
+		}
+		if topic == "" {
+			topic = genReq.Type + " suggestions"
+		}
 
-	// Extract data from AI service response (matching the AI service response structure)
-	if title, ok := result["title"].(string); ok && title != "" {
-		aiResponse.Suggestions = append(aiResponse.Suggestions, "Title: "+title)
+		saved, err := ctrl.aiSuggestionUC.SaveAISuggestion(userID.(string), topic, genReq.Keywords, genReq.Tone, suggestions)
+		if err != nil {
+			c.SSEvent("error", gin.H{"message": "Failed to save suggestion: " + err.Error()})
+			return
+		}
+		done["suggestion"] = saved
 	}
 
-	if audience, ok := result["audience"].(string); ok && audience != "" {
-		aiResponse.Suggestions = append(aiResponse.Suggestions, "Target Audience: "+audience)
+	c.SSEvent("done", done)
+	c.Writer.Flush()
+}
+
+// generationRequest fills in the default tone and type and checks the type is known. Without a
+// type, a request carrying blog content asks for improvements and one without asks for ideas.
+// Ideas are built from the keywords, so they need at least one; every other type works on the
+// blog content instead, where keywords are optional hints.
+func (req AISuggestionRequest) generationRequest() (models.AIGenerationRequest, error) {
+	if req.Tone == "" {
+		req.Tone = "professional"
+	}
+	if req.Type == "" {
+		req.Type = models.AISuggestionTypeIdeas
+		if strings.TrimSpace(req.BlogContent) != "" {
+			req.Type = models.AISuggestionTypeImprovement
+		}
 	}
 
-	if headlines, ok := result["headlines"].([]interface{}); ok {
-		for _, headline := range headlines {
-			if str, ok := headline.(string); ok {
-				aiResponse.Suggestions = append(aiResponse.Suggestions, "• "+str)
-			}
+	switch req.Type {
+	case models.AISuggestionTypeIdeas:
+		if len(req.Keywords) == 0 {
+			return models.AIGenerationRequest{}, errors.New("keywords are required for ideas suggestions")
+		}
+	case models.AISuggestionTypeImprovement, models.AISuggestionTypeTitle, models.AISuggestionTypeSummary:
+		if strings.TrimSpace(req.BlogContent) == "" {
+			return models.AIGenerationRequest{}, errors.New("blog_content is required for " + req.Type + " suggestions")
 		}
+	default:
+		return models.AIGenerationRequest{}, errors.New("type must be one of improvement, ideas, title, summary")
 	}
 
-	utils.SendSuccess(c, aiResponse.Message, aiResponse)
+	return models.AIGenerationRequest{
+		Type:     req.Type,
+		Keywords: req.Keywords,
+		Tone:     req.Tone,
+		Content:  req.BlogContent,
+	}, nil
 }
 
 // SaveAISuggestion saves the AI suggestion to the database
@@ -140,7 +197,7 @@ func (ctrl *AISuggestionController) SaveAISuggestion(c *gin.Context) {
 
 	var req struct {
 		InputTopic  string   `json:"input_topic" binding:"required"`
-		Keywords    []string `json:"keywords" binding:"required"`
+		Keywords    []string `json:"keywords"`
 		Tone        string   `json:"tone"`
 		Suggestions []string `json:"suggestions" binding:"required"`
 	}
@@ -171,7 +228,8 @@ func (ctrl *AISuggestionController) SaveAISuggestion(c *gin.Context) {
 	utils.SendSuccess(c, "AI suggestion saved successfully", suggestion)
 }
 
-// GetAISuggestions retrieves AI suggestions for the authenticated user
+// GetAISuggestions retrieves AI suggestions for the authenticated user.
+// Offset pages by default; ?pagination=cursor or ?cursor= without ?page= pages with opaque cursors.
 func (ctrl *AISuggestionController) GetAISuggestions(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
@@ -179,6 +237,11 @@ func (ctrl *AISuggestionController) GetAISuggestions(c *gin.Context) {
 		return
 	}
 
+	if utils.CursorRequested(c) {
+		ctrl.getAISuggestionsByCursor(c, userID.(string), "")
+		return
+	}
+
 	pageStr := c.DefaultQuery("page", "1")
 	limitStr := c.DefaultQuery("limit", "10")
 
@@ -215,6 +278,11 @@ func (ctrl *AISuggestionController) GetAISuggestionsByStatus(c *gin.Context) {
 		return
 	}
 
+	if utils.CursorRequested(c) {
+		ctrl.getAISuggestionsByCursor(c, userID.(string), status)
+		return
+	}
+
 	pageStr := c.DefaultQuery("page", "1")
 	limitStr := c.DefaultQuery("limit", "10")
 
@@ -237,6 +305,22 @@ func (ctrl *AISuggestionController) GetAISuggestionsByStatus(c *gin.Context) {
 	utils.SendSuccess(c, "AI suggestions retrieved successfully", suggestions)
 }
 
+func (ctrl *AISuggestionController) getAISuggestionsByCursor(c *gin.Context, userID string, status string) {
+	page, err := utils.ParsePageRequest(c, 10, 100)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	suggestions, info, err := ctrl.aiSuggestionUC.GetAISuggestionsByCursor(userID, status, page)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve suggestions: "+err.Error())
+		return
+	}
+
+	utils.SendPage(c, "AI suggestions retrieved successfully", suggestions, info)
+}
+
 // ConvertSuggestionToDraft converts an AI suggestion to a blog draft
 func (ctrl *AISuggestionController) ConvertSuggestionToDraft(c *gin.Context) {
 	userID, exists := c.Get("userID")
@@ -251,9 +335,12 @@ func (ctrl *AISuggestionController) ConvertSuggestionToDraft(c *gin.Context) {
 		return
 	}
 
-	// Convert suggestion to draft
-	blog, err := ctrl.aiSuggestionUC.ConvertSuggestionToDraft(suggestionID, userID.(string))
+	blog, err := ctrl.aiSuggestionUC.ConvertSuggestionToDraft(c.Request.Context(), suggestionID, userID.(string), ExpandsDraft(c))
 	if err != nil {
+		if err.Error() == "suggestion already converted to a draft" {
+			utils.SendError(c, http.StatusConflict, err.Error())
+			return
+		}
 		utils.SendError(c, http.StatusInternalServerError, "Failed to convert suggestion to draft: "+err.Error())
 		return
 	}
@@ -261,6 +348,13 @@ func (ctrl *AISuggestionController) ConvertSuggestionToDraft(c *gin.Context) {
 	utils.SendSuccess(c, "Suggestion converted to draft successfully", blog)
 }
 
+// ExpandsDraft reports whether a convert-to-draft request asks the AI to write the body
+// (?expand=true) instead of leaving an outline
+func ExpandsDraft(c *gin.Context) bool {
+	expand, _ := strconv.ParseBool(c.Query("expand"))
+	return expand
+}
+
 // DeleteAISuggestion deletes an AI suggestion
 func (ctrl *AISuggestionController) DeleteAISuggestion(c *gin.Context) {
 	userID, exists := c.Get("userID")
@@ -285,7 +379,7 @@ func (ctrl *AISuggestionController) DeleteAISuggestion(c *gin.Context) {
 }
 
 // GenerateContentIdeas handles AI-generated content ideas
-func GenerateContentIdeas(c *gin.Context) {
+func (ctrl *AISuggestionController) GenerateContentIdeas(c *gin.Context) {
 	var req struct {
 		Keywords []string `json:"keywords"`
 		Tone     string   `json:"tone"`
@@ -297,72 +391,80 @@ func GenerateContentIdeas(c *gin.Context) {
 		return
 	}
 
+	if len(req.Keywords) == 0 {
+		utils.SendError(c, http.StatusBadRequest, "keywords are required for ideas suggestions")
+		return
+	}
+
 	// Set default tone if not provided
 	if req.Tone == "" {
 		req.Tone = "professional"
 	}
 
-	// Marshal the input to JSON for sending to the AI microservice
-	payloadBytes, err := json.Marshal(map[string]interface{}{
-		"keywords": req.Keywords,
-		"tone":     req.Tone,
+	generation, err := ctrl.aiSuggestionUC.GenerateSuggestion(c.Request.Context(), models.AIGenerationRequest{
+		Type:     models.AISuggestionTypeIdeas,
+		Keywords: req.Keywords,
+		Tone:     req.Tone,
 	})
-
 	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to encode request")
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Get AI service URL from environment
-	aiURL := os.Getenv("AI_SERVICE_URL")
-	if aiURL == "" {
-		utils.SendError(c, http.StatusInternalServerError, "AI_SERVICE_URL not configured")
-		return
+	aiResponse := AISuggestionResponse{
+		Suggestions: generationToSuggestions(generation),
+		Type:        models.AISuggestionTypeIdeas,
+		Result:      generation,
+		Meta:        responseMeta(c, generation),
+		Message:     "Content ideas generated successfully",
 	}
 
-	// Make request to AI service
-	resp, err := http.Post(aiURL+"/generate", "application/json", bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "AI service request failed: "+err.Error())
-		return
-	}
-	defer resp.Body.Close()
+	utils.SendSuccess(c, aiResponse.Message, aiResponse)
+}
 
-	// Check if AI service responded successfully
-	if resp.StatusCode != http.StatusOK {
-		utils.SendError(c, http.StatusInternalServerError, "AI service returned error status: "+resp.Status)
-		return
+// responseMeta reports how the generation was served, mirroring the cache status in the
+// X-AI-Cache header
+func responseMeta(c *gin.Context, generation models.AIGeneration) AIResponseMeta {
+	if generation.Cache != "" {
+		c.Header("X-AI-Cache", generation.Cache)
 	}
+	return AIResponseMeta{Cache: generation.Cache}
+}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		utils.SendError(c, http.StatusInternalServerError, "Failed to parse AI response")
-		return
-	}
+// generationToSuggestions flattens a generation into the "Title:", "Target Audience:", "Summary:"
+// and "• " lines clients save and send back
+func generationToSuggestions(generation models.AIGeneration) []string {
+	suggestions := []string{}
 
-	// Create response
-	aiResponse := AISuggestionResponse{
-		Suggestions: []string{},
-		Type:        "ideas",
-		Message:     "Content ideas generated successfully",
+	switch generation.Type {
+	case models.AISuggestionTypeImprovement:
+		for _, improvement := range generation.Improvements {
+			suggestions = append(suggestions, "• "+improvement)
+		}
+		return suggestions
+	case models.AISuggestionTypeTitle:
+		for _, title := range generation.Titles {
+			suggestions = append(suggestions, "Title: "+title)
+		}
+		return suggestions
+	case models.AISuggestionTypeSummary:
+		if generation.Summary != "" {
+			suggestions = append(suggestions, "Summary: "+generation.Summary)
+		}
+		return suggestions
 	}
 
-	// Extract data from AI service response (matching the AI service response structure)
-	if title, ok := result["title"].(string); ok && title != "" {
-		aiResponse.Suggestions = append(aiResponse.Suggestions, "Title: "+title)
+	if generation.Title != "" {
+		suggestions = append(suggestions, "Title: "+generation.Title)
 	}
 
-	if audience, ok := result["audience"].(string); ok && audience != "" {
-		aiResponse.Suggestions = append(aiResponse.Suggestions, "Target Audience: "+audience)
+	if generation.Audience != "" {
+		suggestions = append(suggestions, "Target Audience: "+generation.Audience)
 	}
 
-	if headlines, ok := result["headlines"].([]interface{}); ok {
-		for _, headline := range headlines {
-			if str, ok := headline.(string); ok {
-				aiResponse.Suggestions = append(aiResponse.Suggestions, "• "+str)
-			}
-		}
+	for _, headline := range generation.Headlines {
+		suggestions = append(suggestions, "• "+headline)
 	}
 
-	utils.SendSuccess(c, aiResponse.Message, aiResponse)
+	return suggestions
 }
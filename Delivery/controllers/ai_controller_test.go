@@ -0,0 +1,373 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"blog-api/Infrastructure/services"
+	"blog-api/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// providerUseCase answers generation calls from an AI provider and everything else from the mock
+type providerUseCase struct {
+	*mocks.AISuggestionUseCaseMock
+	provider interfaces.AIProvider
+}
+
+func (u providerUseCase) GenerateSuggestion(ctx context.Context, request models.AIGenerationRequest) (models.AIGeneration, error) {
+	return u.provider.Generate(ctx, request)
+}
+
+func (u providerUseCase) StreamSuggestion(ctx context.Context, request models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	return u.provider.Stream(ctx, request, onToken)
+}
+
+type AIControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *AISuggestionController
+	mockUC     *mocks.AISuggestionUseCaseMock
+	provider   *services.FakeAIProvider
+}
+
+func (suite *AIControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.AISuggestionUseCaseMock{}
+	suite.provider = services.NewFakeAIProvider()
+	suite.controller = NewAISuggestionController(providerUseCase{suite.mockUC, suite.provider})
+}
+
+func (suite *AIControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *AIControllerTestSuite) TestGenerateAISuggestion_Success() {
+	// Setup route
+	suite.router.POST("/ai/suggestions", suite.controller.GenerateAISuggestion)
+
+	// Create request
+	body := `{"keywords": ["go", "testing"]}`
+	req, _ := http.NewRequest("POST", "/ai/suggestions", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data AISuggestionResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ideas", response.Data.Type)
+	assert.Equal(suite.T(), "A professional guide to go, testing", response.Data.Result.Title)
+	assert.Equal(suite.T(), "Title: A professional guide to go, testing", response.Data.Suggestions[0])
+	assert.Equal(suite.T(), "• Getting started with go", response.Data.Suggestions[2])
+	assert.Equal(suite.T(), "professional", suite.provider.Requests[0].Tone)
+}
+
+func (suite *AIControllerTestSuite) TestGenerateAISuggestion_CacheMeta() {
+	// Setup mock
+	controller := NewAISuggestionController(providerUseCase{suite.mockUC, services.NewCachingAIProvider(suite.provider, time.Minute, 10)})
+
+	// Setup route
+	suite.router.POST("/ai/suggestions", controller.GenerateAISuggestion)
+
+	for _, expected := range []string{models.AICacheMiss, models.AICacheHit} {
+		// Create request
+		body := `{"keywords": ["go", "testing"], "tone": "casual"}`
+		req, _ := http.NewRequest("POST", "/ai/suggestions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute request
+		suite.router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(suite.T(), http.StatusOK, w.Code)
+		assert.Equal(suite.T(), expected, w.Header().Get("X-AI-Cache"))
+
+		var response struct {
+			Data AISuggestionResponse `json:"data"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), expected, response.Data.Meta.Cache)
+		assert.Equal(suite.T(), "A casual guide to go, testing", response.Data.Result.Title)
+	}
+	assert.Len(suite.T(), suite.provider.Requests, 1)
+}
+
+func (suite *AIControllerTestSuite) TestGenerateAISuggestion_Types() {
+	content := "Go makes concurrency approachable. Goroutines are cheap and channels connect them."
+
+	tests := []struct {
+		name        string
+		body        string
+		wantType    string
+		wantFirst   string
+		checkResult func(result models.AIGeneration)
+	}{
+		{
+			name:      "content without a type asks for improvements",
+			body:      `{"keywords": ["go"], "blog_content": "` + content + `"}`,
+			wantType:  "improvement",
+			wantFirst: "• Expand on go with a concrete example",
+			checkResult: func(result models.AIGeneration) {
+				assert.Len(suite.T(), result.Improvements, 2)
+			},
+		},
+		{
+			name:      "title alternatives",
+			body:      `{"keywords": ["go"], "tone": "casual", "type": "title", "blog_content": "` + content + `"}`,
+			wantType:  "title",
+			wantFirst: "Title: A casual guide to go",
+			checkResult: func(result models.AIGeneration) {
+				assert.Len(suite.T(), result.Titles, 3)
+			},
+		},
+		{
+			name:      "title alternatives without keywords",
+			body:      `{"type": "title", "blog_content": "` + content + `"}`,
+			wantType:  "title",
+			wantFirst: "Title: A professional guide to ",
+			checkResult: func(result models.AIGeneration) {
+				assert.Len(suite.T(), result.Titles, 3)
+			},
+		},
+		{
+			name:      "summary",
+			body:      `{"type": "summary", "blog_content": "` + content + `"}`,
+			wantType:  "summary",
+			wantFirst: "Summary: " + content,
+			checkResult: func(result models.AIGeneration) {
+				assert.Equal(suite.T(), content, result.Summary)
+			},
+		},
+	}
+
+	// Setup route
+	suite.router.POST("/ai/suggestions", suite.controller.GenerateAISuggestion)
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Create request
+			req, _ := http.NewRequest("POST", "/ai/suggestions", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Execute request
+			suite.router.ServeHTTP(w, req)
+
+			// Assertions
+			assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+			var response struct {
+				Data AISuggestionResponse `json:"data"`
+			}
+			assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(suite.T(), tt.wantType, response.Data.Type)
+			assert.Equal(suite.T(), tt.wantType, response.Data.Result.Type)
+			assert.Equal(suite.T(), tt.wantFirst, response.Data.Suggestions[0])
+			tt.checkResult(response.Data.Result)
+
+			sent := suite.provider.Requests[len(suite.provider.Requests)-1]
+			assert.Equal(suite.T(), content, sent.Content)
+		})
+	}
+}
+
+func (suite *AIControllerTestSuite) TestGenerateAISuggestion_InvalidType() {
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{
+			name:    "unknown type",
+			body:    `{"keywords": ["go"], "type": "poem", "blog_content": "Go is fun."}`,
+			message: "type must be one of",
+		},
+		{
+			name:    "ideas without keywords",
+			body:    `{"type": "ideas"}`,
+			message: "keywords are required for ideas suggestions",
+		},
+		{
+			name:    "summary without content",
+			body:    `{"keywords": ["go"], "type": "summary"}`,
+			message: "blog_content is required for summary suggestions",
+		},
+		{
+			name:    "improvement without content",
+			body:    `{"keywords": ["go"], "type": "improvement", "blog_content": "   "}`,
+			message: "blog_content is required for improvement suggestions",
+		},
+	}
+
+	// Setup route
+	suite.router.POST("/ai/suggestions", suite.controller.GenerateAISuggestion)
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Create request
+			req, _ := http.NewRequest("POST", "/ai/suggestions", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Execute request
+			suite.router.ServeHTTP(w, req)
+
+			// Assertions
+			assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+			assert.Contains(suite.T(), w.Body.String(), tt.message)
+		})
+	}
+	assert.Empty(suite.T(), suite.provider.Requests)
+}
+
+func (suite *AIControllerTestSuite) TestGenerateAISuggestion_ProviderError() {
+	suite.provider.Err = errors.New("AI service returned error status: 502 Bad Gateway")
+
+	// Setup route
+	suite.router.POST("/ai/suggestions", suite.controller.GenerateAISuggestion)
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/ai/suggestions", bytes.NewBufferString(`{"keywords": ["go"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "502 Bad Gateway")
+}
+
+func (suite *AIControllerTestSuite) TestStreamAISuggestion_SavesResult() {
+	// Setup mock
+	saved := models.AISuggestion{ID: "suggestion123", Status: models.AISuggestionStatusSaved}
+	suite.mockUC.On("SaveAISuggestion", "user123", "A casual guide to go", []string{"go"}, "casual", mock.MatchedBy(func(suggestions []string) bool {
+		return len(suggestions) == 3 && suggestions[0] == "Title: A casual guide to go"
+	})).Return(saved, nil)
+
+	// Setup route
+	suite.router.POST("/ai/suggestions/stream", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.StreamAISuggestion(c)
+	})
+
+	// Create request
+	body := `{"keywords": ["go"], "tone": "casual", "save": true}`
+	req, _ := http.NewRequest("POST", "/ai/suggestions/stream", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "text/event-stream", w.Header().Get("Content-Type"))
+
+	events := w.Body.String()
+	assert.Greater(suite.T(), strings.Count(events, "event:token"), 3)
+	assert.Contains(suite.T(), events, `"text":"Blog `)
+	assert.Contains(suite.T(), events, "event:done")
+	assert.Contains(suite.T(), events, `"id":"suggestion123"`)
+	assert.NotContains(suite.T(), events, "event:error")
+}
+
+func (suite *AIControllerTestSuite) TestStreamAISuggestion_ProviderError() {
+	suite.provider.Err = errors.New("AI service request failed")
+
+	// Setup route
+	suite.router.POST("/ai/suggestions/stream", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.StreamAISuggestion(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/ai/suggestions/stream", bytes.NewBufferString(`{"keywords": ["go"], "save": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Contains(suite.T(), w.Body.String(), "event:error")
+	assert.NotContains(suite.T(), w.Body.String(), "event:done")
+	suite.mockUC.AssertNotCalled(suite.T(), "SaveAISuggestion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AIControllerTestSuite) TestStreamAISuggestion_MissingKeywords() {
+	// Setup route
+	suite.router.POST("/ai/suggestions/stream", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.StreamAISuggestion(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/ai/suggestions/stream", bytes.NewBufferString(`{"tone": "casual"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "keywords are required for ideas suggestions")
+	assert.Empty(suite.T(), suite.provider.Requests)
+}
+
+func (suite *AIControllerTestSuite) TestStreamAISuggestion_SummaryWithoutKeywords() {
+	// Setup mock
+	saved := models.AISuggestion{ID: "suggestion123", Status: models.AISuggestionStatusSaved}
+	suite.mockUC.On("SaveAISuggestion", "user123", "summary suggestions", []string(nil), "professional", []string{"Summary: Go is fun."}).Return(saved, nil)
+
+	// Setup route
+	suite.router.POST("/ai/suggestions/stream", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.StreamAISuggestion(c)
+	})
+
+	// Create request
+	body := `{"type": "summary", "blog_content": "Go is fun.", "save": true}`
+	req, _ := http.NewRequest("POST", "/ai/suggestions/stream", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "event:done")
+	assert.NotContains(suite.T(), w.Body.String(), "event:error")
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+// Run the test suite
+func TestAIControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AIControllerTestSuite))
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AIUsageController struct {
+	aiUsageUC interfaces.AIUsageUseCase
+}
+
+func NewAIUsageController(aiUsageUC interfaces.AIUsageUseCase) *AIUsageController {
+	return &AIUsageController{aiUsageUC: aiUsageUC}
+}
+
+// GetMyUsage shows the caller's AI usage against their role's daily and monthly quota
+func (ctrl *AIUsageController) GetMyUsage(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	status, err := ctrl.aiUsageUC.GetUsageStatus(userID.(string), c.GetString("role"))
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve AI usage: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "AI usage retrieved successfully", status)
+}
+
+// GetUsageReport summarises AI usage per user. from and to take RFC3339 times or dates, a date
+// for to including that whole day; the default is the current month so far.
+func (ctrl *AIUsageController) GetUsageReport(c *gin.Context) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+
+	if value := c.Query("from"); value != "" {
+		parsed, _, err := parseReportTime(value)
+		if err != nil {
+			utils.SendError(c, http.StatusBadRequest, "from must be a date (YYYY-MM-DD) or RFC3339 time")
+			return
+		}
+		from = parsed
+	}
+
+	if value := c.Query("to"); value != "" {
+		parsed, isDate, err := parseReportTime(value)
+		if err != nil {
+			utils.SendError(c, http.StatusBadRequest, "to must be a date (YYYY-MM-DD) or RFC3339 time")
+			return
+		}
+		to = parsed
+		if isDate {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+
+	if !from.Before(to) {
+		utils.SendError(c, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := ctrl.aiUsageUC.GetUsageReport(from, to)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to generate AI usage report: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "AI usage report generated successfully", report)
+}
+
+func parseReportTime(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
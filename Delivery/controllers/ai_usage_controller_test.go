@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AIUsageControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *AIUsageController
+	mockUC     *mocks.AIUsageUseCaseMock
+}
+
+func (suite *AIUsageControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.AIUsageUseCaseMock{}
+	suite.controller = NewAIUsageController(suite.mockUC)
+}
+
+func (suite *AIUsageControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *AIUsageControllerTestSuite) TestGetMyUsage() {
+	// Setup mock
+	suite.mockUC.On("GetUsageStatus", "user123", "admin").Return(models.AIUsageStatus{
+		Role:  "admin",
+		Daily: models.AIUsageWindow{Requests: 4, RequestLimit: 200},
+	}, nil)
+
+	// Setup route
+	suite.router.GET("/ai/usage", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		c.Set("role", "admin")
+		suite.controller.GetMyUsage(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/ai/usage", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"request_limit":200`)
+}
+
+func (suite *AIUsageControllerTestSuite) TestGetUsageReport_DateRange() {
+	// Setup mock: a date for to includes that whole day
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	suite.mockUC.On("GetUsageReport", from, to).Return(models.AIUsageReport{From: from, To: to, Requests: 12}, nil)
+
+	// Setup route
+	suite.router.GET("/admin/ai-usage", suite.controller.GetUsageReport)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/admin/ai-usage?from=2026-03-01&to=2026-03-07", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"requests":12`)
+}
+
+func (suite *AIUsageControllerTestSuite) TestGetUsageReport_InvalidRange() {
+	// Setup route
+	suite.router.GET("/admin/ai-usage", suite.controller.GetUsageReport)
+
+	for _, query := range []string{"?from=yesterday", "?from=2026-03-07&to=2026-03-01"} {
+		// Create request
+		req, _ := http.NewRequest("GET", "/admin/ai-usage"+query, nil)
+		w := httptest.NewRecorder()
+
+		// Execute request
+		suite.router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, query)
+	}
+}
+
+// Run the test suite
+func TestAIUsageControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AIUsageControllerTestSuite))
+}
@@ -2,14 +2,27 @@ package controllers
 
 import (
 	"blog-api/Domain/models"
+	"blog-api/Infrastructure/utils"
 	"blog-api/usecases"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// queryableSortFields are the sort_by values accepted by QueryBlogs
+var queryableSortFields = map[string]struct{}{
+	models.SortByRelevance: {},
+	"created_at":           {},
+	"updated_at":           {},
+	"view_count":           {},
+	"likes":                {},
+	"title":                {},
+}
+
 type BlogController struct {
 	blogUC usecases.BlogUseCase
 }
@@ -25,6 +38,11 @@ func (ctrl *BlogController) CreateBlog(c *gin.Context) {
 		return
 	}
 
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "publish_at must be in the future"})
+		return
+	}
+
 	userID, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -44,10 +62,10 @@ func (ctrl *BlogController) CreateBlog(c *gin.Context) {
 		AuthorName:  authorName.(string),
 		Tags:        req.Tags,
 		IsPublished: req.IsPublished,
+		PublishAt:   req.PublishAt,
 		ViewCount:   0,
 		Likes:       0,
 		Dislikes:    0,
-		Comments:    []models.Comment{},
 	}
 
 	createdBlog, err := ctrl.blogUC.CreateBlog(blog)
@@ -61,7 +79,14 @@ func (ctrl *BlogController) CreateBlog(c *gin.Context) {
 }
 
 // GET /blogs - Get paginated blogs
+// Offset pages by default; ?pagination=cursor or ?cursor= without ?page= pages with opaque cursors,
+// which stay stable while new blogs are published
 func (ctrl *BlogController) GetPaginatedBlogs(c *gin.Context) {
+	if utils.CursorRequested(c) {
+		ctrl.getBlogsByCursor(c)
+		return
+	}
+
 	pageStr := c.DefaultQuery("page", "1")
 	limitStr := c.DefaultQuery("limit", "10")
 
@@ -75,7 +100,7 @@ func (ctrl *BlogController) GetPaginatedBlogs(c *gin.Context) {
 		limit = 10
 	}
 
-	blogs, err := ctrl.blogUC.GetPaginatedBlogs(page, limit)
+	blogs, total, err := ctrl.blogUC.GetPaginatedBlogs(page, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -90,13 +115,41 @@ func (ctrl *BlogController) GetPaginatedBlogs(c *gin.Context) {
 		Blogs:      blogResponses,
 		Page:       page,
 		Limit:      limit,
-		Total:      int64(len(blogs)),
-		TotalPages: (len(blogs) + limit - 1) / limit,
+		Total:      &total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+func (ctrl *BlogController) getBlogsByCursor(c *gin.Context) {
+	page, err := utils.ParsePageRequest(c, 10, 100)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	blogs, info, err := ctrl.blogUC.GetBlogsByCursor(page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var blogResponses []BlogResponse
+	for _, blog := range blogs {
+		blogResponses = append(blogResponses, ctrl.blogToResponse(blog))
+	}
+
+	links := utils.NewPageLinks(info)
+	c.JSON(http.StatusOK, PaginatedBlogsResponse{
+		Blogs:      blogResponses,
+		Limit:      page.Limit,
+		Total:      links.Total,
+		NextCursor: links.NextCursor,
+		PrevCursor: links.PrevCursor,
+	})
+}
+
 func (ctrl *BlogController) GetBlogByID(c *gin.Context) {
 	blogID := c.Param("id")
 	if blogID == "" {
@@ -110,6 +163,12 @@ func (ctrl *BlogController) GetBlogByID(c *gin.Context) {
 		return
 	}
 
+	// Drafts are invisible to everyone but their author and admins
+	if !blog.IsPublished && !ctrl.isAuthorOrAdmin(c, blog.AuthorID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
 	// Increment view count
 	go func() {
 		ctrl.blogUC.IncrementViewCount(blogID)
@@ -119,6 +178,38 @@ func (ctrl *BlogController) GetBlogByID(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GET /blogs/by-slug/:slug - Get blog by slug, redirecting slugs the blog was renamed away from
+func (ctrl *BlogController) GetBlogBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Slug is required"})
+		return
+	}
+
+	blog, err := ctrl.blogUC.GetBlogBySlug(slug)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
+	if !blog.IsPublished && !ctrl.isAuthorOrAdmin(c, blog.AuthorID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return
+	}
+
+	if blog.Slug != slug {
+		c.Redirect(http.StatusMovedPermanently, "/blogs/by-slug/"+blog.Slug)
+		return
+	}
+
+	go func() {
+		ctrl.blogUC.IncrementViewCount(blog.ID)
+	}()
+
+	response := ctrl.blogToResponse(blog)
+	c.JSON(http.StatusOK, response)
+}
+
 // PUT /blogs/:id - Update blog
 func (ctrl *BlogController) UpdateBlog(c *gin.Context) {
 	blogID := c.Param("id")
@@ -133,6 +224,11 @@ func (ctrl *BlogController) UpdateBlog(c *gin.Context) {
 		return
 	}
 
+	if req.PublishAt != nil && !req.PublishAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "publish_at must be in the future"})
+		return
+	}
+
 	// Get existing blog
 	existingBlog, err := ctrl.blogUC.GetBlogByID(blogID)
 	if err != nil {
@@ -160,8 +256,18 @@ func (ctrl *BlogController) UpdateBlog(c *gin.Context) {
 	if req.IsPublished != nil {
 		existingBlog.IsPublished = *req.IsPublished
 	}
+	if req.CancelSchedule {
+		existingBlog.PublishAt = nil
+	}
+	if req.PublishAt != nil {
+		existingBlog.PublishAt = req.PublishAt
+	}
 
 	updatedBlog, err := ctrl.blogUC.UpdateBlog(existingBlog)
+	if errors.Is(err, models.ErrBlogModified) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -202,6 +308,74 @@ func (ctrl *BlogController) DeleteBlog(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Blog deleted successfully"})
 }
 
+// GET /blogs/mine - List the caller's own posts, drafts included
+func (ctrl *BlogController) GetMyBlogs(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	status := c.DefaultQuery("status", models.BlogStatusAll)
+	switch status {
+	case models.BlogStatusAll, models.BlogStatusDraft, models.BlogStatusScheduled, models.BlogStatusPublished:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of all, draft, scheduled, published"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	blogs, total, err := ctrl.blogUC.GetMyBlogs(userID.(string), status, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var blogResponses []BlogResponse
+	for _, blog := range blogs {
+		blogResponses = append(blogResponses, ctrl.blogToResponse(blog))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blogs":  blogResponses,
+		"status": status,
+		"page":   page,
+		"limit":  limit,
+		"total":  total,
+	})
+}
+
+// GET /blogs/scheduled - List the caller's scheduled drafts
+func (ctrl *BlogController) GetScheduledBlogs(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blogs, err := ctrl.blogUC.GetScheduledBlogs(userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var blogResponses []BlogResponse
+	for _, blog := range blogs {
+		blogResponses = append(blogResponses, ctrl.blogToResponse(blog))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"blogs": blogResponses})
+}
+
 // GET /blogs/search - Search blogs
 func (ctrl *BlogController) SearchBlogs(c *gin.Context) {
 	query := c.Query("q")
@@ -210,18 +384,38 @@ func (ctrl *BlogController) SearchBlogs(c *gin.Context) {
 		return
 	}
 
-	blogs, err := ctrl.blogUC.SearchBlogs(query)
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	results, total, err := ctrl.blogUC.SearchBlogs(query, page, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	var blogResponses []BlogResponse
-	for _, blog := range blogs {
-		blogResponses = append(blogResponses, ctrl.blogToResponse(blog))
+	var blogResponses []SearchResultResponse
+	for _, result := range results {
+		blogResponses = append(blogResponses, SearchResultResponse{
+			BlogResponse: ctrl.blogToResponse(result.Blog),
+			Score:        result.Score,
+			Highlights:   result.Highlights,
+		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{"blogs": blogResponses, "query": query})
+	c.JSON(http.StatusOK, gin.H{
+		"blogs": blogResponses,
+		"query": query,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
 }
 
 // GET /blogs/filter - Filter blogs
@@ -250,96 +444,169 @@ func (ctrl *BlogController) FilterBlogs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"blogs": blogResponses})
 }
 
-// POST /blogs/:id/view - Increment view count
-func (ctrl *BlogController) IncrementViewCount(c *gin.Context) {
-	blogID := c.Param("id")
-	if blogID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
-		return
+// GET /blogs/query - Faceted listing combining text search and filters
+func (ctrl *BlogController) QueryBlogs(c *gin.Context) {
+	query := models.BlogQuery{
+		Text:     c.Query("q"),
+		AuthorID: c.Query("author"),
+		TagMatch: c.DefaultQuery("tag_match", models.TagMatchAny),
+		SortBy:   c.Query("sort_by"),
 	}
 
-	err := ctrl.blogUC.IncrementViewCount(blogID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	// Tags may be repeated (?tags=a&tags=b) or comma-separated (?tags=a,b)
+	for _, value := range c.QueryArray("tags") {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				query.Tags = append(query.Tags, tag)
+			}
+		}
+	}
+
+	if query.TagMatch != models.TagMatchAny && query.TagMatch != models.TagMatchAll {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tag_match must be any or all"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "View count incremented"})
-}
+	if query.SortBy != "" {
+		if _, ok := queryableSortFields[query.SortBy]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "sort_by must be one of relevance, created_at, updated_at, view_count, likes, title"})
+			return
+		}
+	}
 
-// POST /blogs/:id/like - Like blog
-func (ctrl *BlogController) LikeBlog(c *gin.Context) {
-	blogID := c.Param("id")
-	if blogID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		query.SortOrder = 1
+	case "desc":
+		query.SortOrder = -1
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
 		return
 	}
 
-	err := ctrl.blogUC.UpdateLikes(blogID, true)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var err error
+	if query.From, err = dateQueryParam(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.To, err = dateQueryParam(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.To != nil {
+		// Include the entire end date
+		end := query.To.Add(24 * time.Hour)
+		query.To = &end
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Blog liked successfully"})
-}
-
-// DELETE /blogs/:id/like - Unlike blog
-func (ctrl *BlogController) UnlikeBlog(c *gin.Context) {
-	blogID := c.Param("id")
-	if blogID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+	if query.MinLikes, err = minQueryParam(c, "min_likes"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.MinViews, err = minQueryParam(c, "min_views"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := ctrl.blogUC.UpdateLikes(blogID, false)
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+	query.Page = page
+	query.Limit = limit
+
+	result, err := ctrl.blogUC.QueryBlogs(query)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Blog unliked successfully"})
+	var blogResponses []BlogResponse
+	for _, blog := range result.Blogs {
+		blogResponses = append(blogResponses, ctrl.blogToResponse(blog))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blogs":  blogResponses,
+		"page":   page,
+		"limit":  limit,
+		"total":  result.Total,
+		"facets": result.Facets,
+	})
 }
 
-// POST /blogs/:id/dislike - Dislike blog
-func (ctrl *BlogController) DislikeBlog(c *gin.Context) {
+// POST /blogs/:id/view - Increment view count
+func (ctrl *BlogController) IncrementViewCount(c *gin.Context) {
 	blogID := c.Param("id")
 	if blogID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
 		return
 	}
 
-	err := ctrl.blogUC.UpdateDislikes(blogID, true)
+	err := ctrl.blogUC.IncrementViewCount(blogID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Blog disliked successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "View count incremented"})
+}
+
+// POST /blogs/:id/like - Like blog
+func (ctrl *BlogController) LikeBlog(c *gin.Context) {
+	ctrl.react(c, models.ReactionLike, "Blog liked successfully")
+}
+
+// POST /blogs/:id/unlike - Unlike blog
+func (ctrl *BlogController) UnlikeBlog(c *gin.Context) {
+	ctrl.removeReaction(c, models.ReactionLike, "Blog unliked successfully")
+}
+
+// POST /blogs/:id/dislike - Dislike blog
+func (ctrl *BlogController) DislikeBlog(c *gin.Context) {
+	ctrl.react(c, models.ReactionDislike, "Blog disliked successfully")
 }
 
-// DELETE /blogs/:id/dislike - Remove dislike
+// POST /blogs/:id/remove-dislike - Remove dislike
 func (ctrl *BlogController) RemoveDislike(c *gin.Context) {
-	blogID := c.Param("id")
-	if blogID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+	ctrl.removeReaction(c, models.ReactionDislike, "Blog dislike removed successfully")
+}
+
+// GET /blogs/:id/reaction - Get the caller's reaction
+func (ctrl *BlogController) GetReaction(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blogID, ok := ctrl.visibleBlogID(c)
+	if !ok {
 		return
 	}
 
-	err := ctrl.blogUC.UpdateDislikes(blogID, false)
+	reaction, err := ctrl.blogUC.GetUserReaction(blogID, userID.(string))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(reactionErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Blog dislike removed successfully"})
+	c.JSON(http.StatusOK, gin.H{"blog_id": blogID, "reaction": reaction})
 }
 
 // POST /blogs/:id/comments - Add comment
 func (ctrl *BlogController) AddComment(c *gin.Context) {
-	blogID := c.Param("id")
-	if blogID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+	blogID, ok := ctrl.visibleBlogID(c)
+	if !ok {
 		return
 	}
 
@@ -364,6 +631,7 @@ func (ctrl *BlogController) AddComment(c *gin.Context) {
 
 	comment := models.Comment{
 		BlogID:     blogID,
+		ParentID:   req.ParentID,
 		AuthorID:   userID.(string),
 		AuthorName: authorName.(string),
 		Content:    req.Content,
@@ -379,15 +647,59 @@ func (ctrl *BlogController) AddComment(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
-// GET /blogs/:id/comments - Get comments
+// GET /blogs/:id/comments - Get paginated comment threads
+// Offset pages by default; ?pagination=cursor or ?cursor= without ?page= pages threads with opaque cursors
 func (ctrl *BlogController) GetComments(c *gin.Context) {
-	blogID := c.Param("id")
-	if blogID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+	blogID, ok := ctrl.visibleBlogID(c)
+	if !ok {
+		return
+	}
+
+	if utils.CursorRequested(c) {
+		ctrl.getCommentsByCursor(c, blogID)
+		return
+	}
+
+	pageStr := c.DefaultQuery("page", "1")
+	limitStr := c.DefaultQuery("limit", "20")
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	comments, total, err := ctrl.blogUC.GetComments(blogID, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	comments, err := ctrl.blogUC.GetComments(blogID)
+	var commentResponses []CommentResponse
+	for _, comment := range comments {
+		commentResponses = append(commentResponses, ctrl.commentToResponse(comment))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments": commentResponses,
+		"page":     page,
+		"limit":    limit,
+		"total":    total,
+	})
+}
+
+func (ctrl *BlogController) getCommentsByCursor(c *gin.Context, blogID string) {
+	page, err := utils.ParsePageRequest(c, 20, 100)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, info, err := ctrl.blogUC.GetCommentsByCursor(blogID, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -398,41 +710,383 @@ func (ctrl *BlogController) GetComments(c *gin.Context) {
 		commentResponses = append(commentResponses, ctrl.commentToResponse(comment))
 	}
 
-	c.JSON(http.StatusOK, gin.H{"comments": commentResponses})
+	c.JSON(http.StatusOK, CommentPageResponse{
+		Comments:  commentResponses,
+		Limit:     page.Limit,
+		PageLinks: utils.NewPageLinks(info),
+	})
+}
+
+// PUT /blogs/:id/comments/:commentId - Edit comment
+func (ctrl *BlogController) UpdateComment(c *gin.Context) {
+	blogID := c.Param("id")
+	commentID := c.Param("commentId")
+	if blogID == "" || commentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID and comment ID are required"})
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existingComment, err := ctrl.blogUC.GetCommentByID(commentID)
+	if err != nil || existingComment.BlogID != blogID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+	if existingComment.IsDeleted {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Deleted comments cannot be edited"})
+		return
+	}
+
+	if !ctrl.isAuthorOrAdmin(c, existingComment.AuthorID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own comments"})
+		return
+	}
+
+	updatedComment, err := ctrl.blogUC.UpdateComment(commentID, req.Content)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := ctrl.commentToResponse(updatedComment)
+	c.JSON(http.StatusOK, response)
+}
+
+// DELETE /blogs/:id/comments/:commentId - Delete comment
+func (ctrl *BlogController) DeleteComment(c *gin.Context) {
+	blogID := c.Param("id")
+	commentID := c.Param("commentId")
+	if blogID == "" || commentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID and comment ID are required"})
+		return
+	}
+
+	existingComment, err := ctrl.blogUC.GetCommentByID(commentID)
+	if err != nil || existingComment.BlogID != blogID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+
+	if !ctrl.isAuthorOrAdmin(c, existingComment.AuthorID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own comments"})
+		return
+	}
+
+	err = ctrl.blogUC.DeleteComment(commentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// GET /blogs/:id/revisions - List revisions
+func (ctrl *BlogController) GetRevisions(c *gin.Context) {
+	blog, ok := ctrl.revisionAccess(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := ctrl.blogUC.GetRevisions(blog.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var response []RevisionResponse
+	for _, revision := range revisions {
+		response = append(response, ctrl.revisionToResponse(revision))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": response})
+}
+
+// GET /blogs/:id/revisions/:version - Get a single revision
+func (ctrl *BlogController) GetRevision(c *gin.Context) {
+	blog, ok := ctrl.revisionAccess(c)
+	if !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	revision, err := ctrl.blogUC.GetRevision(blog.ID, version)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ctrl.revisionToResponse(revision))
+}
+
+// GET /blogs/:id/diff?from=1&to=2 - Line diff between two revisions
+func (ctrl *BlogController) DiffRevisions(c *gin.Context) {
+	blog, ok := ctrl.revisionAccess(c)
+	if !ok {
+		return
+	}
+
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to versions are required"})
+		return
+	}
+
+	diff, err := ctrl.blogUC.DiffRevisions(blog.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// POST /blogs/:id/revisions/:version/restore - Restore a revision as the current version
+func (ctrl *BlogController) RestoreRevision(c *gin.Context) {
+	blog, ok := ctrl.revisionAccess(c)
+	if !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	if _, err := ctrl.blogUC.GetRevision(blog.ID, version); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+
+	restoredBlog, err := ctrl.blogUC.RestoreRevision(blog.ID, version)
+	if errors.Is(err, models.ErrBlogModified) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := ctrl.blogToResponse(restoredBlog)
+	c.JSON(http.StatusOK, response)
 }
 
 // Helper methods
+func (ctrl *BlogController) react(c *gin.Context, reactionType, message string) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blogID, ok := ctrl.visibleBlogID(c)
+	if !ok {
+		return
+	}
+
+	reaction, err := ctrl.blogUC.ReactToBlog(blogID, userID.(string), reactionType)
+	if err != nil {
+		c.JSON(reactionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message, "reaction": reaction.Type})
+}
+
+func (ctrl *BlogController) removeReaction(c *gin.Context, reactionType, message string) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	blogID, ok := ctrl.visibleBlogID(c)
+	if !ok {
+		return
+	}
+
+	err := ctrl.blogUC.RemoveReaction(blogID, userID.(string), reactionType)
+	if err != nil {
+		c.JSON(reactionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// reactionErrorStatus maps reaction errors to HTTP statuses; the ledger reports a blog deleted
+// since it was looked up as not found
+func reactionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrBlogNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrInvalidReaction):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// dateQueryParam parses an optional YYYY-MM-DD query parameter
+func dateQueryParam(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, errors.New(name + " must be a date formatted as YYYY-MM-DD")
+	}
+	return &date, nil
+}
+
+// minQueryParam parses an optional non-negative integer threshold
+func minQueryParam(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, errors.New(name + " must be a non-negative integer")
+	}
+	return number, nil
+}
+
+// isAuthorOrAdmin reports whether the caller owns the resource or holds an admin role
+func (ctrl *BlogController) isAuthorOrAdmin(c *gin.Context, authorID string) bool {
+	userID, exists := c.Get("userID")
+	if exists && userID.(string) == authorID {
+		return true
+	}
+
+	role, exists := c.Get("role")
+	return exists && (role.(string) == "admin" || role.(string) == "superadmin")
+}
+
+// revisionAccess loads the blog from the path and only lets its author or an admin through
+func (ctrl *BlogController) revisionAccess(c *gin.Context) (models.Blog, bool) {
+	blogID := c.Param("id")
+	if blogID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+		return models.Blog{}, false
+	}
+
+	blog, err := ctrl.blogUC.GetBlogByID(blogID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return models.Blog{}, false
+	}
+
+	if !ctrl.isAuthorOrAdmin(c, blog.AuthorID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author or an admin can view revision history"})
+		return models.Blog{}, false
+	}
+
+	return blog, true
+}
+
+// visibleBlogID returns the blog ID from the path when the caller may see that blog. Drafts
+// look missing to everyone but their author and admins, as they do in GetBlogByID.
+func (ctrl *BlogController) visibleBlogID(c *gin.Context) (string, bool) {
+	blogID := c.Param("id")
+	if blogID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Blog ID is required"})
+		return "", false
+	}
+
+	blog, err := ctrl.blogUC.GetBlogByID(blogID)
+	if err != nil || (!blog.IsPublished && !ctrl.isAuthorOrAdmin(c, blog.AuthorID)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blog not found"})
+		return "", false
+	}
+
+	return blogID, true
+}
+
 func (ctrl *BlogController) blogToResponse(blog models.Blog) BlogResponse {
-	var commentResponses []CommentResponse
-	for _, comment := range blog.Comments {
-		commentResponses = append(commentResponses, ctrl.commentToResponse(comment))
+	response := BlogResponse{
+		ID:           blog.ID,
+		Title:        blog.Title,
+		Slug:         blog.Slug,
+		Content:      blog.Content,
+		AuthorID:     blog.AuthorID,
+		AuthorName:   blog.AuthorName,
+		Tags:         blog.Tags,
+		ViewCount:    blog.ViewCount,
+		Likes:        blog.Likes,
+		Dislikes:     blog.Dislikes,
+		CommentCount: blog.CommentCount,
+		IsPublished:  blog.IsPublished,
+		Version:      blog.Version,
+		CreatedAt:    blog.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    blog.UpdatedAt.Format(time.RFC3339),
+
+		SourceSuggestionID: blog.SourceSuggestionID,
+		ModerationStatus:   blog.ModerationStatus,
 	}
 
-	return BlogResponse{
-		ID:          blog.ID,
-		Title:       blog.Title,
-		Content:     blog.Content,
-		AuthorID:    blog.AuthorID,
-		AuthorName:  blog.AuthorName,
-		Tags:        blog.Tags,
-		ViewCount:   blog.ViewCount,
-		Likes:       blog.Likes,
-		Dislikes:    blog.Dislikes,
-		Comments:    commentResponses,
-		IsPublished: blog.IsPublished,
-		CreatedAt:   blog.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   blog.UpdatedAt.Format(time.RFC3339),
+	if blog.PublishAt != nil {
+		response.PublishAt = blog.PublishAt.Format(time.RFC3339)
 	}
+
+	return response
 }
 
 func (ctrl *BlogController) commentToResponse(comment models.Comment) CommentResponse {
-	return CommentResponse{
+	var replies []CommentResponse
+	for _, reply := range comment.Replies {
+		replies = append(replies, ctrl.commentToResponse(reply))
+	}
+
+	response := CommentResponse{
 		ID:         comment.ID,
 		BlogID:     comment.BlogID,
+		ParentID:   comment.ParentID,
+		Depth:      comment.Depth,
 		AuthorID:   comment.AuthorID,
 		AuthorName: comment.AuthorName,
 		Content:    comment.Content,
+		IsDeleted:  comment.IsDeleted,
+		Replies:    replies,
 		CreatedAt:  comment.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:  comment.UpdatedAt.Format(time.RFC3339),
+
+		ModerationStatus: comment.ModerationStatus,
+	}
+
+	// Tombstones keep their place in the thread but reveal nothing about the author
+	if comment.IsDeleted {
+		response.AuthorID = ""
+		response.AuthorName = ""
+		response.Content = "[deleted]"
+	}
+
+	return response
+}
+
+func (ctrl *BlogController) revisionToResponse(revision models.BlogRevision) RevisionResponse {
+	return RevisionResponse{
+		Version:   revision.Version,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Tags:      revision.Tags,
+		IsCurrent: revision.IsCurrent,
+		CreatedAt: revision.CreatedAt.Format(time.RFC3339),
 	}
 }
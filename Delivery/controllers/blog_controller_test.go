@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"blog-api/Domain/models"
+	"blog-api/Infrastructure/utils"
 	"blog-api/mocks"
 	"bytes"
 	"encoding/json"
@@ -196,7 +197,7 @@ func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_Success() {
 	}
 
 	// Setup mock
-	suite.mockUC.On("GetPaginatedBlogs", 1, 10).Return(expectedBlogs, nil)
+	suite.mockUC.On("GetPaginatedBlogs", 1, 10).Return(expectedBlogs, int64(25), nil)
 
 	// Setup route
 	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
@@ -217,11 +218,146 @@ func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_Success() {
 	assert.Len(suite.T(), response.Blogs, 2)
 	assert.Equal(suite.T(), 1, response.Page)
 	assert.Equal(suite.T(), 10, response.Limit)
+	assert.Equal(suite.T(), int64(25), *response.Total)
+	assert.Equal(suite.T(), 3, response.TotalPages)
+}
+
+func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_DefaultsToOffsetPages() {
+	// Setup mock
+	suite.mockUC.On("GetPaginatedBlogs", 1, 10).Return([]models.Blog{{ID: "blog1", Title: "Blog 1", IsPublished: true}}, int64(1), nil)
+
+	// Setup route
+	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "GetBlogsByCursor", mock.Anything)
+
+	var response PaginatedBlogsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, response.Page)
+	assert.Equal(suite.T(), 1, response.TotalPages)
+	assert.Equal(suite.T(), int64(1), *response.Total)
+}
+
+func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_LimitKeepsOffsetPages() {
+	// Setup mock
+	suite.mockUC.On("GetPaginatedBlogs", 1, 5).Return([]models.Blog{{ID: "blog1", Title: "Blog 1", IsPublished: true}}, int64(6), nil)
+
+	// Setup route
+	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs?limit=5", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "GetBlogsByCursor", mock.Anything)
+
+	var response PaginatedBlogsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, response.Page)
+	assert.Equal(suite.T(), 5, response.Limit)
+	assert.Equal(suite.T(), 2, response.TotalPages)
+	assert.Equal(suite.T(), int64(6), *response.Total)
+}
+
+func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_CursorOptIn() {
+	// Setup mock
+	suite.mockUC.On("GetBlogsByCursor", models.PageRequest{Limit: 5}).Return([]models.Blog{{ID: "blog1", IsPublished: true}}, models.PageInfo{}, nil)
+
+	// Setup route
+	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs?pagination=cursor&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "GetPaginatedBlogs", mock.Anything, mock.Anything)
+}
+
+func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_Cursor() {
+	// Test data
+	createdAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	cursor := &models.Cursor{CreatedAt: createdAt, ID: "blog1"}
+	expectedBlogs := []models.Blog{{ID: "blog2", Title: "Blog 2", IsPublished: true, CreatedAt: createdAt}}
+	info := models.PageInfo{
+		Next: &models.Cursor{CreatedAt: createdAt, ID: "blog2"},
+		Prev: &models.Cursor{CreatedAt: createdAt, ID: "blog2", Backward: true},
+	}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogsByCursor", mock.MatchedBy(func(page models.PageRequest) bool {
+		return page.Limit == 1 && !page.WithTotal && page.Cursor != nil &&
+			page.Cursor.ID == "blog1" && page.Cursor.CreatedAt.Equal(createdAt)
+	})).Return(expectedBlogs, info, nil)
+
+	// Setup route
+	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs?limit=1&cursor="+utils.EncodeCursor(cursor), nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response PaginatedBlogsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Blogs, 1)
+	assert.Nil(suite.T(), response.Total)
+
+	next, err := utils.DecodeCursor(response.NextCursor)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "blog2", next.ID)
+	assert.False(suite.T(), next.Backward)
+
+	prev, err := utils.DecodeCursor(response.PrevCursor)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), prev.Backward)
+}
+
+func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_InvalidCursor() {
+	// Setup route
+	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs?cursor=not-a-cursor", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "GetBlogsByCursor", mock.Anything)
 }
 
 func (suite *BlogControllerTestSuite) TestGetPaginatedBlogs_UseCaseError() {
 	// Setup mock to return error
-	suite.mockUC.On("GetPaginatedBlogs", 1, 10).Return(nil, errors.New("database error"))
+	suite.mockUC.On("GetPaginatedBlogs", 1, 10).Return(nil, int64(0), errors.New("database error"))
 
 	// Setup route
 	suite.router.GET("/blogs", suite.controller.GetPaginatedBlogs)
@@ -293,6 +429,157 @@ func (suite *BlogControllerTestSuite) TestGetBlogByID_NotFound() {
 	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
 }
 
+func (suite *BlogControllerTestSuite) TestGetBlogByID_DraftHiddenFromOthers() {
+	// Test data
+	draft := models.Blog{ID: "blog123", Title: "Draft", AuthorID: "user123", IsPublished: false}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(draft, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id", func(c *gin.Context) {
+		c.Set("userID", "differentUser")
+		c.Set("role", "user")
+		suite.controller.GetBlogByID(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "IncrementViewCount", "blog123")
+}
+
+func (suite *BlogControllerTestSuite) TestGetBlogByID_DraftVisibleToAuthor() {
+	// Test data
+	draft := models.Blog{ID: "blog123", Title: "Draft", AuthorID: "user123", IsPublished: false}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(draft, nil)
+	suite.mockUC.On("IncrementViewCount", "blog123").Return(nil).Maybe()
+
+	// Setup route
+	suite.router.GET("/blogs/:id", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		c.Set("role", "user")
+		suite.controller.GetBlogByID(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestGetMyBlogs_Success() {
+	// Test data
+	blogs := []models.Blog{
+		{ID: "blog1", Title: "Draft", AuthorID: "user123", IsPublished: false},
+	}
+
+	// Setup mock
+	suite.mockUC.On("GetMyBlogs", "user123", models.BlogStatusDraft, 1, 10).Return(blogs, int64(1), nil)
+
+	// Setup route
+	suite.router.GET("/blogs/mine", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.GetMyBlogs(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/mine?status=draft", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), float64(1), response["total"])
+	assert.Len(suite.T(), response["blogs"], 1)
+}
+
+func (suite *BlogControllerTestSuite) TestGetMyBlogs_InvalidStatus() {
+	// Setup route
+	suite.router.GET("/blogs/mine", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.GetMyBlogs(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/mine?status=archived", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestGetBlogBySlug_Success() {
+	// Test data
+	blog := models.Blog{ID: "blog123", Title: "Hello World", Slug: "hello-world", IsPublished: true}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogBySlug", "hello-world").Return(blog, nil)
+	suite.mockUC.On("IncrementViewCount", "blog123").Return(nil).Maybe()
+
+	// Setup route
+	suite.router.GET("/blogs/by-slug/:slug", suite.controller.GetBlogBySlug)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/by-slug/hello-world", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response BlogResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "hello-world", response.Slug)
+}
+
+func (suite *BlogControllerTestSuite) TestGetBlogBySlug_OldSlugRedirects() {
+	// Test data
+	blog := models.Blog{ID: "blog123", Title: "Hello Again", Slug: "hello-again", IsPublished: true}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogBySlug", "hello-world").Return(blog, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/by-slug/:slug", suite.controller.GetBlogBySlug)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/by-slug/hello-world", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusMovedPermanently, w.Code)
+	assert.Equal(suite.T(), "/blogs/by-slug/hello-again", w.Header().Get("Location"))
+}
+
 func (suite *BlogControllerTestSuite) TestUpdateBlog_Success() {
 	// Test data
 	requestBody := UpdateBlogRequest{
@@ -447,27 +734,35 @@ func (suite *BlogControllerTestSuite) TestDeleteBlog_Forbidden() {
 
 func (suite *BlogControllerTestSuite) TestSearchBlogs_Success() {
 	// Test data
-	expectedBlogs := []models.Blog{
+	expectedResults := []models.BlogSearchResult{
 		{
-			ID:          "blog1",
-			Title:       "Go Programming",
-			Content:     "Learn Go programming",
-			AuthorID:    "user1",
-			AuthorName:  "user1@test.com",
-			IsPublished: true,
+			Blog: models.Blog{
+				ID:          "blog1",
+				Title:       "Go Programming",
+				Content:     "Learn Go programming",
+				AuthorID:    "user1",
+				AuthorName:  "user1@test.com",
+				IsPublished: true,
+			},
+			Score:      3.2,
+			Highlights: []string{"Learn <mark>Go</mark> programming"},
 		},
 		{
-			ID:          "blog2",
-			Title:       "Web Development with Go",
-			Content:     "Learn web development",
-			AuthorID:    "user2",
-			AuthorName:  "user2@test.com",
-			IsPublished: true,
+			Blog: models.Blog{
+				ID:          "blog2",
+				Title:       "Web Development with Go",
+				Content:     "Learn web development",
+				AuthorID:    "user2",
+				AuthorName:  "user2@test.com",
+				IsPublished: true,
+			},
+			Score:      1.5,
+			Highlights: []string{"Learn web development"},
 		},
 	}
 
 	// Setup mock
-	suite.mockUC.On("SearchBlogs", "Go").Return(expectedBlogs, nil)
+	suite.mockUC.On("SearchBlogs", "Go", 1, 10).Return(expectedResults, int64(2), nil)
 
 	// Setup route
 	suite.router.GET("/blogs/search", suite.controller.SearchBlogs)
@@ -486,6 +781,11 @@ func (suite *BlogControllerTestSuite) TestSearchBlogs_Success() {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "Go", response["query"])
+	assert.Equal(suite.T(), float64(2), response["total"])
+
+	first := response["blogs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(suite.T(), "blog1", first["id"])
+	assert.Equal(suite.T(), 3.2, first["score"])
 }
 
 func (suite *BlogControllerTestSuite) TestSearchBlogs_MissingQuery() {
@@ -503,6 +803,80 @@ func (suite *BlogControllerTestSuite) TestSearchBlogs_MissingQuery() {
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
 
+func (suite *BlogControllerTestSuite) TestQueryBlogs_Success() {
+	// Test data
+	result := models.BlogQueryResult{
+		Blogs: []models.Blog{{ID: "blog1", Title: "Go Tips", Tags: []string{"go", "tips"}, IsPublished: true}},
+		Total: 1,
+		Facets: models.BlogFacets{
+			Tags:    []models.FacetCount{{Value: "go", Count: 1}, {Value: "tips", Count: 1}},
+			Authors: []models.FacetCount{{Value: "user1", Label: "user1@test.com", Count: 1}},
+			Months:  []models.FacetCount{{Value: "2025-01", Count: 1}},
+		},
+	}
+
+	// Setup mock
+	suite.mockUC.On("QueryBlogs", mock.MatchedBy(func(q models.BlogQuery) bool {
+		return q.Text == "tips" &&
+			len(q.Tags) == 2 && q.Tags[0] == "go" && q.Tags[1] == "tips" &&
+			q.TagMatch == models.TagMatchAll &&
+			q.AuthorID == "user1" &&
+			q.From.Format("2006-01-02") == "2025-01-01" &&
+			q.To.Format("2006-01-02") == "2025-02-01" &&
+			q.MinLikes == 5 &&
+			q.SortBy == "likes" && q.SortOrder == 1
+	})).Return(result, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/query", suite.controller.QueryBlogs)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/query?q=tips&tags=go,tips&tag_match=all&author=user1&from=2025-01-01&to=2025-01-31&min_likes=5&sort_by=likes&order=asc", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Blogs  []BlogResponse    `json:"blogs"`
+		Total  int64             `json:"total"`
+		Facets models.BlogFacets `json:"facets"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Blogs, 1)
+	assert.Equal(suite.T(), int64(1), response.Total)
+	assert.Len(suite.T(), response.Facets.Tags, 2)
+	assert.Equal(suite.T(), "user1@test.com", response.Facets.Authors[0].Label)
+}
+
+func (suite *BlogControllerTestSuite) TestQueryBlogs_InvalidParams() {
+	// Setup route
+	suite.router.GET("/blogs/query", suite.controller.QueryBlogs)
+
+	for _, query := range []string{
+		"from=01-01-2025",
+		"from=2025-02-01&to=2025-01-01",
+		"tag_match=some",
+		"sort_by=author_id",
+		"order=sideways",
+		"min_views=-1",
+	} {
+		// Create request
+		req, _ := http.NewRequest("GET", "/blogs/query?"+query, nil)
+		w := httptest.NewRecorder()
+
+		// Execute request
+		suite.router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, query)
+	}
+}
+
 func (suite *BlogControllerTestSuite) TestFilterBlogs_Success() {
 	// Test data
 	expectedBlogs := []models.Blog{
@@ -559,10 +933,14 @@ func (suite *BlogControllerTestSuite) TestIncrementViewCount_Success() {
 
 func (suite *BlogControllerTestSuite) TestLikeBlog_Success() {
 	// Setup mock
-	suite.mockUC.On("UpdateLikes", "blog123", true).Return(nil)
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("ReactToBlog", "blog123", "user123", models.ReactionLike).Return(models.Reaction{Type: models.ReactionLike}, nil)
 
 	// Setup route
-	suite.router.POST("/blogs/:id/like", suite.controller.LikeBlog)
+	suite.router.POST("/blogs/:id/like", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.LikeBlog(c)
+	})
 
 	// Create request
 	req, _ := http.NewRequest("POST", "/blogs/blog123/like", nil)
@@ -577,10 +955,14 @@ func (suite *BlogControllerTestSuite) TestLikeBlog_Success() {
 
 func (suite *BlogControllerTestSuite) TestUnlikeBlog_Success() {
 	// Setup mock
-	suite.mockUC.On("UpdateLikes", "blog123", false).Return(nil)
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("RemoveReaction", "blog123", "user123", models.ReactionLike).Return(nil)
 
 	// Setup route
-	suite.router.DELETE("/blogs/:id/like", suite.controller.UnlikeBlog)
+	suite.router.DELETE("/blogs/:id/like", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.UnlikeBlog(c)
+	})
 
 	// Create request
 	req, _ := http.NewRequest("DELETE", "/blogs/blog123/like", nil)
@@ -595,10 +977,14 @@ func (suite *BlogControllerTestSuite) TestUnlikeBlog_Success() {
 
 func (suite *BlogControllerTestSuite) TestDislikeBlog_Success() {
 	// Setup mock
-	suite.mockUC.On("UpdateDislikes", "blog123", true).Return(nil)
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("ReactToBlog", "blog123", "user123", models.ReactionDislike).Return(models.Reaction{Type: models.ReactionDislike}, nil)
 
 	// Setup route
-	suite.router.POST("/blogs/:id/dislike", suite.controller.DislikeBlog)
+	suite.router.POST("/blogs/:id/dislike", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.DislikeBlog(c)
+	})
 
 	// Create request
 	req, _ := http.NewRequest("POST", "/blogs/blog123/dislike", nil)
@@ -613,10 +999,14 @@ func (suite *BlogControllerTestSuite) TestDislikeBlog_Success() {
 
 func (suite *BlogControllerTestSuite) TestRemoveDislike_Success() {
 	// Setup mock
-	suite.mockUC.On("UpdateDislikes", "blog123", false).Return(nil)
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("RemoveReaction", "blog123", "user123", models.ReactionDislike).Return(nil)
 
 	// Setup route
-	suite.router.DELETE("/blogs/:id/dislike", suite.controller.RemoveDislike)
+	suite.router.DELETE("/blogs/:id/dislike", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.RemoveDislike(c)
+	})
 
 	// Create request
 	req, _ := http.NewRequest("DELETE", "/blogs/blog123/dislike", nil)
@@ -629,12 +1019,98 @@ func (suite *BlogControllerTestSuite) TestRemoveDislike_Success() {
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 }
 
-func (suite *BlogControllerTestSuite) TestAddComment_Success() {
-	// Test data
-	requestBody := CommentRequest{
-		Content: "Great blog post!",
-	}
-	jsonBody, _ := json.Marshal(requestBody)
+func (suite *BlogControllerTestSuite) TestLikeBlog_Draft() {
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: false}, nil)
+
+	// Setup route
+	suite.router.POST("/blogs/:id/like", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.LikeBlog(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/like", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "ReactToBlog", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BlogControllerTestSuite) TestLikeBlog_DeletedMeanwhile() {
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("ReactToBlog", "blog123", "user123", models.ReactionLike).Return(models.Reaction{}, models.ErrBlogNotFound)
+
+	// Setup route
+	suite.router.POST("/blogs/:id/like", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.LikeBlog(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/like", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestLikeBlog_Unauthorized() {
+	// Setup route without user context
+	suite.router.POST("/blogs/:id/like", suite.controller.LikeBlog)
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/like", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestGetReaction_Success() {
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("GetUserReaction", "blog123", "user123").Return(models.ReactionLike, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/reaction", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.GetReaction(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/reaction", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.ReactionLike, response["reaction"])
+}
+
+func (suite *BlogControllerTestSuite) TestAddComment_Success() {
+	// Test data
+	requestBody := CommentRequest{
+		Content: "Great blog post!",
+	}
+	jsonBody, _ := json.Marshal(requestBody)
 
 	expectedComment := models.Comment{
 		ID:         "comment123",
@@ -647,6 +1123,7 @@ func (suite *BlogControllerTestSuite) TestAddComment_Success() {
 	}
 
 	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
 	suite.mockUC.On("AddComment", "blog123", mock.AnythingOfType("models.Comment")).Return(expectedComment, nil)
 
 	// Setup route
@@ -698,13 +1175,14 @@ func (suite *BlogControllerTestSuite) TestGetComments_Success() {
 	}
 
 	// Setup mock
-	suite.mockUC.On("GetComments", "blog123").Return(expectedComments, nil)
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("GetComments", "blog123", 1, 20).Return(expectedComments, int64(2), nil)
 
 	// Setup route
 	suite.router.GET("/blogs/:id/comments", suite.controller.GetComments)
 
 	// Create request
-	req, _ := http.NewRequest("GET", "/blogs/blog123/comments", nil)
+	req, _ := http.NewRequest("GET", "/blogs/blog123/comments?page=1", nil)
 	w := httptest.NewRecorder()
 
 	// Execute request
@@ -717,6 +1195,197 @@ func (suite *BlogControllerTestSuite) TestGetComments_Success() {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response["comments"])
+	assert.Equal(suite.T(), float64(2), response["total"])
+}
+
+func (suite *BlogControllerTestSuite) TestGetComments_Cursor() {
+	// Test data
+	expectedComments := []models.Comment{{ID: "comment1", BlogID: "blog123", Content: "Comment 1"}}
+	total := int64(4)
+	info := models.PageInfo{Next: &models.Cursor{ID: "comment1"}, Total: &total}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}, nil)
+	suite.mockUC.On("GetCommentsByCursor", "blog123", models.PageRequest{Limit: 1, WithTotal: true}).Return(expectedComments, info, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/comments", suite.controller.GetComments)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/comments?pagination=cursor&limit=1&with_total=true", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response CommentPageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Comments, 1)
+	assert.Equal(suite.T(), int64(4), *response.Total)
+	assert.NotEmpty(suite.T(), response.NextCursor)
+	assert.Empty(suite.T(), response.PrevCursor)
+}
+
+func (suite *BlogControllerTestSuite) TestAddComment_Draft() {
+	// Test data
+	jsonBody, _ := json.Marshal(CommentRequest{Content: "First!"})
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: false}, nil)
+
+	// Setup route
+	suite.router.POST("/blogs/:id/comments", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		c.Set("email", "test@example.com")
+		suite.controller.AddComment(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/comments", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "AddComment", mock.Anything, mock.Anything)
+}
+
+func (suite *BlogControllerTestSuite) TestGetComments_Draft() {
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: false}, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/comments", suite.controller.GetComments)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/comments?page=1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "GetComments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BlogControllerTestSuite) TestGetComments_DraftVisibleToAuthor() {
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: false}, nil)
+	suite.mockUC.On("GetComments", "blog123", 1, 20).Return([]models.Comment{}, int64(0), nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/comments", func(c *gin.Context) {
+		c.Set("userID", "author123")
+		suite.controller.GetComments(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/comments?page=1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestUpdateComment_Success() {
+	// Test data
+	requestBody := CommentRequest{Content: "Edited comment"}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	existingComment := models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "Original"}
+	updatedComment := models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "Edited comment"}
+
+	// Setup mock
+	suite.mockUC.On("GetCommentByID", "comment123").Return(existingComment, nil)
+	suite.mockUC.On("UpdateComment", "comment123", "Edited comment").Return(updatedComment, nil)
+
+	// Setup route
+	suite.router.PUT("/blogs/:id/comments/:commentId", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		c.Set("role", "user")
+		suite.controller.UpdateComment(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/blogs/blog123/comments/comment123", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response CommentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Edited comment", response.Content)
+}
+
+func (suite *BlogControllerTestSuite) TestUpdateComment_Forbidden() {
+	// Test data
+	requestBody := CommentRequest{Content: "Edited comment"}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	existingComment := models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "Original"}
+
+	// Setup mock
+	suite.mockUC.On("GetCommentByID", "comment123").Return(existingComment, nil)
+
+	// Setup route
+	suite.router.PUT("/blogs/:id/comments/:commentId", func(c *gin.Context) {
+		c.Set("userID", "differentUser")
+		c.Set("role", "user")
+		suite.controller.UpdateComment(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/blogs/blog123/comments/comment123", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestDeleteComment_AdminSuccess() {
+	existingComment := models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "Spam"}
+
+	// Setup mock
+	suite.mockUC.On("GetCommentByID", "comment123").Return(existingComment, nil)
+	suite.mockUC.On("DeleteComment", "comment123").Return(nil)
+
+	// Setup route
+	suite.router.DELETE("/blogs/:id/comments/:commentId", func(c *gin.Context) {
+		c.Set("userID", "admin123")
+		c.Set("role", "admin")
+		suite.controller.DeleteComment(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/blogs/blog123/comments/comment123", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
 }
 
 // Helper function to create bool pointer
@@ -724,6 +1393,221 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func (suite *BlogControllerTestSuite) TestCreateBlog_PublishAtInPast() {
+	// Test data
+	past := time.Now().Add(-time.Hour)
+	requestBody := CreateBlogRequest{
+		Title:     "Test Blog",
+		Content:   "Test Content",
+		PublishAt: &past,
+	}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	// Setup route
+	suite.router.POST("/blogs", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		c.Set("email", "test@example.com")
+		suite.controller.CreateBlog(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "CreateBlog", mock.Anything)
+}
+
+func (suite *BlogControllerTestSuite) TestGetScheduledBlogs_Success() {
+	publishAt := time.Now().Add(time.Hour)
+	scheduled := []models.Blog{
+		{ID: "blog123", Title: "Coming soon", AuthorID: "user123", PublishAt: &publishAt},
+	}
+
+	// Setup mock
+	suite.mockUC.On("GetScheduledBlogs", "user123").Return(scheduled, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/scheduled", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.GetScheduledBlogs(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/scheduled", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response map[string][]BlogResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response["blogs"], 1)
+	assert.Equal(suite.T(), publishAt.Format(time.RFC3339), response["blogs"][0].PublishAt)
+}
+
+func (suite *BlogControllerTestSuite) TestGetRevisions_Success() {
+	blog := models.Blog{ID: "blog123", AuthorID: "user123", Version: 2}
+	revisions := []models.BlogRevision{
+		{BlogID: "blog123", Version: 2, Title: "Current", IsCurrent: true},
+		{BlogID: "blog123", Version: 1, Title: "First"},
+	}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(blog, nil)
+	suite.mockUC.On("GetRevisions", "blog123").Return(revisions, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/revisions", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		c.Set("role", "user")
+		suite.controller.GetRevisions(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/revisions", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response map[string][]RevisionResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response["revisions"], 2)
+	assert.True(suite.T(), response["revisions"][0].IsCurrent)
+}
+
+func (suite *BlogControllerTestSuite) TestGetRevisions_Forbidden() {
+	blog := models.Blog{ID: "blog123", AuthorID: "user123", Version: 2}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(blog, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/revisions", func(c *gin.Context) {
+		c.Set("userID", "differentUser")
+		c.Set("role", "user")
+		suite.controller.GetRevisions(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/revisions", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestDiffRevisions_Success() {
+	blog := models.Blog{ID: "blog123", AuthorID: "user123", Version: 2}
+	diff := models.RevisionDiff{
+		BlogID:      "blog123",
+		FromVersion: 1,
+		ToVersion:   2,
+		Lines:       []models.DiffLine{{Op: models.DiffInsert, Text: "new line"}},
+		Added:       1,
+	}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(blog, nil)
+	suite.mockUC.On("DiffRevisions", "blog123", 1, 2).Return(diff, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/diff", func(c *gin.Context) {
+		c.Set("userID", "admin1")
+		c.Set("role", "admin")
+		suite.controller.DiffRevisions(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/diff?from=1&to=2", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response models.RevisionDiff
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, response.Added)
+	assert.Equal(suite.T(), models.DiffInsert, response.Lines[0].Op)
+}
+
+func (suite *BlogControllerTestSuite) TestDiffRevisions_MissingVersions() {
+	blog := models.Blog{ID: "blog123", AuthorID: "user123", Version: 2}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(blog, nil)
+
+	// Setup route
+	suite.router.GET("/blogs/:id/diff", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.DiffRevisions(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/blogs/blog123/diff?from=1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *BlogControllerTestSuite) TestRestoreRevision_Success() {
+	blog := models.Blog{ID: "blog123", AuthorID: "user123", Title: "Current", Version: 2}
+	revision := models.BlogRevision{BlogID: "blog123", Version: 1, Title: "First"}
+	restoredBlog := models.Blog{ID: "blog123", AuthorID: "user123", Title: "First", Version: 3}
+
+	// Setup mock
+	suite.mockUC.On("GetBlogByID", "blog123").Return(blog, nil)
+	suite.mockUC.On("GetRevision", "blog123", 1).Return(revision, nil)
+	suite.mockUC.On("RestoreRevision", "blog123", 1).Return(restoredBlog, nil)
+
+	// Setup route
+	suite.router.POST("/blogs/:id/revisions/:version/restore", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.RestoreRevision(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/revisions/1/restore", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response BlogResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "First", response.Title)
+	assert.Equal(suite.T(), 3, response.Version)
+}
+
 // Run the test suite
 func TestBlogControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(BlogControllerTestSuite))
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkController struct {
+	bookmarkUC interfaces.BookmarkUseCase
+}
+
+func NewBookmarkController(bookmarkUC interfaces.BookmarkUseCase) *BookmarkController {
+	return &BookmarkController{bookmarkUC: bookmarkUC}
+}
+
+// GetCollections lists the user's bookmark collections
+func (ctrl *BookmarkController) GetCollections(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	collections, err := ctrl.bookmarkUC.GetCollections(userID.(string))
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve collections: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Collections retrieved successfully", collections)
+}
+
+// CreateCollection creates a named bookmark collection
+func (ctrl *BookmarkController) CreateCollection(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req BookmarkCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	collection, err := ctrl.bookmarkUC.CreateCollection(userID.(string), req.Name)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to create collection: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Collection created successfully", collection)
+}
+
+// RenameCollection renames one of the user's collections
+func (ctrl *BookmarkController) RenameCollection(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req BookmarkCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	collection, err := ctrl.bookmarkUC.RenameCollection(userID.(string), c.Param("id"), req.Name)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to rename collection: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Collection renamed successfully", collection)
+}
+
+// DeleteCollection deletes one of the user's collections and its bookmarks
+func (ctrl *BookmarkController) DeleteCollection(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := ctrl.bookmarkUC.DeleteCollection(userID.(string), c.Param("id")); err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to delete collection: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Collection deleted successfully", nil)
+}
+
+// GetBookmarks lists a page of a collection in its order; unread=true leaves out posts already read
+func (ctrl *BookmarkController) GetBookmarks(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	bookmarks, total, err := ctrl.bookmarkUC.GetBookmarks(userID.(string), c.Param("id"), c.Query("unread") == "true", page, limit)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to retrieve bookmarks: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Bookmarks retrieved successfully", BookmarkListResponse{
+		Bookmarks:  bookmarks,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	})
+}
+
+// ReorderBookmarks puts a collection's bookmarks in the given order
+func (ctrl *BookmarkController) ReorderBookmarks(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req ReorderBookmarksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	if err := ctrl.bookmarkUC.ReorderBookmarks(userID.(string), c.Param("id"), req.BookmarkIDs); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to reorder bookmarks: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Bookmarks reordered successfully", nil)
+}
+
+// AddBookmark saves a post to a collection
+func (ctrl *BookmarkController) AddBookmark(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req AddBookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	bookmark, err := ctrl.bookmarkUC.AddBookmark(userID.(string), req.BlogID, req.CollectionID)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to bookmark blog: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Blog bookmarked successfully", bookmark)
+}
+
+// MarkRead marks a bookmarked post read or unread
+func (ctrl *BookmarkController) MarkRead(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req MarkReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	bookmark, err := ctrl.bookmarkUC.MarkRead(userID.(string), c.Param("id"), *req.Read)
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to update bookmark: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Bookmark updated successfully", bookmark)
+}
+
+// RemoveBookmark removes a post from its collection
+func (ctrl *BookmarkController) RemoveBookmark(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := ctrl.bookmarkUC.RemoveBookmark(userID.(string), c.Param("id")); err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to remove bookmark: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Bookmark removed successfully", nil)
+}
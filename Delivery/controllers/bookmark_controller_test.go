@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BookmarkControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *BookmarkController
+	mockUC     *mocks.BookmarkUseCaseMock
+}
+
+func (suite *BookmarkControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.BookmarkUseCaseMock{}
+	suite.controller = NewBookmarkController(suite.mockUC)
+}
+
+func (suite *BookmarkControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *BookmarkControllerTestSuite) TestAddBookmark() {
+	// Setup mock
+	suite.mockUC.On("AddBookmark", "reader123", "blog123", "").
+		Return(models.Bookmark{ID: "bookmark123", CollectionID: "collection123", BlogID: "blog123"}, nil)
+
+	// Setup route
+	suite.router.POST("/bookmarks", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.AddBookmark(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/bookmarks", bytes.NewBufferString(`{"blog_id": "blog123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"collection_id":"collection123"`)
+}
+
+func (suite *BookmarkControllerTestSuite) TestAddBookmark_AlreadySaved() {
+	// Setup mock
+	suite.mockUC.On("AddBookmark", "reader123", "blog123", "collection123").
+		Return(models.Bookmark{}, errors.New("post is already in this collection"))
+
+	// Setup route
+	suite.router.POST("/bookmarks", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.AddBookmark(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/bookmarks", bytes.NewBufferString(`{"blog_id": "blog123", "collection_id": "collection123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "already in this collection")
+}
+
+func (suite *BookmarkControllerTestSuite) TestGetBookmarks_Unread() {
+	// Setup mock
+	bookmarks := []models.Bookmark{{ID: "bookmark123", BlogID: "blog123", Blog: &models.Blog{ID: "blog123", Title: "Saved post"}}}
+	suite.mockUC.On("GetBookmarks", "reader123", "collection123", true, 1, 10).Return(bookmarks, int64(11), nil)
+
+	// Setup route
+	suite.router.GET("/bookmarks/collections/:id", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.GetBookmarks(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/bookmarks/collections/collection123?unread=true", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "Saved post")
+	assert.Contains(suite.T(), w.Body.String(), `"total_pages":2`)
+}
+
+func (suite *BookmarkControllerTestSuite) TestReorderBookmarks() {
+	// Setup mock
+	suite.mockUC.On("ReorderBookmarks", "reader123", "collection123", []string{"b2", "b1"}).Return(nil)
+
+	// Setup route
+	suite.router.PUT("/bookmarks/collections/:id/order", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.ReorderBookmarks(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/bookmarks/collections/collection123/order", bytes.NewBufferString(`{"bookmark_ids": ["b2", "b1"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *BookmarkControllerTestSuite) TestMarkRead_MissingRead() {
+	// Setup route
+	suite.router.PUT("/bookmarks/:id/read", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.MarkRead(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/bookmarks/bookmark123/read", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "MarkRead", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *BookmarkControllerTestSuite) TestMarkRead_Unread() {
+	// Setup mock
+	suite.mockUC.On("MarkRead", "reader123", "bookmark123", false).Return(models.Bookmark{ID: "bookmark123", IsRead: false}, nil)
+
+	// Setup route
+	suite.router.PUT("/bookmarks/:id/read", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.MarkRead(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("PUT", "/bookmarks/bookmark123/read", bytes.NewBufferString(`{"read": false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"is_read":false`)
+}
+
+// Run the test suite
+func TestBookmarkControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(BookmarkControllerTestSuite))
+}
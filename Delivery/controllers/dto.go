@@ -1,6 +1,12 @@
 // controllers/dto.go
 package controllers
 
+import (
+	"blog-api/Domain/models"
+	"blog-api/Infrastructure/utils"
+	"time"
+)
+
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=50"`
 	Email    string `json:"email" binding:"required,email"`
@@ -17,55 +23,202 @@ type EmailRequest struct {
 
 // Blog DTOs
 type CreateBlogRequest struct {
-	Title       string   `json:"title" binding:"required"`
-	Content     string   `json:"content" binding:"required"`
-	Tags        []string `json:"tags"`
-	IsPublished bool     `json:"is_published"`
+	Title       string     `json:"title" binding:"required"`
+	Content     string     `json:"content" binding:"required"`
+	Tags        []string   `json:"tags"`
+	IsPublished bool       `json:"is_published"`
+	PublishAt   *time.Time `json:"publish_at"`
+}
+
+// Tag DTOs
+type SuggestTagsRequest struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" binding:"required,min=1"`
+	Target  string   `json:"target" binding:"required"`
+}
+
+// ReviewModerationRequest carries the reviewer's note, which is passed on to the author
+type ReviewModerationRequest struct {
+	Note string `json:"note"`
+}
+
+// ModerationQueueResponse is a page of the moderation queue
+type ModerationQueueResponse struct {
+	Items      []models.ModerationItem `json:"items"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+	Total      int64                   `json:"total"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+// ReportContentRequest is a reader's report about a post or comment
+type ReportContentRequest struct {
+	Reason  string `json:"reason" binding:"required"`
+	Details string `json:"details"`
+}
+
+// ResolveReportRequest is an admin's decision on reported content. SuspendDays is required when
+// suspending the author.
+type ResolveReportRequest struct {
+	Action      string `json:"action" binding:"required"`
+	Note        string `json:"note"`
+	SuspendDays int    `json:"suspend_days"`
+}
+
+// ReportListResponse is a page of reader reports
+type ReportListResponse struct {
+	Reports    []models.Report `json:"reports"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+	Total      int64           `json:"total"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// RefreshTokenRequest identifies the caller's own session by its refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// MFALoginRequest finishes a login with the challenge from /login and a second factor
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFACodeRequest carries a code from the authenticator app, or a recovery code where accepted
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAEnrollRequest proves the caller knows the account's password before an authenticator is set up
+type MFAEnrollRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// MFAConfirmRequest turns MFA on with the password and a code from the new authenticator
+type MFAConfirmRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// BookmarkCollectionRequest names a bookmark collection
+type BookmarkCollectionRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// AddBookmarkRequest saves a post; without a collection it goes to the default one
+type AddBookmarkRequest struct {
+	BlogID       string `json:"blog_id" binding:"required"`
+	CollectionID string `json:"collection_id"`
+}
+
+// ReorderBookmarksRequest lists every bookmark of a collection in the new order
+type ReorderBookmarksRequest struct {
+	BookmarkIDs []string `json:"bookmark_ids" binding:"required"`
+}
+
+// MarkReadRequest marks a bookmark read or unread
+type MarkReadRequest struct {
+	Read *bool `json:"read" binding:"required"`
+}
+
+// BookmarkListResponse is a page of a bookmark collection
+type BookmarkListResponse struct {
+	Bookmarks  []models.Bookmark `json:"bookmarks"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	Total      int64             `json:"total"`
+	TotalPages int               `json:"total_pages"`
 }
 
 type UpdateBlogRequest struct {
-	Title       string   `json:"title"`
-	Content     string   `json:"content"`
-	Tags        []string `json:"tags"`
-	IsPublished *bool    `json:"is_published"`
+	Title          string     `json:"title"`
+	Content        string     `json:"content"`
+	Tags           []string   `json:"tags"`
+	IsPublished    *bool      `json:"is_published"`
+	PublishAt      *time.Time `json:"publish_at"`
+	CancelSchedule bool       `json:"cancel_schedule"`
 }
 
 type BlogResponse struct {
-	ID          string            `json:"id"`
-	Title       string            `json:"title"`
-	Content     string            `json:"content"`
-	AuthorID    string            `json:"author_id"`
-	AuthorName  string            `json:"author_name"`
-	Tags        []string          `json:"tags"`
-	ViewCount   int               `json:"view_count"`
-	Likes       int               `json:"likes"`
-	Dislikes    int               `json:"dislikes"`
-	Comments    []CommentResponse `json:"comments"`
-	IsPublished bool              `json:"is_published"`
-	CreatedAt   string            `json:"created_at"`
-	UpdatedAt   string            `json:"updated_at"`
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Slug         string   `json:"slug"`
+	Content      string   `json:"content"`
+	AuthorID     string   `json:"author_id"`
+	AuthorName   string   `json:"author_name"`
+	Tags         []string `json:"tags"`
+	ViewCount    int      `json:"view_count"`
+	Likes        int      `json:"likes"`
+	Dislikes     int      `json:"dislikes"`
+	CommentCount int      `json:"comment_count"`
+	IsPublished  bool     `json:"is_published"`
+	PublishAt    string   `json:"publish_at,omitempty"`
+	Version      int      `json:"version"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+
+	SourceSuggestionID string `json:"source_suggestion_id,omitempty"`
+	ModerationStatus   string `json:"moderation_status,omitempty"`
+}
+
+type RevisionResponse struct {
+	Version   int      `json:"version"`
+	Title     string   `json:"title"`
+	Content   string   `json:"content"`
+	Tags      []string `json:"tags"`
+	IsCurrent bool     `json:"is_current"`
+	CreatedAt string   `json:"created_at"`
 }
 
 type CommentRequest struct {
-	Content string `json:"content" binding:"required"`
+	Content  string `json:"content" binding:"required"`
+	ParentID string `json:"parent_id"`
 }
 
 type CommentResponse struct {
-	ID         string `json:"id"`
-	BlogID     string `json:"blog_id"`
-	AuthorID   string `json:"author_id"`
-	AuthorName string `json:"author_name"`
-	Content    string `json:"content"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	ID         string            `json:"id"`
+	BlogID     string            `json:"blog_id"`
+	ParentID   string            `json:"parent_id,omitempty"`
+	Depth      int               `json:"depth"`
+	AuthorID   string            `json:"author_id"`
+	AuthorName string            `json:"author_name"`
+	Content    string            `json:"content"`
+	IsDeleted  bool              `json:"is_deleted"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+	CreatedAt  string            `json:"created_at"`
+	UpdatedAt  string            `json:"updated_at"`
+
+	ModerationStatus string `json:"moderation_status,omitempty"`
 }
 
+// CommentPageResponse is a cursor-paginated page of comment threads
+type CommentPageResponse struct {
+	Comments []CommentResponse `json:"comments"`
+	Limit    int               `json:"limit"`
+	utils.PageLinks
+}
+
+// PaginatedBlogsResponse carries page/total_pages for ?page= requests and
+// next_cursor/prev_cursor for cursor requests
 type PaginatedBlogsResponse struct {
 	Blogs      []BlogResponse `json:"blogs"`
-	Page       int            `json:"page"`
+	Page       int            `json:"page,omitempty"`
 	Limit      int            `json:"limit"`
-	Total      int64          `json:"total"`
-	TotalPages int            `json:"total_pages"`
+	Total      *int64         `json:"total,omitempty"`
+	TotalPages int            `json:"total_pages,omitempty"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	PrevCursor string         `json:"prev_cursor,omitempty"`
+}
+
+type SearchResultResponse struct {
+	BlogResponse
+	Score      float64  `json:"score"`
+	Highlights []string `json:"highlights"`
 }
 
 type SearchBlogsRequest struct {
@@ -77,3 +230,9 @@ type FilterBlogsRequest struct {
 	DateRange []string `json:"date_range"`
 	SortBy    string   `json:"sort_by"`
 }
+
+// RecommendationPageResponse is a page of recommendations with its cursor links
+type RecommendationPageResponse struct {
+	models.RecommendationResponse
+	utils.PageLinks
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FollowController struct {
+	followUC interfaces.FollowUseCase
+}
+
+func NewFollowController(followUC interfaces.FollowUseCase) *FollowController {
+	return &FollowController{followUC: followUC}
+}
+
+// Follow follows the author; following someone already followed is not an error
+func (ctrl *FollowController) Follow(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := ctrl.followUC.Follow(c.Request.Context(), userID.(string), c.Param("id")); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to follow user: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "User followed successfully", nil)
+}
+
+// Unfollow stops following the author
+func (ctrl *FollowController) Unfollow(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := ctrl.followUC.Unfollow(c.Request.Context(), userID.(string), c.Param("id")); err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to unfollow user: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "User unfollowed successfully", nil)
+}
+
+// GetAuthorProfile shows an author's public profile with follow counts. Signed-in viewers also
+// see whether they follow the author.
+func (ctrl *FollowController) GetAuthorProfile(c *gin.Context) {
+	profile, err := ctrl.followUC.GetAuthorProfile(c.Request.Context(), c.Param("id"), c.GetString("userID"))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "User not found")
+		return
+	}
+
+	utils.SendSuccess(c, "Profile retrieved successfully", profile)
+}
+
+// GetFeed lists the published posts of the authors the user follows, newest first
+func (ctrl *FollowController) GetFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, err := utils.ParsePageRequest(c, 10, 100)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	blogs, info, err := ctrl.followUC.GetFeed(userID.(string), page)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve feed: "+err.Error())
+		return
+	}
+
+	utils.SendPage(c, "Feed retrieved successfully", blogs, info)
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type FollowControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *FollowController
+	mockUC     *mocks.FollowUseCaseMock
+}
+
+func (suite *FollowControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.FollowUseCaseMock{}
+	suite.controller = NewFollowController(suite.mockUC)
+}
+
+func (suite *FollowControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *FollowControllerTestSuite) TestFollow() {
+	// Setup mock
+	suite.mockUC.On("Follow", mock.Anything, "reader123", "author123").Return(nil)
+
+	// Setup route
+	suite.router.POST("/users/:id/follow", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.Follow(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/users/author123/follow", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "User followed successfully")
+}
+
+func (suite *FollowControllerTestSuite) TestFollow_Self() {
+	// Setup mock
+	suite.mockUC.On("Follow", mock.Anything, "reader123", "reader123").Return(errors.New("you cannot follow yourself"))
+
+	// Setup route
+	suite.router.POST("/users/:id/follow", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.Follow(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/users/reader123/follow", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "cannot follow yourself")
+}
+
+func (suite *FollowControllerTestSuite) TestUnfollow() {
+	// Setup mock
+	suite.mockUC.On("Unfollow", mock.Anything, "reader123", "author123").Return(nil)
+
+	// Setup route
+	suite.router.DELETE("/users/:id/follow", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.Unfollow(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/users/author123/follow", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *FollowControllerTestSuite) TestGetAuthorProfile_Anonymous() {
+	// Setup mock
+	suite.mockUC.On("GetAuthorProfile", mock.Anything, "author123", "").
+		Return(models.AuthorProfile{ID: "author123", Username: "author", FollowersCount: 12, FollowingCount: 3}, nil)
+
+	// Setup route
+	suite.router.GET("/users/:id", suite.controller.GetAuthorProfile)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/users/author123", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"followers_count":12`)
+	assert.Contains(suite.T(), w.Body.String(), `"following_count":3`)
+}
+
+func (suite *FollowControllerTestSuite) TestGetFeed() {
+	// Setup mock
+	next := models.Cursor{CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), ID: "blog1"}
+	suite.mockUC.On("GetFeed", "reader123", models.PageRequest{Limit: 1}).
+		Return([]models.Blog{{ID: "blog1", Title: "Followed post"}}, models.PageInfo{Next: &next}, nil)
+
+	// Setup route
+	suite.router.GET("/feed", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.GetFeed(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/feed?limit=1", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "Followed post")
+	assert.Contains(suite.T(), w.Body.String(), `"next_cursor"`)
+}
+
+// Run the test suite
+func TestFollowControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowControllerTestSuite))
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MFAController struct {
+	mfaUC interfaces.MFAUseCase
+}
+
+func NewMFAController(mfaUC interfaces.MFAUseCase) *MFAController {
+	return &MFAController{mfaUC: mfaUC}
+}
+
+// GetStatus tells whether MFA is on, whether the user's role requires it and how many recovery
+// codes are left
+func (ctrl *MFAController) GetStatus(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	status, err := ctrl.mfaUC.GetStatus(c.Request.Context(), userID.(string))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to retrieve MFA status: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "MFA status retrieved successfully", status)
+}
+
+// Enroll checks the password and returns a new secret and its otpauth URI to add to an
+// authenticator app
+func (ctrl *MFAController) Enroll(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req MFAEnrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	enrollment, err := ctrl.mfaUC.Enroll(c.Request.Context(), userID.(string), req.Password, clientInfo(c))
+	if err != nil {
+		if sendThrottled(c, err) {
+			return
+		}
+		utils.SendError(c, mfaErrorStatus(err), "Failed to start MFA enrolment: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Scan the URI with an authenticator app and confirm a code to turn MFA on", enrollment)
+}
+
+// Confirm turns MFA on with a code from the new secret and returns the recovery codes
+func (ctrl *MFAController) Confirm(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "password and code are required")
+		return
+	}
+
+	codes, err := ctrl.mfaUC.Confirm(c.Request.Context(), userID.(string), req.Password, req.Code, clientInfo(c))
+	if err != nil {
+		if sendThrottled(c, err) {
+			return
+		}
+		utils.SendError(c, mfaErrorStatus(err), "Failed to turn MFA on: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "MFA enabled; store the recovery codes and sign in again", gin.H{"recovery_codes": codes})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes
+func (ctrl *MFAController) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	codes, err := ctrl.mfaUC.RegenerateRecoveryCodes(c.Request.Context(), userID.(string), req.Code)
+	if err != nil {
+		utils.SendError(c, mfaErrorStatus(err), "Failed to regenerate recovery codes: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Recovery codes regenerated successfully", gin.H{"recovery_codes": codes})
+}
+
+// Disable turns MFA off
+func (ctrl *MFAController) Disable(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	if err := ctrl.mfaUC.Disable(c.Request.Context(), userID.(string), req.Code); err != nil {
+		utils.SendError(c, mfaErrorStatus(err), "Failed to turn MFA off: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "MFA disabled successfully", nil)
+}
+
+func mfaErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found":
+		return http.StatusNotFound
+	case "invalid verification code":
+		return http.StatusBadRequest
+	case "MFA is required for your role", "incorrect password":
+		return http.StatusForbidden
+	case "MFA is already enabled", "MFA is not enabled", "MFA enrolment has not been started":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MFAControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *MFAController
+	mockUC     *mocks.MFAUseCaseMock
+}
+
+func (suite *MFAControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.MFAUseCaseMock{}
+	suite.controller = NewMFAController(suite.mockUC)
+}
+
+func (suite *MFAControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *MFAControllerTestSuite) TestEnroll() {
+	// Setup mock
+	suite.mockUC.On("Enroll", mock.Anything, "user123", "password123", mock.Anything).
+		Return(models.MFAEnrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/Blog%20API:john@example.com?secret=JBSWY3DPEHPK3PXP"}, nil)
+
+	// Setup route
+	suite.router.POST("/mfa/enroll", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.Enroll(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/mfa/enroll", bytes.NewBufferString(`{"password": "password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"secret":"JBSWY3DPEHPK3PXP"`)
+	assert.Contains(suite.T(), w.Body.String(), `"otpauth_uri":"otpauth://totp/`)
+}
+
+func (suite *MFAControllerTestSuite) TestEnroll_AlreadyEnabled() {
+	// Setup mock
+	suite.mockUC.On("Enroll", mock.Anything, "user123", "password123", mock.Anything).Return(models.MFAEnrollment{}, errors.New("MFA is already enabled"))
+
+	// Setup route
+	suite.router.POST("/mfa/enroll", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.Enroll(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/mfa/enroll", bytes.NewBufferString(`{"password": "password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *MFAControllerTestSuite) TestConfirm() {
+	// Setup mock
+	suite.mockUC.On("Confirm", mock.Anything, "user123", "password123", "123456", mock.Anything).Return([]string{"abcde-fghij", "klmno-pqrst"}, nil)
+
+	// Setup route
+	suite.router.POST("/mfa/confirm", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.Confirm(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/mfa/confirm", bytes.NewBufferString(`{"password": "password123", "code": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"recovery_codes":["abcde-fghij","klmno-pqrst"]`)
+}
+
+func (suite *MFAControllerTestSuite) TestConfirm_MissingPassword() {
+	// Setup route
+	suite.router.POST("/mfa/confirm", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.Confirm(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/mfa/confirm", bytes.NewBufferString(`{"code": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "Confirm", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MFAControllerTestSuite) TestEnroll_IncorrectPassword() {
+	// Setup mock
+	suite.mockUC.On("Enroll", mock.Anything, "user123", "guess", mock.Anything).Return(models.MFAEnrollment{}, errors.New("incorrect password"))
+
+	// Setup route
+	suite.router.POST("/mfa/enroll", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.Enroll(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/mfa/enroll", bytes.NewBufferString(`{"password": "guess"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *MFAControllerTestSuite) TestDisable_RequiredForRole() {
+	// Setup mock
+	suite.mockUC.On("Disable", mock.Anything, "user123", "123456").Return(errors.New("MFA is required for your role"))
+
+	// Setup route
+	suite.router.POST("/mfa/disable", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.Disable(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/mfa/disable", bytes.NewBufferString(`{"code": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+// Run the test suite
+func TestMFAControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(MFAControllerTestSuite))
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationController struct {
+	moderationUC interfaces.ModerationUseCase
+}
+
+func NewModerationController(moderationUC interfaces.ModerationUseCase) *ModerationController {
+	return &ModerationController{moderationUC: moderationUC}
+}
+
+// GetQueue lists held posts and comments oldest first. status defaults to pending; "all" lists
+// every item.
+func (ctrl *ModerationController) GetQueue(c *gin.Context) {
+	status := c.DefaultQuery("status", models.ModerationStatusPending)
+	switch status {
+	case "all":
+		status = ""
+	case models.ModerationStatusPending, models.ModerationStatusApproved, models.ModerationStatusRejected:
+	default:
+		utils.SendError(c, http.StatusBadRequest, "status must be pending, approved, rejected or all")
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	items, total, err := ctrl.moderationUC.GetQueue(status, page, limit)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve moderation queue: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Moderation queue retrieved successfully", ModerationQueueResponse{
+		Items:      items,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	})
+}
+
+// GetItem shows one queue item with the reasons it was held
+func (ctrl *ModerationController) GetItem(c *gin.Context) {
+	item, err := ctrl.moderationUC.GetItem(c.Param("id"))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Moderation item not found")
+		return
+	}
+
+	utils.SendSuccess(c, "Moderation item retrieved successfully", item)
+}
+
+// Approve publishes held content, or makes it visible again
+func (ctrl *ModerationController) Approve(c *gin.Context) {
+	ctrl.review(c, models.ModerationStatusApproved)
+}
+
+// Reject keeps held content hidden
+func (ctrl *ModerationController) Reject(c *gin.Context) {
+	ctrl.review(c, models.ModerationStatusRejected)
+}
+
+func (ctrl *ModerationController) review(c *gin.Context, status string) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var req ReviewModerationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+			return
+		}
+	}
+
+	item, err := ctrl.moderationUC.Review(c.Request.Context(), c.Param("id"), userID.(string), status, req.Note)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to review content: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Content "+status+" successfully", item)
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModerationControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *ModerationController
+	mockUC     *mocks.ModerationUseCaseMock
+}
+
+func (suite *ModerationControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.ModerationUseCaseMock{}
+	suite.controller = NewModerationController(suite.mockUC)
+}
+
+func (suite *ModerationControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *ModerationControllerTestSuite) TestGetQueue() {
+	// Setup mock
+	items := []models.ModerationItem{{ID: "item123", ContentType: models.ModerationContentBlog, Reasons: []string{"Consists mostly of links"}, Status: models.ModerationStatusPending}}
+	suite.mockUC.On("GetQueue", models.ModerationStatusPending, 1, 10).Return(items, int64(11), nil)
+
+	// Setup route
+	suite.router.GET("/admin/moderation", suite.controller.GetQueue)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/admin/moderation", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"reasons":["Consists mostly of links"]`)
+	assert.Contains(suite.T(), w.Body.String(), `"total_pages":2`)
+}
+
+func (suite *ModerationControllerTestSuite) TestGetQueue_AllAndInvalidStatus() {
+	// Setup mock
+	suite.mockUC.On("GetQueue", "", 2, 5).Return([]models.ModerationItem{}, int64(0), nil)
+
+	// Setup route
+	suite.router.GET("/admin/moderation", suite.controller.GetQueue)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/admin/moderation?status=all&page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/moderation?status=spam", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ModerationControllerTestSuite) TestApprove() {
+	// Setup mock
+	suite.mockUC.On("Review", mock.Anything, "item123", "admin123", models.ModerationStatusApproved, "").
+		Return(models.ModerationItem{ID: "item123", Status: models.ModerationStatusApproved, ReviewerID: "admin123"}, nil)
+
+	// Setup route
+	suite.router.POST("/admin/moderation/:id/approve", func(c *gin.Context) {
+		c.Set("userID", "admin123")
+		suite.controller.Approve(c)
+	})
+
+	// Create request without a body
+	req, _ := http.NewRequest("POST", "/admin/moderation/item123/approve", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"approved"`)
+}
+
+func (suite *ModerationControllerTestSuite) TestReject_WithNote() {
+	// Setup mock
+	suite.mockUC.On("Review", mock.Anything, "item123", "admin123", models.ModerationStatusRejected, "No ads").
+		Return(models.ModerationItem{ID: "item123", Status: models.ModerationStatusRejected, ReviewNote: "No ads"}, nil)
+
+	// Setup route
+	suite.router.POST("/admin/moderation/:id/reject", func(c *gin.Context) {
+		c.Set("userID", "admin123")
+		suite.controller.Reject(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/admin/moderation/item123/reject", bytes.NewBufferString(`{"note": "No ads"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"review_note":"No ads"`)
+}
+
+func (suite *ModerationControllerTestSuite) TestReject_AlreadyReviewed() {
+	// Setup mock
+	suite.mockUC.On("Review", mock.Anything, "item123", "admin123", models.ModerationStatusRejected, "").
+		Return(models.ModerationItem{}, errors.New("moderation item already reviewed"))
+
+	// Setup route
+	suite.router.POST("/admin/moderation/:id/reject", func(c *gin.Context) {
+		c.Set("userID", "admin123")
+		suite.controller.Reject(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/admin/moderation/item123/reject", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "already reviewed")
+}
+
+// Run the test suite
+func TestModerationControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationControllerTestSuite))
+}
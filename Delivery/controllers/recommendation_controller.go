@@ -3,6 +3,7 @@ package controllers
 import (
 	"blog-api/Domain/interfaces"
 	"blog-api/Domain/models"
+	"blog-api/Infrastructure/utils"
 	"net/http"
 	"strconv"
 
@@ -69,21 +70,24 @@ func (rc *RecommendationController) GetUserRecommendations(c *gin.Context) {
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 || limit > 50 {
-		limit = 10
+	page, err := utils.ParsePageRequest(c, 10, 50)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	category := c.DefaultQuery("category", models.CategoryAll)
 
-	response, err := rc.recommendationUC.GetUserRecommendations(userID.(string), limit, category)
+	response, err := rc.recommendationUC.GetUserRecommendations(userID.(string), category, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommendations"})
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, RecommendationPageResponse{
+		RecommendationResponse: response,
+		PageLinks:              utils.NewPageLinks(response.Page),
+	})
 }
 
 // GetSimilarContent gets content similar to a specific blog
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct {
+	reportUC interfaces.ReportUseCase
+}
+
+func NewReportController(reportUC interfaces.ReportUseCase) *ReportController {
+	return &ReportController{reportUC: reportUC}
+}
+
+// ReportBlog lets a reader flag a post
+func (ctrl *ReportController) ReportBlog(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req ReportContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	report, err := ctrl.reportUC.ReportBlog(c.Param("id"), userID.(string), req.Reason, req.Details)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to report blog: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Blog reported successfully", report)
+}
+
+// ReportComment lets a reader flag a comment
+func (ctrl *ReportController) ReportComment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req ReportContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	report, err := ctrl.reportUC.ReportComment(c.Param("id"), c.Param("commentId"), userID.(string), req.Reason, req.Details)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to report comment: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Comment reported successfully", report)
+}
+
+// GetReports lists reports oldest first. status defaults to open; "all" lists every report.
+func (ctrl *ReportController) GetReports(c *gin.Context) {
+	status := c.DefaultQuery("status", models.ReportStatusOpen)
+	switch status {
+	case "all":
+		status = ""
+	case models.ReportStatusOpen, models.ReportStatusResolved:
+	default:
+		utils.SendError(c, http.StatusBadRequest, "status must be open, resolved or all")
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	reports, total, err := ctrl.reportUC.GetReports(status, page, limit)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve reports: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Reports retrieved successfully", ReportListResponse{
+		Reports:    reports,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	})
+}
+
+// GetReport shows one report
+func (ctrl *ReportController) GetReport(c *gin.Context) {
+	report, err := ctrl.reportUC.GetReport(c.Param("id"))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Report not found")
+		return
+	}
+
+	utils.SendSuccess(c, "Report retrieved successfully", report)
+}
+
+// Resolve closes the open reports of the reported content, dismissing them or hiding the content
+// and optionally warning or suspending its author
+func (ctrl *ReportController) Resolve(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	report, err := ctrl.reportUC.Resolve(c.Request.Context(), c.Param("id"), userID.(string), req.Action, req.Note, req.SuspendDays)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to resolve report: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Report resolved successfully", report)
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *ReportController
+	mockUC     *mocks.ReportUseCaseMock
+}
+
+func (suite *ReportControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.ReportUseCaseMock{}
+	suite.controller = NewReportController(suite.mockUC)
+}
+
+func (suite *ReportControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *ReportControllerTestSuite) TestReportBlog() {
+	// Setup mock
+	suite.mockUC.On("ReportBlog", "blog123", "reader123", models.ReportReasonSpam, "Only links").
+		Return(models.Report{ID: "report123", ContentType: models.ModerationContentBlog, ContentID: "blog123", Reason: models.ReportReasonSpam, Status: models.ReportStatusOpen}, nil)
+
+	// Setup route
+	suite.router.POST("/blogs/:id/report", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.ReportBlog(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/report", bytes.NewBufferString(`{"reason": "spam", "details": "Only links"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"open"`)
+}
+
+func (suite *ReportControllerTestSuite) TestReportComment_AlreadyReported() {
+	// Setup mock
+	suite.mockUC.On("ReportComment", "blog123", "comment123", "reader123", models.ReportReasonHarassment, "").
+		Return(models.Report{}, errors.New("you have already reported this"))
+
+	// Setup route
+	suite.router.POST("/blogs/:id/comments/:commentId/report", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.ReportComment(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/comments/comment123/report", bytes.NewBufferString(`{"reason": "harassment"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "already reported")
+}
+
+func (suite *ReportControllerTestSuite) TestReportBlog_MissingReason() {
+	// Setup route
+	suite.router.POST("/blogs/:id/report", func(c *gin.Context) {
+		c.Set("userID", "reader123")
+		suite.controller.ReportBlog(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/blogs/blog123/report", bytes.NewBufferString(`{"details": "I do not like it"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "ReportBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ReportControllerTestSuite) TestGetReports() {
+	// Setup mock
+	reports := []models.Report{{ID: "report123", Reason: models.ReportReasonSpam, Status: models.ReportStatusOpen}}
+	suite.mockUC.On("GetReports", models.ReportStatusOpen, 1, 10).Return(reports, int64(21), nil)
+
+	// Setup route
+	suite.router.GET("/admin/reports", suite.controller.GetReports)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/admin/reports", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"reason":"spam"`)
+	assert.Contains(suite.T(), w.Body.String(), `"total_pages":3`)
+
+	req, _ = http.NewRequest("GET", "/admin/reports?status=pending", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ReportControllerTestSuite) TestResolve_Suspend() {
+	// Setup mock
+	suite.mockUC.On("Resolve", mock.Anything, "report123", "admin123", models.ReportActionSuspend, "Repeated abuse", 7).
+		Return(models.Report{ID: "report123", Status: models.ReportStatusResolved, Action: models.ReportActionSuspend}, nil)
+
+	// Setup route
+	suite.router.POST("/admin/reports/:id/resolve", func(c *gin.Context) {
+		c.Set("userID", "admin123")
+		suite.controller.Resolve(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/admin/reports/report123/resolve", bytes.NewBufferString(`{"action": "suspend", "note": "Repeated abuse", "suspend_days": 7}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"action":"suspend"`)
+}
+
+// Run the test suite
+func TestReportControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportControllerTestSuite))
+}
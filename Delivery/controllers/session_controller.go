@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionController struct {
+	sessionUC interfaces.SessionUseCase
+}
+
+func NewSessionController(sessionUC interfaces.SessionUseCase) *SessionController {
+	return &SessionController{sessionUC: sessionUC}
+}
+
+// GetSessions lists the devices the user is signed in on
+func (ctrl *SessionController) GetSessions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	sessions, err := ctrl.sessionUC.GetSessions(userID.(string))
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve sessions: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeSession signs one device out
+func (ctrl *SessionController) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := ctrl.sessionUC.RevokeSession(userID.(string), c.Param("id")); err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to revoke session: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Session revoked successfully", nil)
+}
+
+// RevokeOtherSessions signs out every device except the one whose refresh token is sent
+func (ctrl *SessionController) RevokeOtherSessions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+
+	revoked, err := ctrl.sessionUC.RevokeOtherSessions(userID.(string), req.RefreshToken)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Failed to revoke sessions: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Other sessions revoked successfully", gin.H{"revoked": revoked})
+}
+
+// RevokeUserSessions signs a user out of every device
+func (ctrl *SessionController) RevokeUserSessions(c *gin.Context) {
+	revoked, err := ctrl.sessionUC.RevokeAllSessions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.SendError(c, http.StatusNotFound, "Failed to revoke sessions: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "User sessions revoked successfully", gin.H{"revoked": revoked})
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *SessionController
+	mockUC     *mocks.SessionUseCaseMock
+}
+
+func (suite *SessionControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.SessionUseCaseMock{}
+	suite.controller = NewSessionController(suite.mockUC)
+}
+
+func (suite *SessionControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *SessionControllerTestSuite) TestGetSessions() {
+	// Setup mock
+	usedAt := time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC)
+	suite.mockUC.On("GetSessions", "user123").
+		Return([]models.Session{{ID: "family1", IP: "203.0.113.7", UserAgent: "Firefox", LastUsedAt: usedAt}}, nil)
+
+	// Setup route
+	suite.router.GET("/sessions", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.GetSessions(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/sessions", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"user_agent":"Firefox"`)
+	assert.Contains(suite.T(), w.Body.String(), `"last_used_at":"2025-06-03T18:00:00Z"`)
+}
+
+func (suite *SessionControllerTestSuite) TestRevokeSession_NotFound() {
+	// Setup mock
+	suite.mockUC.On("RevokeSession", "user123", "family9").Return(errors.New("session not found"))
+
+	// Setup route
+	suite.router.DELETE("/sessions/:id", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.RevokeSession(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/sessions/family9", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *SessionControllerTestSuite) TestRevokeOtherSessions() {
+	// Setup mock
+	suite.mockUC.On("RevokeOtherSessions", "user123", "refresh.jwt.token").Return(int64(2), nil)
+
+	// Setup route
+	suite.router.POST("/sessions/revoke-others", func(c *gin.Context) {
+		c.Set("userID", "user123")
+		suite.controller.RevokeOtherSessions(c)
+	})
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/sessions/revoke-others", bytes.NewBufferString(`{"refresh_token": "refresh.jwt.token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"revoked":2`)
+}
+
+func (suite *SessionControllerTestSuite) TestRevokeUserSessions() {
+	// Setup mock
+	suite.mockUC.On("RevokeAllSessions", mock.Anything, "user123").Return(int64(3), nil)
+
+	// Setup route
+	suite.router.DELETE("/admin/users/:id/sessions", suite.controller.RevokeUserSessions)
+
+	// Create request
+	req, _ := http.NewRequest("DELETE", "/admin/users/user123/sessions", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"revoked":3`)
+}
+
+// Run the test suite
+func TestSessionControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionControllerTestSuite))
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Infrastructure/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagController struct {
+	tagUC interfaces.TagUseCase
+}
+
+func NewTagController(tagUC interfaces.TagUseCase) *TagController {
+	return &TagController{tagUC: tagUC}
+}
+
+// GetTags lists the canonical tags with their aliases
+func (ctrl *TagController) GetTags(c *gin.Context) {
+	tags, err := ctrl.tagUC.GetTags()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to retrieve tags: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Tags retrieved successfully", tags)
+}
+
+// SuggestTags proposes canonical tags for a draft's title and content
+func (ctrl *TagController) SuggestTags(c *gin.Context) {
+	var req SuggestTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	if strings.TrimSpace(req.Title) == "" && strings.TrimSpace(req.Content) == "" {
+		utils.SendError(c, http.StatusBadRequest, "Title or content is required")
+		return
+	}
+
+	suggestion, err := ctrl.tagUC.SuggestTags(c.Request.Context(), req.Title, req.Content)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to suggest tags: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Tags suggested successfully", suggestion)
+}
+
+// MergeTags folds the source tags into the target and rewrites every blog using them
+func (ctrl *TagController) MergeTags(c *gin.Context) {
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return
+	}
+
+	if strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(req.Target), "#")) == "" {
+		utils.SendError(c, http.StatusBadRequest, "Target tag is required")
+		return
+	}
+
+	result, err := ctrl.tagUC.MergeTags(req.Sources, req.Target)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Failed to merge tags: "+err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, "Tags merged successfully", result)
+}
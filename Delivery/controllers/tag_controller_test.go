@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TagControllerTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	controller *TagController
+	mockUC     *mocks.TagUseCaseMock
+}
+
+func (suite *TagControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.mockUC = &mocks.TagUseCaseMock{}
+	suite.controller = NewTagController(suite.mockUC)
+}
+
+func (suite *TagControllerTestSuite) TearDownTest() {
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *TagControllerTestSuite) TestGetTags() {
+	// Setup mock
+	suite.mockUC.On("GetTags").Return([]models.Tag{{Name: "go", Aliases: []string{"golang"}}}, nil)
+
+	// Setup route
+	suite.router.GET("/tags", suite.controller.GetTags)
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/tags", nil)
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"aliases":["golang"]`)
+}
+
+func (suite *TagControllerTestSuite) TestSuggestTags() {
+	// Setup mock
+	suite.mockUC.On("SuggestTags", mock.Anything, "Golang tips", "Use goroutines.").
+		Return(models.TagSuggestion{Tags: []string{"go", "concurrency"}, Source: models.TagSuggestionSourceKeywords}, nil)
+
+	// Setup route
+	suite.router.POST("/ai/tags", suite.controller.SuggestTags)
+
+	// Create request
+	body := `{"title": "Golang tips", "content": "Use goroutines."}`
+	req, _ := http.NewRequest("POST", "/ai/tags", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"tags":["go","concurrency"]`)
+	assert.Contains(suite.T(), w.Body.String(), `"source":"keywords"`)
+}
+
+func (suite *TagControllerTestSuite) TestSuggestTags_MissingText() {
+	// Setup route
+	suite.router.POST("/ai/tags", suite.controller.SuggestTags)
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/ai/tags", bytes.NewBufferString(`{"title": " "}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "SuggestTags", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TagControllerTestSuite) TestMergeTags() {
+	// Setup mock
+	suite.mockUC.On("MergeTags", []string{"golang", "go-lang"}, "go").Return(models.TagMergeResult{
+		Tag:          models.Tag{Name: "go", Aliases: []string{"golang", "go-lang"}},
+		BlogsUpdated: 4,
+	}, nil)
+
+	// Setup route
+	suite.router.POST("/admin/tags/merge", suite.controller.MergeTags)
+
+	// Create request
+	body := `{"sources": ["golang", "go-lang"], "target": "go"}`
+	req, _ := http.NewRequest("POST", "/admin/tags/merge", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"blogs_updated":4`)
+}
+
+func (suite *TagControllerTestSuite) TestMergeTags_InvalidInput() {
+	// Setup route
+	suite.router.POST("/admin/tags/merge", suite.controller.MergeTags)
+
+	for _, body := range []string{`{"sources": [], "target": "go"}`, `{"sources": ["golang"], "target": " # "}`} {
+		// Create request
+		req, _ := http.NewRequest("POST", "/admin/tags/merge", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute request
+		suite.router.ServeHTTP(w, req)
+
+		// Assertions
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	}
+	suite.mockUC.AssertNotCalled(suite.T(), "MergeTags", mock.Anything, mock.Anything)
+}
+
+func (suite *TagControllerTestSuite) TestMergeTags_Error() {
+	// Setup mock
+	suite.mockUC.On("MergeTags", []string{"golang"}, "go").Return(models.TagMergeResult{}, errors.New("database error"))
+
+	// Setup route
+	suite.router.POST("/admin/tags/merge", suite.controller.MergeTags)
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/admin/tags/merge", bytes.NewBufferString(`{"sources": ["golang"], "target": "go"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute request
+	suite.router.ServeHTTP(w, req)
+
+	// Assertions
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+// Run the test suite
+func TestTagControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TagControllerTestSuite))
+}
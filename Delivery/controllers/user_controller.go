@@ -3,8 +3,11 @@ package controllers
 import (
 	"blog-api/Domain/models"
 	"blog-api/usecases"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -56,8 +59,34 @@ func (ctrl *UserController) Login(c *gin.Context) {
 		Email:    userreq.Email,
 		Password: userreq.Password,
 	}
-	tokens, err := ctrl.userUC.Login(user)
+	tokens, err := ctrl.userUC.Login(user, clientInfo(c))
 	if err != nil {
+		if sendThrottled(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if tokens.MFA_token != "" {
+		c.JSON(http.StatusOK, gin.H{"mfa_required": true, "mfa_token": tokens.MFA_token})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// POST /login/mfa
+func (ctrl *UserController) VerifyMFA(c *gin.Context) {
+	var req MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mfa_token and code are required"})
+		return
+	}
+
+	tokens, err := ctrl.userUC.VerifyMFA(req.MFAToken, req.Code, clientInfo(c))
+	if err != nil {
+		if sendThrottled(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
@@ -73,12 +102,12 @@ func (ctrl *UserController) RefreshToken(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	newToken, err := ctrl.userUC.RefreshToken(body.RefreshToken)
+	tokens, err := ctrl.userUC.RefreshToken(body.RefreshToken, clientInfo(c))
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"access_token": newToken})
+	c.JSON(http.StatusOK, gin.H{"access_token": tokens.Access_token, "refresh_token": tokens.Refresh_token})
 }
 
 // PUT /promote/:email
@@ -142,12 +171,15 @@ func (uc *UserController) RequestPasswordReset(c *gin.Context) {
 		return
 	}
 
-	if err := uc.userUC.RequestPasswordReset(req.Email); err != nil {
+	if err := uc.userUC.RequestPasswordReset(req.Email, clientInfo(c)); err != nil {
+		if sendThrottled(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Password reset link sent"})
+	c.JSON(http.StatusOK, gin.H{"message": "If an account with that email exists, a password reset link has been sent"})
 }
 
 // POST /reset-password
@@ -173,6 +205,20 @@ func (uc *UserController) ResetPassword(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset successfully"})
 }
 
+// POST /api/admin/users/:id/unlock
+func (uc *UserController) UnlockAccount(c *gin.Context) {
+	if err := uc.userUC.UnlockAccount(c.Request.Context(), c.Param("id")); err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlocked"})
+}
+
 // GET /verify-email?token=...
 func (uc *UserController) VerifyEmail(c *gin.Context) {
 	token := c.Query("token")
@@ -188,3 +234,21 @@ func (uc *UserController) VerifyEmail(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
 }
+
+// clientInfo describes the client of the request for its session
+func clientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// sendThrottled answers a throttled request with 429 and when to retry
+func sendThrottled(c *gin.Context, err error) bool {
+	var throttled *models.ThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+
+	retryAfter := int(time.Until(throttled.RetryAt).Seconds()) + 1
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retry_at": throttled.RetryAt})
+	return true
+}
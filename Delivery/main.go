@@ -2,6 +2,8 @@ package main
 
 import (
 	"blog-api/Delivery/routers"
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
 	"blog-api/Infrastructure/database"
 	"blog-api/Infrastructure/repositories"
 	"blog-api/Infrastructure/services"
@@ -11,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -35,16 +38,57 @@ func main() {
 	userCollection := database.GetCollection("users")
 	blogCollection := database.GetCollection("blogs")
 	tokenCollection := database.GetCollection("tokens")
+	revisionCollection := database.GetCollection("blog_revisions")
+	slugCollection := database.GetCollection("blog_slugs")
 
 	userRepo := repositories.NewUserMongoRepo(userCollection)
 	blogRepo := repositories.NewBlogMongoRepo(blogCollection)
+	if err := blogRepo.EnsureSearchIndex(); err != nil {
+		log.Printf("Failed to create blog search index: %v", err)
+	}
 	tokenRepo := repositories.NewTokenMongoRepo(tokenCollection)
+	if err := tokenRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create token indexes: %v", err)
+	}
+	reactionRepo := repositories.NewReactionMongoRepo(database.GetDatabase())
+	if err := reactionRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create reaction indexes: %v", err)
+	}
+	commentRepo := repositories.NewCommentMongoRepo(database.GetDatabase())
+	if migrated, err := commentRepo.MigrateEmbeddedComments(); err != nil {
+		log.Printf("Failed to migrate embedded comments: %v", err)
+	} else if migrated > 0 {
+		log.Printf("Migrated %d embedded comments", migrated)
+	}
+	revisionRepo := repositories.NewRevisionMongoRepo(revisionCollection)
+	slugRepo := repositories.NewSlugMongoRepo(slugCollection)
+	tagRepo := repositories.NewTagMongoRepo(database.GetCollection("tags"))
+	if err := tagRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create tag indexes: %v", err)
+	}
 
 	// Initialize recommendation repository
 	recommendationRepo := repositories.NewRecommendationMongoRepo(database.GetClient(), database.GetDatabase())
+	followRepo := repositories.NewFollowMongoRepo(database.GetCollection("follows"))
+	if err := followRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create follow indexes: %v", err)
+	}
+
+	bookmarkRepo := repositories.NewBookmarkMongoRepo(database.GetDatabase())
+	if err := bookmarkRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create bookmark indexes: %v", err)
+	}
 
 	// Initialize AI suggestion repository
 	aiSuggestionRepo := repositories.NewAISuggestionMongoRepo(database.GetCollection("ai_suggestions"))
+	aiUsageRepo := repositories.NewAIUsageMongoRepo(database.GetCollection("ai_usage"))
+	if err := aiUsageRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create AI usage index: %v", err)
+	}
+	aiQuotaCounterRepo := repositories.NewAIQuotaCounterMongoRepo(database.GetCollection("ai_quota_counters"))
+	if err := aiQuotaCounterRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create AI quota counter index: %v", err)
+	}
 
 	// Initialize services
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -55,6 +99,43 @@ func main() {
 	jwtService := services.NewJWTService(jwtSecret, jwtSecret, 15*time.Minute, 7*24*time.Hour)
 	passwordService := &services.BcryptHasher{}
 
+	// Access tokens are checked against the user's token version; a short cache keeps Mongo out of it
+	tokenVersionTTL := 30 * time.Second
+	if seconds, err := strconv.Atoi(os.Getenv("TOKEN_VERSION_CACHE_SECONDS")); err == nil && seconds >= 0 {
+		tokenVersionTTL = time.Duration(seconds) * time.Second
+	}
+	tokenVersions := services.NewTokenVersionCache(userRepo, tokenVersionTTL, 10000)
+	tokenService := services.NewVersionCheckingTokenService(jwtService, tokenVersions)
+
+	// Failed logins and reset requests are counted per account and IP to slow down guessing
+	loginAttemptRepo := repositories.NewLoginAttemptMongoRepo(database.GetCollection("login_attempts"))
+	if err := loginAttemptRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create login attempt index: %v", err)
+	}
+	loginThrottle := services.NewLoginThrottle(loginAttemptRepo, services.DefaultLoginThrottlePolicies)
+
+	// Two-factor authentication; the listed roles cannot use admin routes without it. Admins need
+	// it unless MFA_REQUIRED_ROLES names other roles, or "none" to turn the requirement off.
+	mfaIssuer := os.Getenv("MFA_ISSUER")
+	if mfaIssuer == "" {
+		mfaIssuer = "Blog API"
+	}
+	totpService := services.NewTOTPService(mfaIssuer)
+	mfaRoles := strings.TrimSpace(os.Getenv("MFA_REQUIRED_ROLES"))
+	if mfaRoles == "" {
+		mfaRoles = "admin,superadmin"
+	}
+	var mfaRequiredRoles []string
+	if strings.EqualFold(mfaRoles, "none") {
+		log.Println("MFA_REQUIRED_ROLES=none: admin routes do not require MFA")
+	} else {
+		for _, role := range strings.Split(mfaRoles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				mfaRequiredRoles = append(mfaRequiredRoles, role)
+			}
+		}
+	}
+
 	// Initialize email service - Brevo SMTP
 	smtpHost := os.Getenv("BREVO_SMTP_HOST")
 	if smtpHost == "" {
@@ -87,14 +168,104 @@ func main() {
 
 	emailService := services.NewEmailService(smtpHost, smtpPort, smtpUsername, smtpPassword, fromEmail, frontendURL)
 
+	// Initialize AI provider
+	aiTimeout := 30 * time.Second
+	if seconds, err := strconv.Atoi(os.Getenv("AI_TIMEOUT_SECONDS")); err == nil && seconds > 0 {
+		aiTimeout = time.Duration(seconds) * time.Second
+	}
+
+	aiProviderName := os.Getenv("AI_PROVIDER")
+	var aiProvider interfaces.AIProvider
+	switch aiProviderName {
+	case "openai":
+		openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
+		if openAIBaseURL == "" {
+			openAIBaseURL = "https://api.openai.com/v1"
+		}
+		openAIModel := os.Getenv("OPENAI_MODEL")
+		if openAIModel == "" {
+			openAIModel = "gpt-4o-mini"
+		}
+		aiProvider = services.NewOpenAIProvider(openAIBaseURL, os.Getenv("OPENAI_API_KEY"), openAIModel, aiTimeout)
+	case "fake":
+		aiProvider = services.NewFakeAIProvider()
+	case "", "sidecar":
+		aiProviderName = "sidecar"
+		aiProvider = services.NewSidecarAIProvider(os.Getenv("AI_SERVICE_URL"), aiTimeout)
+	default:
+		log.Fatalf("Unknown AI_PROVIDER %q: expected sidecar, openai or fake", aiProviderName)
+	}
+	log.Printf("AI provider: %s (timeout %s)", aiProviderName, aiTimeout)
+	// Metered inside the cache, so answers served from it cost no quota
+	aiProvider = services.NewMeteredAIProvider(aiProvider)
+
+	aiCacheTTL := time.Hour
+	if seconds, err := strconv.Atoi(os.Getenv("AI_CACHE_TTL_SECONDS")); err == nil && seconds >= 0 {
+		aiCacheTTL = time.Duration(seconds) * time.Second
+	}
+	aiCacheMaxEntries := 1000
+	if entries, err := strconv.Atoi(os.Getenv("AI_CACHE_MAX_ENTRIES")); err == nil && entries > 0 {
+		aiCacheMaxEntries = entries
+	}
+	if aiCacheTTL > 0 {
+		aiProvider = services.NewCachingAIProvider(aiProvider, aiCacheTTL, aiCacheMaxEntries)
+		log.Printf("AI response cache: ttl %s, up to %d entries", aiCacheTTL, aiCacheMaxEntries)
+	}
+
+	// Initialize moderation: local rules always, the AI classifier on request
+	moderationRepo := repositories.NewModerationMongoRepo(database.GetCollection("moderation_queue"))
+	if err := moderationRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create moderation indexes: %v", err)
+	}
+
+	var moderationBlocklist []string
+	for _, term := range strings.Split(os.Getenv("MODERATION_BLOCKLIST"), ",") {
+		if term = strings.TrimSpace(term); term != "" {
+			moderationBlocklist = append(moderationBlocklist, term)
+		}
+	}
+	moderationMaxLinks := 5
+	if links, err := strconv.Atoi(os.Getenv("MODERATION_MAX_LINKS")); err == nil && links >= 0 {
+		moderationMaxLinks = links
+	}
+	var moderator interfaces.Moderator = services.NewRuleModerator(moderationBlocklist, moderationMaxLinks)
+	if os.Getenv("MODERATION_AI") == "true" {
+		moderator = services.NewChainModerator(moderator, services.NewAIModerator(aiProvider))
+	}
+	log.Printf("Moderation: %d blocked terms, up to %d links, AI classifier %t",
+		len(moderationBlocklist), moderationMaxLinks, os.Getenv("MODERATION_AI") == "true")
+
+	// Initialize reader reports; enough open reports take content down for review
+	reportRepo := repositories.NewReportMongoRepo(database.GetCollection("reports"))
+	if err := reportRepo.EnsureIndexes(); err != nil {
+		log.Printf("Failed to create report indexes: %v", err)
+	}
+	reportHideThreshold := 5
+	if threshold, err := strconv.Atoi(os.Getenv("REPORT_HIDE_THRESHOLD")); err == nil && threshold >= 0 {
+		reportHideThreshold = threshold
+	}
+
 	// Initialize recommendation service
-	recommendationService := services.NewRecommendationService(recommendationRepo, blogRepo)
+	recommendationService := services.NewRecommendationService(recommendationRepo, blogRepo, followRepo)
 
 	// Initialize use cases
-	userUC := usecases.NewUserUsecase(userRepo, passwordService, jwtService, tokenRepo, emailService)
-	blogUC := usecases.NewBlogUseCase(blogRepo)
+	userUC := usecases.NewUserUsecase(userRepo, passwordService, jwtService, tokenRepo, emailService, tokenVersions, loginThrottle, totpService)
+	moderationUC := usecases.NewModerationUseCase(moderator, moderationRepo, blogRepo, commentRepo, userRepo, emailService)
+	blogUC := usecases.NewBlogUseCase(blogRepo, reactionRepo, commentRepo, revisionRepo, slugRepo, tagRepo, moderationUC)
+	if assigned, err := blogUC.BackfillSlugs(); err != nil {
+		log.Printf("Failed to backfill blog slugs: %v", err)
+	} else if assigned > 0 {
+		log.Printf("Assigned slugs to %d existing blogs", assigned)
+	}
+	reportUC := usecases.NewReportUseCase(reportRepo, blogRepo, commentRepo, userRepo, tokenRepo, tokenVersions, moderationUC, emailService, reportHideThreshold)
 	recommendationUC := usecases.NewRecommendationUseCase(recommendationRepo, blogRepo, recommendationService)
-	aiSuggestionUC := usecases.NewAISuggestionUseCase(aiSuggestionRepo, blogRepo)
+	followUC := usecases.NewFollowUseCase(followRepo, userRepo, blogRepo, recommendationService)
+	bookmarkUC := usecases.NewBookmarkUseCase(bookmarkRepo, blogRepo, recommendationService)
+	sessionUC := usecases.NewSessionUseCase(tokenRepo, jwtService, userRepo, tokenVersions)
+	mfaUC := usecases.NewMFAUseCase(userRepo, totpService, passwordService, loginThrottle, tokenRepo, tokenVersions, mfaRequiredRoles)
+	aiSuggestionUC := usecases.NewAISuggestionUseCase(aiSuggestionRepo, blogUC, userRepo, aiProvider)
+	aiUsageUC := usecases.NewAIUsageUseCase(aiUsageRepo, aiQuotaCounterRepo, loadAIQuotas())
+	tagUC := usecases.NewTagUseCase(tagRepo, blogRepo, aiProvider)
 
 	// Create Gin router with proper configuration
 	r := gin.New() // Use gin.New() instead of gin.Default() to avoid middleware duplication
@@ -141,8 +312,13 @@ func main() {
 	recommendationWorker.Start()
 	defer recommendationWorker.Stop()
 
+	// Initialize scheduled publishing
+	publishScheduler := services.NewPublishScheduler(blogUC)
+	publishScheduler.Start()
+	defer publishScheduler.Stop()
+
 	// Setup routes
-	routers.SetupRouter(r, userUC, blogUC, recommendationUC, aiSuggestionUC, jwtService)
+	routers.SetupRouter(r, userUC, blogUC, recommendationUC, aiSuggestionUC, aiUsageUC, tagUC, moderationUC, reportUC, followUC, bookmarkUC, sessionUC, mfaUC, tokenService)
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -160,3 +336,31 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// loadAIQuotas reads AI_QUOTA_<ROLE>_<DAILY|MONTHLY>_<REQUESTS|TOKENS>, falling back to the
+// defaults below. 0 means unlimited.
+func loadAIQuotas() map[string]models.AIQuota {
+	quotas := map[string]models.AIQuota{
+		"user":       {DailyRequests: 50, MonthlyRequests: 1000, DailyTokens: 100000, MonthlyTokens: 2000000},
+		"admin":      {DailyRequests: 200, MonthlyRequests: 4000, DailyTokens: 400000, MonthlyTokens: 8000000},
+		"superadmin": {},
+	}
+
+	for role, quota := range quotas {
+		prefix := "AI_QUOTA_" + strings.ToUpper(role) + "_"
+		for name, limit := range map[string]*int64{
+			"DAILY_REQUESTS":   &quota.DailyRequests,
+			"MONTHLY_REQUESTS": &quota.MonthlyRequests,
+			"DAILY_TOKENS":     &quota.DailyTokens,
+			"MONTHLY_TOKENS":   &quota.MonthlyTokens,
+		} {
+			if value, err := strconv.ParseInt(os.Getenv(prefix+name), 10, 64); err == nil && value >= 0 {
+				*limit = value
+			}
+		}
+		quotas[role] = quota
+		log.Printf("AI quota for %s: %+v", role, quota)
+	}
+
+	return quotas
+}
@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AIQuotaMiddleware reserves a place in the caller's role quota before the handler can call the
+// model, refusing requests over it with 429 and the reset time, and settles the tokens and
+// latency of the requests it lets through. Requests whose model call failed are still recorded
+// but give their place back. It must run after AuthMiddleware. usesAI, when given,
+// limits both to the requests it approves, for routes that only sometimes call the model.
+func AIQuotaMiddleware(aiUsageUC interfaces.AIUsageUseCase, usesAI ...func(c *gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, uses := range usesAI {
+			if !uses(c) {
+				c.Next()
+				return
+			}
+		}
+
+		userID := c.GetString("userID")
+		role := c.GetString("role")
+
+		reservation, err := aiUsageUC.ReserveRequest(userID, role)
+		var exceeded *models.AIQuotaExceededError
+		if errors.As(err, &exceeded) {
+			retryAfter := int(time.Until(exceeded.ResetAt).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			body := gin.H{
+				"success":  false,
+				"message":  "AI usage quota exceeded",
+				"reset_at": exceeded.ResetAt,
+			}
+			if status, err := aiUsageUC.GetUsageStatus(userID, role); err == nil {
+				body["usage"] = status
+			}
+			c.JSON(http.StatusTooManyRequests, body)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Failed to check AI quota"})
+			c.Abort()
+			return
+		}
+
+		// The metered AI provider reports every call made with the request context
+		meter := &models.AIUsageMeter{}
+		c.Request = c.Request.WithContext(models.ContextWithAIUsageMeter(c.Request.Context(), meter))
+
+		c.Next()
+
+		record, ok := meter.Record()
+		if !ok {
+			if err := aiUsageUC.ReleaseRequest(reservation); err != nil {
+				log.Printf("Failed to release AI quota for user %s: %v", userID, err)
+			}
+			return
+		}
+		record.UserID = userID
+		record.Role = role
+		record.Endpoint = c.FullPath()
+		if err := aiUsageUC.SettleRequest(reservation, record); err != nil {
+			log.Printf("Failed to record AI usage for user %s: %v", userID, err)
+		}
+		if !record.Success {
+			if err := aiUsageUC.ReleaseRequest(reservation); err != nil {
+				log.Printf("Failed to release AI quota for user %s: %v", userID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package middlewares
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/Infrastructure/services"
+	"blog-api/mocks"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAIQuotaMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resetAt := time.Now().Add(time.Hour)
+
+	reservation := models.AIQuotaReservation{UserID: "user123", Keys: []string{"user123:month:2026-10", "user123:day:2026-10-16"}}
+
+	tests := []struct {
+		name           string
+		reserveErr     error
+		callsAI        bool
+		aiErr          error
+		skip           bool
+		expectedStatus int
+		expectRecord   bool
+		expectRelease  bool
+	}{
+		{
+			name:           "under quota is metered",
+			callsAI:        true,
+			expectedStatus: http.StatusOK,
+			expectRecord:   true,
+		},
+		{
+			name:           "failed AI call is recorded and releases its reservation",
+			callsAI:        true,
+			aiErr:          errors.New("AI service stream ended unexpectedly"),
+			expectedStatus: http.StatusOK,
+			expectRecord:   true,
+			expectRelease:  true,
+		},
+		{
+			name:           "over quota is refused",
+			reserveErr:     &models.AIQuotaExceededError{ResetAt: resetAt},
+			callsAI:        true,
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "quota check failure is refused",
+			reserveErr:     errors.New("database error"),
+			callsAI:        true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "request without an AI call releases its reservation",
+			expectedStatus: http.StatusOK,
+			expectRelease:  true,
+		},
+		{
+			name:           "routes that skip AI pass without a reservation",
+			skip:           true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockUC := &mocks.AIUsageUseCaseMock{}
+			if !tt.skip {
+				if tt.reserveErr != nil {
+					mockUC.On("ReserveRequest", "user123", "user").Return(models.AIQuotaReservation{}, tt.reserveErr)
+				} else {
+					mockUC.On("ReserveRequest", "user123", "user").Return(reservation, nil)
+				}
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				mockUC.On("GetUsageStatus", "user123", "user").Return(models.AIUsageStatus{Role: "user", Exceeded: true, ResetAt: &resetAt}, nil)
+			}
+			if tt.expectRecord {
+				mockUC.On("SettleRequest", reservation, mock.MatchedBy(func(record models.AIUsageRecord) bool {
+					if tt.aiErr != nil {
+						return record.UserID == "user123" && record.Calls == 1 && !record.Success
+					}
+					return record.UserID == "user123" && record.Endpoint == "/ai/ideas" &&
+						record.Calls == 1 && record.TotalTokens > 0 && record.Success
+				})).Return(nil)
+			}
+			if tt.expectRelease {
+				mockUC.On("ReleaseRequest", reservation).Return(nil)
+			}
+			called := false
+			fake := services.NewFakeAIProvider()
+			fake.Err = tt.aiErr
+			provider := services.NewMeteredAIProvider(fake)
+
+			// Setup route
+			router := gin.New()
+			router.POST("/ai/ideas", func(c *gin.Context) {
+				c.Set("userID", "user123")
+				c.Set("role", "user")
+			}, AIQuotaMiddleware(mockUC, func(c *gin.Context) bool { return !tt.skip }), func(c *gin.Context) {
+				called = true
+				if tt.callsAI {
+					provider.Generate(c.Request.Context(), models.AIGenerationRequest{Keywords: []string{"go"}})
+				}
+				c.Status(http.StatusOK)
+			})
+
+			// Execute request
+			req, _ := http.NewRequest("POST", "/ai/ideas", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assertions
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				assert.NotEmpty(t, w.Header().Get("Retry-After"))
+				assert.Contains(t, w.Body.String(), `"reset_at"`)
+				assert.Contains(t, w.Body.String(), `"usage"`)
+			}
+			assert.Equal(t, tt.reserveErr == nil, called)
+			mockUC.AssertExpectations(t)
+			if !tt.expectRecord {
+				mockUC.AssertNotCalled(t, "SettleRequest", mock.Anything, mock.Anything)
+			}
+			if !tt.expectRelease {
+				mockUC.AssertNotCalled(t, "ReleaseRequest", mock.Anything)
+			}
+		})
+	}
+}
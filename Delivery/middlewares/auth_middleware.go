@@ -52,6 +52,24 @@ func AuthMiddleware(tokenService interfaces.TokenService, allowedRoles ...string
 		c.Set("userID", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
+		c.Set("mfa", claims.MFA)
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware identifies the caller when a valid bearer token is sent,
+// but lets anonymous requests through so public routes can tailor their response.
+func OptionalAuthMiddleware(tokenService interfaces.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := tokenService.VerifyAccessToken(parts[1]); err == nil {
+				c.Set("userID", claims.UserID)
+				c.Set("email", claims.Email)
+				c.Set("role", claims.Role)
+			}
+		}
 
 		c.Next()
 	}
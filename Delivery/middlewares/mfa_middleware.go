@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"blog-api/Domain/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MFAMiddleware refuses callers whose role requires MFA unless they signed in with it. It must
+// run after AuthMiddleware.
+func MFAMiddleware(mfaUC interfaces.MFAUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if mfaUC.RequiredFor(c.GetString("role")) && !c.GetBool("mfa") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Multi-factor authentication is required for this role; enable it at /api/mfa and sign in again"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"blog-api/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMFAMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		role           string
+		mfa            bool
+		required       bool
+		expectedStatus int
+	}{
+		{name: "admin signed in with MFA passes", role: "admin", mfa: true, required: true, expectedStatus: http.StatusOK},
+		{name: "admin signed in without MFA is refused", role: "admin", required: true, expectedStatus: http.StatusForbidden},
+		{name: "role without the requirement passes", role: "user", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockUC := &mocks.MFAUseCaseMock{}
+			mockUC.On("RequiredFor", tt.role).Return(tt.required)
+
+			// Setup route
+			router := gin.New()
+			router.GET("/admin/users", func(c *gin.Context) {
+				c.Set("role", tt.role)
+				c.Set("mfa", tt.mfa)
+			}, MFAMiddleware(mockUC), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			// Execute request
+			req, _ := http.NewRequest("GET", "/admin/users", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assertions
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
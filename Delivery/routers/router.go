@@ -9,12 +9,20 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(r *gin.Engine, userUC usecases.UserUsecaseInterface, blogUC usecases.BlogUseCase, recommendationUC interfaces.RecommendationUseCase, aiSuggestionUC interfaces.AISuggestionUseCase, tokenService interfaces.TokenService) {
+func SetupRouter(r *gin.Engine, userUC usecases.UserUsecaseInterface, blogUC usecases.BlogUseCase, recommendationUC interfaces.RecommendationUseCase, aiSuggestionUC interfaces.AISuggestionUseCase, aiUsageUC interfaces.AIUsageUseCase, tagUC interfaces.TagUseCase, moderationUC interfaces.ModerationUseCase, reportUC interfaces.ReportUseCase, followUC interfaces.FollowUseCase, bookmarkUC interfaces.BookmarkUseCase, sessionUC interfaces.SessionUseCase, mfaUC interfaces.MFAUseCase, tokenService interfaces.TokenService) {
 	// Initialize controllers
 	userController := controllers.NewUserController(userUC)
 	blogController := controllers.NewBlogController(blogUC)
 	recommendationController := controllers.NewRecommendationController(recommendationUC)
 	aiSuggestionController := controllers.NewAISuggestionController(aiSuggestionUC)
+	aiUsageController := controllers.NewAIUsageController(aiUsageUC)
+	tagController := controllers.NewTagController(tagUC)
+	moderationController := controllers.NewModerationController(moderationUC)
+	reportController := controllers.NewReportController(reportUC)
+	followController := controllers.NewFollowController(followUC)
+	bookmarkController := controllers.NewBookmarkController(bookmarkUC)
+	sessionController := controllers.NewSessionController(sessionUC)
+	mfaController := controllers.NewMFAController(mfaUC)
 
 	// Initialize profile controllers
 	controllers.InitUserController(userUC)
@@ -22,6 +30,7 @@ func SetupRouter(r *gin.Engine, userUC usecases.UserUsecaseInterface, blogUC use
 	// Public routes
 	r.POST("/register", userController.Register)
 	r.POST("/login", userController.Login)
+	r.POST("/login/mfa", userController.VerifyMFA)
 	r.POST("/refresh", userController.RefreshToken)
 	r.GET("/verify-email", userController.VerifyEmail)
 	r.POST("/forgot-password", userController.RequestPasswordReset)
@@ -30,8 +39,12 @@ func SetupRouter(r *gin.Engine, userUC usecases.UserUsecaseInterface, blogUC use
 	r.GET("/blogs", blogController.GetPaginatedBlogs)
 	r.GET("/blogs/search", blogController.SearchBlogs)
 	r.GET("/blogs/filter", blogController.FilterBlogs)
-	r.GET("/blogs/:id", blogController.GetBlogByID)
-	r.GET("/blogs/:id/comments", blogController.GetComments)
+	r.GET("/blogs/query", blogController.QueryBlogs)
+	r.GET("/blogs/by-slug/:slug", middlewares.OptionalAuthMiddleware(tokenService), blogController.GetBlogBySlug)
+	r.GET("/blogs/:id", middlewares.OptionalAuthMiddleware(tokenService), blogController.GetBlogByID)
+	r.GET("/blogs/:id/comments", middlewares.OptionalAuthMiddleware(tokenService), blogController.GetComments)
+	r.GET("/tags", tagController.GetTags)
+	r.GET("/users/:id", middlewares.OptionalAuthMiddleware(tokenService), followController.GetAuthorProfile)
 
 	// Recommendation routes (public)
 	r.GET("/recommendations/trending", recommendationController.GetTrendingContent)
@@ -50,28 +63,84 @@ func SetupRouter(r *gin.Engine, userUC usecases.UserUsecaseInterface, blogUC use
 			user.PUT("/profile", controllers.UpdateUserProfile)
 		}
 
+		// Session routes with real auth
+		sessions := auth.Group("/sessions").Use(middlewares.AuthMiddleware(tokenService))
+		{
+			sessions.GET("", sessionController.GetSessions)
+			sessions.POST("/revoke-others", sessionController.RevokeOtherSessions)
+			sessions.DELETE("/:id", sessionController.RevokeSession)
+		}
+
+		// MFA routes with real auth
+		mfa := auth.Group("/mfa").Use(middlewares.AuthMiddleware(tokenService))
+		{
+			mfa.GET("", mfaController.GetStatus)
+			mfa.POST("/enroll", mfaController.Enroll)
+			mfa.POST("/confirm", mfaController.Confirm)
+			mfa.POST("/recovery-codes", mfaController.RegenerateRecoveryCodes)
+			mfa.POST("/disable", mfaController.Disable)
+		}
+
+		// Follow routes with real auth
+		users := auth.Group("/users").Use(middlewares.AuthMiddleware(tokenService))
+		{
+			users.POST("/:id/follow", followController.Follow)
+			users.DELETE("/:id/follow", followController.Unfollow)
+		}
+		auth.GET("/feed", middlewares.AuthMiddleware(tokenService), followController.GetFeed)
+
+		// Bookmark routes with real auth
+		bookmarks := auth.Group("/bookmarks").Use(middlewares.AuthMiddleware(tokenService))
+		{
+			bookmarks.GET("/collections", bookmarkController.GetCollections)
+			bookmarks.POST("/collections", bookmarkController.CreateCollection)
+			bookmarks.GET("/collections/:id", bookmarkController.GetBookmarks)
+			bookmarks.PUT("/collections/:id", bookmarkController.RenameCollection)
+			bookmarks.DELETE("/collections/:id", bookmarkController.DeleteCollection)
+			bookmarks.PUT("/collections/:id/order", bookmarkController.ReorderBookmarks)
+			bookmarks.POST("", bookmarkController.AddBookmark)
+			bookmarks.PUT("/:id/read", bookmarkController.MarkRead)
+			bookmarks.DELETE("/:id", bookmarkController.RemoveBookmark)
+		}
+
 		// Blog routes with real auth
 		blogs := auth.Group("/blogs").Use(middlewares.AuthMiddleware(tokenService))
 		{
 			blogs.POST("/", blogController.CreateBlog)
+			blogs.GET("/mine", blogController.GetMyBlogs)
+			blogs.GET("/scheduled", blogController.GetScheduledBlogs)
 			blogs.PUT("/:id", blogController.UpdateBlog)
 			blogs.DELETE("/:id", blogController.DeleteBlog)
 			blogs.POST("/:id/comments", blogController.AddComment)
+			blogs.PUT("/:id/comments/:commentId", blogController.UpdateComment)
+			blogs.DELETE("/:id/comments/:commentId", blogController.DeleteComment)
+			blogs.POST("/:id/report", reportController.ReportBlog)
+			blogs.POST("/:id/comments/:commentId/report", reportController.ReportComment)
 			blogs.POST("/:id/like", blogController.LikeBlog)
 			blogs.POST("/:id/unlike", blogController.UnlikeBlog)
 			blogs.POST("/:id/dislike", blogController.DislikeBlog)
 			blogs.POST("/:id/remove-dislike", blogController.RemoveDislike)
+			blogs.GET("/:id/reaction", blogController.GetReaction)
+			blogs.GET("/:id/revisions", blogController.GetRevisions)
+			blogs.GET("/:id/revisions/:version", blogController.GetRevision)
+			blogs.POST("/:id/revisions/:version/restore", blogController.RestoreRevision)
+			blogs.GET("/:id/diff", blogController.DiffRevisions)
 		}
 
 		// AI routes with real auth
 		ai := auth.Group("/ai").Use(middlewares.AuthMiddleware(tokenService))
 		{
-			ai.POST("/suggestions", controllers.GenerateAISuggestion)
-			ai.POST("/ideas", controllers.GenerateContentIdeas)
+			// Routes that call the model are metered and subject to the per-role quota
+			aiQuota := middlewares.AIQuotaMiddleware(aiUsageUC)
+			ai.POST("/suggestions", aiQuota, aiSuggestionController.GenerateAISuggestion)
+			ai.POST("/suggestions/stream", aiQuota, aiSuggestionController.StreamAISuggestion)
+			ai.POST("/ideas", aiQuota, aiSuggestionController.GenerateContentIdeas)
+			ai.POST("/tags", aiQuota, tagController.SuggestTags)
+			ai.GET("/usage", aiUsageController.GetMyUsage)
 			ai.POST("/save", aiSuggestionController.SaveAISuggestion)
 			ai.GET("/suggestions", aiSuggestionController.GetAISuggestions)
 			ai.GET("/suggestions/status/:status", aiSuggestionController.GetAISuggestionsByStatus)
-			ai.POST("/suggestions/:id/convert-to-draft", aiSuggestionController.ConvertSuggestionToDraft)
+			ai.POST("/suggestions/:id/convert-to-draft", middlewares.AIQuotaMiddleware(aiUsageUC, controllers.ExpandsDraft), aiSuggestionController.ConvertSuggestionToDraft)
 			ai.DELETE("/suggestions/:id", aiSuggestionController.DeleteAISuggestion)
 		}
 
@@ -87,13 +156,24 @@ func SetupRouter(r *gin.Engine, userUC usecases.UserUsecaseInterface, blogUC use
 		}
 
 		// Admin-only routes
-		admin := auth.Group("/admin").Use(middlewares.AuthMiddleware(tokenService, "admin", "superadmin"))
+		admin := auth.Group("/admin").Use(middlewares.AuthMiddleware(tokenService, "admin", "superadmin"), middlewares.MFAMiddleware(mfaUC))
 		{
 			admin.POST("/promote", userController.Promote)
+			admin.GET("/ai-usage", aiUsageController.GetUsageReport)
+			admin.POST("/tags/merge", tagController.MergeTags)
+			admin.GET("/moderation", moderationController.GetQueue)
+			admin.GET("/moderation/:id", moderationController.GetItem)
+			admin.POST("/moderation/:id/approve", moderationController.Approve)
+			admin.POST("/moderation/:id/reject", moderationController.Reject)
+			admin.GET("/reports", reportController.GetReports)
+			admin.GET("/reports/:id", reportController.GetReport)
+			admin.POST("/reports/:id/resolve", reportController.Resolve)
+			admin.DELETE("/users/:id/sessions", sessionController.RevokeUserSessions)
+			admin.POST("/users/:id/unlock", userController.UnlockAccount)
 		}
 
 		// Superadmin-only routes
-		superadmin := auth.Group("/superadmin").Use(middlewares.AuthMiddleware(tokenService, "superadmin"))
+		superadmin := auth.Group("/superadmin").Use(middlewares.AuthMiddleware(tokenService, "superadmin"), middlewares.MFAMiddleware(mfaUC))
 		{
 			superadmin.POST("/demote", userController.Demote)
 		}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+// AIProvider generates content suggestions from an AI backend
+type AIProvider interface {
+	// Generate blocks until the backend answers or ctx is cancelled
+	Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error)
+	// Stream calls onToken with each chunk of text as the backend produces it and returns the
+	// parsed generation once it finishes. Cancelling ctx or returning an error from onToken
+	// aborts the upstream call.
+	Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error)
+}
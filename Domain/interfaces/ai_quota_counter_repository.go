@@ -0,0 +1,12 @@
+package interfaces
+
+import "time"
+
+// AIQuotaCounterRepository keeps a request and token counter per user and quota window
+type AIQuotaCounterRepository interface {
+	// ReserveAIRequest counts one request against the counter unless it has already reached either
+	// limit, in which case it reports false. A zero limit is unlimited.
+	ReserveAIRequest(key string, requestLimit, tokenLimit int64, expiresAt time.Time) (bool, error)
+	// AddAIUsage adjusts the counter, if it still exists, by the given requests and tokens
+	AddAIUsage(key string, requests, tokens int64) error
+}
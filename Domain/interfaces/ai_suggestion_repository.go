@@ -7,10 +7,16 @@ type AISuggestionRepository interface {
 	GetAISuggestionByID(suggestionID string) (models.AISuggestion, error)
 	GetAISuggestionsByUserID(userID string, page, limit int) ([]models.AISuggestion, error)
 	UpdateAISuggestion(suggestion models.AISuggestion) (models.AISuggestion, error)
+	// MarkAISuggestionConverted sets the status to converted-to-draft and reports false if it already was
+	MarkAISuggestionConverted(suggestionID string) (bool, error)
 	DeleteAISuggestion(suggestionID string) error
 	
 	// Get suggestions by status
 	GetAISuggestionsByStatus(userID string, status string, page, limit int) ([]models.AISuggestion, error)
+
+	// Keyset pagination; an empty status lists every suggestion
+	GetAISuggestionsByCursor(userID string, status string, page models.PageRequest) ([]models.AISuggestion, bool, error)
+	CountAISuggestions(userID string, status string) (int64, error)
 	
 	// Convert suggestion to draft (creates a blog draft from suggestion)
 	ConvertSuggestionToDraft(suggestionID string, userID string) (models.Blog, error)
@@ -1,6 +1,9 @@
 package interfaces
 
-import "blog-api/Domain/models"
+import (
+	"blog-api/Domain/models"
+	"context"
+)
 
 type AISuggestionUseCase interface {
 	CreateAISuggestion(suggestion models.AISuggestion) (models.AISuggestion, error)
@@ -11,9 +14,17 @@ type AISuggestionUseCase interface {
 	
 	// Get suggestions by status
 	GetAISuggestionsByStatus(userID string, status string, page, limit int) ([]models.AISuggestion, error)
+
+	// Keyset pagination; an empty status lists every suggestion
+	GetAISuggestionsByCursor(userID string, status string, page models.PageRequest) ([]models.AISuggestion, models.PageInfo, error)
+	
+	// Convert suggestion to draft; expand asks the AI provider to write the body
+	ConvertSuggestionToDraft(ctx context.Context, suggestionID string, userID string, expand bool) (models.Blog, error)
 	
-	// Convert suggestion to draft
-	ConvertSuggestionToDraft(suggestionID string, userID string) (models.Blog, error)
+	// Generate suggestions with the configured AI provider
+	GenerateSuggestion(ctx context.Context, request models.AIGenerationRequest) (models.AIGeneration, error)
+	// StreamSuggestion is GenerateSuggestion that hands each chunk of text to onToken as it arrives
+	StreamSuggestion(ctx context.Context, request models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error)
 	
 	// Save AI suggestion with status
 	SaveAISuggestion(userID string, inputTopic string, keywords []string, tone string, suggestions []string) (models.AISuggestion, error)
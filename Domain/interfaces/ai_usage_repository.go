@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"time"
+)
+
+// AIUsageRepository is the ledger of metered AI requests
+type AIUsageRepository interface {
+	RecordAIUsage(record models.AIUsageRecord) (models.AIUsageRecord, error)
+	// SumAIUsage totals the user's requests, failed ones included, made at or after since
+	SumAIUsage(userID string, since time.Time) (models.AIUsageTotals, error)
+	// GetAIUsageSummaries groups the requests made in [from, to) per user, heaviest first
+	GetAIUsageSummaries(from, to time.Time) ([]models.AIUsageSummary, error)
+}
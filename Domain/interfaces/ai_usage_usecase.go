@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"time"
+)
+
+// AIUsageUseCase meters AI requests and enforces the per-role quotas
+type AIUsageUseCase interface {
+	GetUsageStatus(userID string, role string) (models.AIUsageStatus, error)
+	// ReserveRequest holds a place in the user's quota before the model is called. Over quota it
+	// returns a *models.AIQuotaExceededError.
+	ReserveRequest(userID string, role string) (models.AIQuotaReservation, error)
+	// SettleRequest records what a reserved request used
+	SettleRequest(reservation models.AIQuotaReservation, record models.AIUsageRecord) error
+	// ReleaseRequest gives back a reservation whose request did not call the model, or whose call failed
+	ReleaseRequest(reservation models.AIQuotaReservation) error
+	GetUsageReport(from, to time.Time) (models.AIUsageReport, error)
+}
@@ -1,20 +1,50 @@
 package interfaces
 
-import "blog-api/Domain/models"
+import (
+	"blog-api/Domain/models"
+	"time"
+)
 
 type BlogRepository interface {
 	CreateBlog(blog models.Blog) (models.Blog, error)
 	GetPaginatedBlogs(page, limit int) ([]models.Blog, error)
+	// GetBlogsByCursor returns the published blogs next to the cursor and whether more follow in that direction
+	GetBlogsByCursor(page models.PageRequest) ([]models.Blog, bool, error)
+	CountPublishedBlogs() (int64, error)
+	// GetBlogsByAuthorsCursor returns the published blogs of the given authors next to the cursor
+	GetBlogsByAuthorsCursor(authorIDs []string, page models.PageRequest) ([]models.Blog, bool, error)
+	CountPublishedBlogsByAuthors(authorIDs []string) (int64, error)
 	GetBlogByID(blogID string) (models.Blog, error)
-	UpdateBlog(blog models.Blog) (models.Blog, error)
+	// UpdateBlog only writes while the stored version is still expectedVersion and fails with
+	// models.ErrBlogModified otherwise
+	UpdateBlog(blog models.Blog, expectedVersion int) (models.Blog, error)
 	DeleteBlog(blogID string) error
+	UpdateSlug(blogID, slug string) error
+	// GetBlogsWithoutSlug returns the ID and title of every blog that has no slug yet
+	GetBlogsWithoutSlug() ([]models.Blog, error)
+	// SetModerationStatus records a moderation outcome; publish also makes the blog live, while a
+	// held status takes it down
+	SetModerationStatus(blogID, status string, publish bool) error
+	// ReplaceTags rewrites the source tags, in any spelling, to target on every blog and returns how many changed
+	ReplaceTags(sources []string, target string) (int64, error)
 
-	SearchBlogs(query string) ([]models.Blog, error)
+	// SearchBlogs runs a full-text search over published blogs, best matches first
+	SearchBlogs(query string, page, limit int) ([]models.BlogSearchResult, error)
+	CountSearchResults(query string) (int64, error)
 	FilterBlogs(tags []string, dateRange [2]string, sortBy string) ([]models.Blog, error)
+	// QueryBlogs applies every filter in the query to published blogs and returns one page plus facet counts
+	QueryBlogs(query models.BlogQuery) (models.BlogQueryResult, error)
+
+	// An author's own posts, including drafts, filtered by BlogStatus*
+	GetBlogsByAuthor(authorID, status string, page, limit int) ([]models.Blog, error)
+	CountBlogsByAuthor(authorID, status string) (int64, error)
+
+	// Scheduled publishing
+	GetScheduledBlogs(authorID string) ([]models.Blog, error)
+	// PublishDueBlog atomically publishes one draft whose PublishAt has passed, or returns nil if none is due
+	PublishDueBlog(now time.Time) (*models.Blog, error)
 
 	IncrementViewCount(blogID string) error
 	UpdateLikes(blogID string, increment bool) error
 	UpdateDislikes(blogID string, increment bool) error
-	AddComment(blogID string, comment models.Comment) (models.Comment, error)
-	GetComments(blogID string) ([]models.Comment, error)
 }
@@ -0,0 +1,30 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// BookmarkRepository stores users' bookmark collections and the posts saved in them
+type BookmarkRepository interface {
+	// CreateCollection fails if the user already has a collection with that name
+	CreateCollection(collection models.BookmarkCollection) (models.BookmarkCollection, error)
+	GetCollectionByID(collectionID string) (models.BookmarkCollection, error)
+	GetCollectionByName(userID, name string) (models.BookmarkCollection, error)
+	// GetCollections lists the user's collections oldest first
+	GetCollections(userID string) ([]models.BookmarkCollection, error)
+	RenameCollection(collectionID, name string) error
+	// DeleteCollection removes the collection along with its bookmarks
+	DeleteCollection(collectionID string) error
+
+	// AddBookmark appends the post to the end of the collection. It returns false if the post
+	// already is in the collection.
+	AddBookmark(bookmark models.Bookmark) (models.Bookmark, bool, error)
+	GetBookmarkByID(bookmarkID string) (models.Bookmark, error)
+	// GetBookmarks lists a page of the collection in its order, optionally only unread posts
+	GetBookmarks(collectionID string, unreadOnly bool, page, limit int) ([]models.Bookmark, error)
+	CountBookmarks(collectionID string, unreadOnly bool) (int64, error)
+	// GetBookmarkIDs lists the IDs of every bookmark in the collection in its order
+	GetBookmarkIDs(collectionID string) ([]string, error)
+	// SetPositions puts the bookmarks of the collection in the given order
+	SetPositions(collectionID string, bookmarkIDs []string) error
+	SetRead(bookmarkID string, read bool) error
+	RemoveBookmark(bookmarkID string) error
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+type BookmarkUseCase interface {
+	CreateCollection(userID, name string) (models.BookmarkCollection, error)
+	GetCollections(userID string) ([]models.BookmarkCollection, error)
+	RenameCollection(userID, collectionID, name string) (models.BookmarkCollection, error)
+	// DeleteCollection deletes the collection and every bookmark in it
+	DeleteCollection(userID, collectionID string) error
+
+	// AddBookmark saves a published post at the end of the collection, or of the default
+	// collection when collectionID is empty, and tracks it as a bookmark action
+	AddBookmark(userID, blogID, collectionID string) (models.Bookmark, error)
+	GetBookmarks(userID, collectionID string, unreadOnly bool, page, limit int) ([]models.Bookmark, int64, error)
+	// ReorderBookmarks takes every bookmark ID of the collection in the new order
+	ReorderBookmarks(userID, collectionID string, bookmarkIDs []string) error
+	MarkRead(userID, bookmarkID string, read bool) (models.Bookmark, error)
+	RemoveBookmark(userID, bookmarkID string) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// CommentRepository stores threaded blog comments in their own collection
+type CommentRepository interface {
+	CreateComment(comment models.Comment) (models.Comment, error)
+	GetCommentByID(commentID string) (models.Comment, error)
+	UpdateComment(commentID string, content string) (models.Comment, error)
+	// SoftDeleteComment blanks the comment but keeps it as a tombstone so replies stay attached
+	SoftDeleteComment(commentID string) error
+	// SetModerationStatus records a moderation outcome, keeping the blog's comment counter to visible comments
+	SetModerationStatus(commentID, status string) error
+
+	// Top-level comments of a blog, oldest first
+	GetRootComments(blogID string, page, limit int) ([]models.Comment, error)
+	GetRootCommentsByCursor(blogID string, page models.PageRequest) ([]models.Comment, bool, error)
+	CountRootComments(blogID string) (int64, error)
+	// All replies belonging to the given top-level comments
+	GetThreadReplies(rootIDs []string) ([]models.Comment, error)
+}
@@ -1,7 +1,12 @@
 package interfaces
 
+import "time"
+
 type EmailService interface {
 	SendEmail(to string, subject string, message string) error
 	SendVerificationEmail(username, email, token string) error
 	SendPasswordResetEmail(username, email, token string) error
+	SendModerationResultEmail(username, email, title, status, note string) error
+	SendWarningEmail(username, email, reason string) error
+	SendSuspensionEmail(username, email, reason string, until time.Time) error
 }
@@ -0,0 +1,12 @@
+package interfaces
+
+// FollowRepository stores who follows whom
+type FollowRepository interface {
+	// Follow records the follow; it reports false if the follower already followed the author
+	Follow(followerID, followeeID string) (bool, error)
+	// Unfollow removes the follow; it reports false if there was none
+	Unfollow(followerID, followeeID string) (bool, error)
+	IsFollowing(followerID, followeeID string) (bool, error)
+	// GetFollowing lists the IDs of every author the user follows
+	GetFollowing(followerID string) ([]string, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+type FollowUseCase interface {
+	// Follow and Unfollow are idempotent
+	Follow(ctx context.Context, followerID, authorID string) error
+	Unfollow(ctx context.Context, followerID, authorID string) error
+	// GetAuthorProfile shows an author with their follow counts; viewerID may be empty
+	GetAuthorProfile(ctx context.Context, authorID, viewerID string) (models.AuthorProfile, error)
+	// GetFeed returns the published posts of the authors the user follows, newest first
+	GetFeed(userID string, page models.PageRequest) ([]models.Blog, models.PageInfo, error)
+}
@@ -6,7 +6,9 @@ import (
 )
 
 type TokenService interface {
-	GenerateAccessToken(userID, email, role string) (string, error)
+	// GenerateAccessToken stamps the user's current token version into the token, and whether they
+	// signed in with a second factor
+	GenerateAccessToken(userID, email, role string, version int, mfa bool) (string, error)
 	GenerateRefreshToken(userID, email, role string) (*models.Token, error)
 	VerifyAccessToken(tokenStr string) (*models.UserAccessClaims, error)
 	VerifyRefreshToken(tokenStr string) (*models.UserRefreshClaims, error)
@@ -14,10 +16,25 @@ type TokenService interface {
 	VerifyJWT(tokenStr string) (models.TokenClaims, error)
 	HashToken(token string) string
 	VerifyToken(hashed, token string) bool
+	// GenerateMFAToken issues the short-lived challenge that proves the password was checked
+	GenerateMFAToken(userID string) (string, error)
+	// VerifyMFAToken returns the user ID of a valid challenge
+	VerifyMFAToken(tokenStr string) (string, error)
 }
 type TokenRepository interface {
 	CreateToken(token *models.Token) error
 	DeleteToken(tokenID string) error
 	Update(token *models.Token) error
 	GetToken(tokenID string) (*models.Token, error)
+	// RotateToken marks a refresh token as exchanged. It returns false if it already was, so of
+	// two refreshes with the same token only one goes through.
+	RotateToken(tokenID string) (bool, error)
+	// DeleteTokenFamily deletes every refresh token of the family
+	DeleteTokenFamily(familyID string) error
+	// GetActiveTokens lists the user's unexpired refresh tokens that were not rotated, one per
+	// session
+	GetActiveTokens(userID string) ([]*models.Token, error)
+	// DeleteUserTokens deletes the user's refresh tokens except those of the kept family, if one
+	// is given, and returns how many it deleted
+	DeleteUserTokens(userID, keepFamilyID string) (int64, error)
 }
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"time"
+)
+
+// LoginAttemptRepository counts recent attempts per key, such as an account or a client IP
+type LoginAttemptRepository interface {
+	// GetAttempts returns the key's counter, empty when nothing was counted
+	GetAttempts(key string) (models.AttemptCounter, error)
+	// ReserveAttempt counts one more attempt at now, starting over when the last one is window old,
+	// but only while the counter is still as seen; it reports false when another attempt changed it
+	// first
+	ReserveAttempt(seen models.AttemptCounter, now time.Time, window time.Duration) (bool, error)
+	// ReleaseAttempt takes back one counted attempt
+	ReleaseAttempt(key string) error
+	ClearAttempts(key string) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+// LoginThrottle slows down password guessing and floods of password reset emails. Accounts are
+// keyed by email whether or not they exist, so throttling reveals nothing about them. Throttled
+// calls return a *models.ThrottledError.
+type LoginThrottle interface {
+	// AttemptLogin counts a login attempt against the account and the client IP before the
+	// password is checked, and fails without counting it while either has to wait. Counting first
+	// means parallel guesses cannot all pass before the first failure is recorded.
+	AttemptLogin(email, ip string) error
+	// LoginSucceeded clears the account's attempts and takes back the IP's attempt; the IP's
+	// failures wear off on their own
+	LoginSucceeded(email, ip string) error
+	// AllowPasswordReset counts a reset request and fails without counting it when the address or
+	// IP asked too often
+	AllowPasswordReset(email, ip string) error
+	// UnlockAccount clears the account's failed logins
+	UnlockAccount(email string) error
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+type MFAUseCase interface {
+	GetStatus(ctx context.Context, userID string) (models.MFAStatus, error)
+	// Enroll starts over with a new secret; MFA is on once a code from it is confirmed. Enroll and
+	// Confirm ask for the current password, so a stolen access token cannot put its holder's
+	// authenticator on the account; wrong passwords are throttled like failed logins.
+	Enroll(ctx context.Context, userID, password string, client models.ClientInfo) (models.MFAEnrollment, error)
+	// Confirm turns MFA on and returns the recovery codes, which are shown only this once. Every
+	// session is signed out so that none outlives the switch without a second factor.
+	Confirm(ctx context.Context, userID, password, code string, client models.ClientInfo) ([]string, error)
+	// RegenerateRecoveryCodes replaces the recovery codes
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID, code string) error
+	// RequiredFor reports whether users of the role must sign in with a second factor
+	RequiredFor(role string) bool
+}
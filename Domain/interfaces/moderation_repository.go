@@ -0,0 +1,17 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// ModerationRepository stores the moderation queue
+type ModerationRepository interface {
+	// QueueItem adds held content to the queue, replacing the open item of the same content if any
+	QueueItem(item models.ModerationItem) (models.ModerationItem, error)
+	GetItemByID(itemID string) (models.ModerationItem, error)
+	// GetPendingItem retrieves the item of content that is waiting for review
+	GetPendingItem(contentType, contentID string) (models.ModerationItem, error)
+	// GetItems lists the queue oldest first; an empty status lists every item
+	GetItems(status string, page, limit int) ([]models.ModerationItem, error)
+	CountItems(status string) (int64, error)
+	// ResolveItem records the decision on a pending item; it fails for an item already reviewed
+	ResolveItem(itemID, status, reviewerID, note string) (models.ModerationItem, error)
+}
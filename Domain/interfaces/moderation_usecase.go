@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+type ModerationUseCase interface {
+	// Check runs the moderator over new or edited content
+	Check(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error)
+	// Hold queues content the check held back; publish is whether the author asked for a post to go live
+	Hold(content models.ModerationContent, verdict models.ModerationVerdict, publish bool) (models.ModerationItem, error)
+	// Flag takes live content down and queues it for review, e.g. once readers reported it
+	Flag(contentType, contentID string, reasons []string) (models.ModerationItem, error)
+
+	GetQueue(status string, page, limit int) ([]models.ModerationItem, int64, error)
+	GetItem(itemID string) (models.ModerationItem, error)
+	// GetPendingItem retrieves the queue item of content that is waiting for review
+	GetPendingItem(contentType, contentID string) (models.ModerationItem, error)
+	// Review approves or rejects held content, shows or hides it accordingly and notifies its author
+	Review(ctx context.Context, itemID, reviewerID, status, note string) (models.ModerationItem, error)
+}
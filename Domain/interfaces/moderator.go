@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+// Moderator screens posts and comments before they go live
+type Moderator interface {
+	Moderate(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+// OTPService generates and checks time-based one-time passwords (RFC 6238)
+type OTPService interface {
+	GenerateSecret() (string, error)
+	// ProvisioningURI is the otpauth:// URI authenticator apps read from a QR code
+	ProvisioningURI(secret, accountName string) string
+	// VerifyCode returns the time step the code belongs to; a step of clock drift is allowed
+	VerifyCode(secret, code string) (int64, bool)
+	// GenerateRecoveryCodes returns n new recovery codes with a new salt to hash them with
+	GenerateRecoveryCodes(n int) (codes []string, salt string, err error)
+	// HashRecoveryCode hashes a recovery code for storage, ignoring case, spaces and dashes
+	HashRecoveryCode(salt, code string) string
+}
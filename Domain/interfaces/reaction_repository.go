@@ -0,0 +1,15 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// ReactionRepository stores per-user likes/dislikes and keeps the blog counters in sync
+type ReactionRepository interface {
+	// SetReaction records the user's reaction, replacing an opposite one. Repeating the same reaction is a no-op.
+	SetReaction(userID, blogID, reactionType string) (models.Reaction, error)
+	// RemoveReaction removes the user's reaction if it matches reactionType
+	RemoveReaction(userID, blogID, reactionType string) error
+	// GetReaction returns the user's current reaction, or nil if there is none
+	GetReaction(userID, blogID string) (*models.Reaction, error)
+	// DeleteBlogReactions removes every reaction recorded on a blog
+	DeleteBlogReactions(blogID string) error
+}
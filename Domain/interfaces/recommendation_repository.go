@@ -18,6 +18,9 @@ type RecommendationRepository interface {
 	// User Recommendations
 	CreateUserRecommendation(recommendation models.UserRecommendation) error
 	GetUserRecommendations(userID string, limit int, category string) ([]models.UserRecommendation, error)
+	GetUserRecommendationsByCursor(userID string, category string, page models.PageRequest) ([]models.UserRecommendation, bool, error)
+	CountUserRecommendations(userID string, category string) (int64, error)
+	DeleteUserRecommendations(userID string) error
 	UpdateRecommendationViewed(recommendationID string) error
 	DeleteExpiredRecommendations() error
 	GetRecommendationStats(userID string) (models.RecommendationStats, error)
@@ -26,6 +29,7 @@ type RecommendationRepository interface {
 	UpdateUserInterest(interest models.UserInterest) error
 	GetUserInterests(userID string) ([]models.UserInterest, error)
 	GetTopUserInterests(userID string, limit int) ([]models.UserInterest, error)
+	DeleteUserInterests(userID string, topics []string) error
 
 	// Content Analysis
 	GetPopularTags(limit int) ([]string, error)
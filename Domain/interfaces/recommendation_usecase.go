@@ -6,7 +6,7 @@ import "blog-api/Domain/models"
 type RecommendationUseCase interface {
 	// User Actions
 	TrackUserAction(userID, blogID, action string) error
-	GetUserRecommendations(userID string, limit int, category string) (models.RecommendationResponse, error)
+	GetUserRecommendations(userID string, category string, page models.PageRequest) (models.RecommendationResponse, error)
 	MarkRecommendationViewed(recommendationID string) error
 
 	// Content Discovery
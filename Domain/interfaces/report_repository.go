@@ -0,0 +1,17 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// ReportRepository stores readers' reports about posts and comments
+type ReportRepository interface {
+	// CreateReport files a report; it fails if the reporter already reported the same content
+	CreateReport(report models.Report) (models.Report, error)
+	GetReportByID(reportID string) (models.Report, error)
+	// GetReports lists reports oldest first; an empty status lists every report
+	GetReports(status string, page, limit int) ([]models.Report, error)
+	CountReports(status string) (int64, error)
+	// CountOpenReports counts the open reports of one post or comment
+	CountOpenReports(contentType, contentID string) (int64, error)
+	// ResolveReports closes the open reports of one post or comment and returns how many it closed
+	ResolveReports(contentType, contentID, resolverID, action, note string) (int64, error)
+}
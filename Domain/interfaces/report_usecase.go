@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+type ReportUseCase interface {
+	// ReportBlog and ReportComment file a reader's report. Content is taken down for review once
+	// enough readers reported it.
+	ReportBlog(blogID, reporterID, reason, details string) (models.Report, error)
+	ReportComment(blogID, commentID, reporterID, reason, details string) (models.Report, error)
+
+	GetReports(status string, page, limit int) ([]models.Report, int64, error)
+	GetReport(reportID string) (models.Report, error)
+	// Resolve closes every open report of the reported content and carries out the action;
+	// suspendDays is how long the author is suspended for
+	Resolve(ctx context.Context, reportID, resolverID, action, note string, suspendDays int) (models.Report, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// RevisionRepository stores archived blog versions. Revisions are append-only.
+type RevisionRepository interface {
+	// CreateRevision fails if the version was already archived, which guards against concurrent edits
+	CreateRevision(revision models.BlogRevision) (models.BlogRevision, error)
+	GetRevisions(blogID string) ([]models.BlogRevision, error)
+	GetRevision(blogID string, version int) (models.BlogRevision, error)
+	// DeleteRevision takes back a revision whose blog update did not go through
+	DeleteRevision(blogID string, version int) error
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+// ScheduledPublisher publishes drafts whose scheduled time has passed
+type ScheduledPublisher interface {
+	// PublishDueBlogs returns how many drafts were published by this call
+	PublishDueBlogs() (int, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+// SessionUseCase lets users see and end the sessions their refresh tokens keep alive
+type SessionUseCase interface {
+	GetSessions(userID string) ([]models.Session, error)
+	RevokeSession(userID, sessionID string) error
+	// RevokeOtherSessions ends every session of the user except the one the refresh token belongs
+	// to and returns how many it ended
+	RevokeOtherSessions(userID, refreshToken string) (int64, error)
+	// RevokeAllSessions ends every session of a user; admins use it on accounts they lock out
+	RevokeAllSessions(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// SlugRepository keeps slugs unique across blogs
+type SlugRepository interface {
+	// ReserveSlug claims the slug for the blog. It returns false if another blog already owns it.
+	ReserveSlug(slug, blogID string) (bool, error)
+	// ReleaseSlug gives up the blog's claim on the slug; slugs owned by other blogs are left alone
+	ReleaseSlug(slug, blogID string) error
+	// ReleaseBlogSlugs frees every slug the blog holds, its earlier ones included
+	ReleaseBlogSlugs(blogID string) error
+	// GetSlug returns the reservation for a slug, or nil if it was never used
+	GetSlug(slug string) (*models.BlogSlug, error)
+}
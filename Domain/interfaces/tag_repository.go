@@ -0,0 +1,13 @@
+package interfaces
+
+import "blog-api/Domain/models"
+
+// TagRepository stores the tag vocabulary: canonical tags and their aliases
+type TagRepository interface {
+	GetTags() ([]models.Tag, error)
+	// FindTags returns the tags named by, or aliased as, any of the normalized names
+	FindTags(names []string) ([]models.Tag, error)
+	// MergeTags makes target a canonical tag with the sources and their aliases as aliases, and
+	// removes the source tags from the vocabulary
+	MergeTags(sources []string, target string) (models.Tag, error)
+}
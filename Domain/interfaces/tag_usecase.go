@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"blog-api/Domain/models"
+	"context"
+)
+
+type TagUseCase interface {
+	GetTags() ([]models.Tag, error)
+	// SuggestTags proposes tags for a draft, falling back to keyword extraction when the AI
+	// provider fails
+	SuggestTags(ctx context.Context, title, content string) (models.TagSuggestion, error)
+	// MergeTags folds the source tags into the target and rewrites the blogs that use them
+	MergeTags(sources []string, target string) (models.TagMergeResult, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+// TokenVersionStore tracks each user's token version. Access tokens carrying an older version
+// than the user's current one are revoked.
+type TokenVersionStore interface {
+	Current(ctx context.Context, userID string) (int, error)
+	// Bump revokes every access token the user holds
+	Bump(ctx context.Context, userID string) error
+}
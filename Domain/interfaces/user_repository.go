@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"blog-api/Domain/models"
 )
@@ -19,4 +20,25 @@ type UserRepository interface {
 	Delete(email string) error
 	Verify(email string) error
 	CountUsers() (int64, error)
+
+	// AdjustFollowCounts moves the follower's following count and the followee's followers count by delta
+	AdjustFollowCounts(ctx context.Context, followerID, followeeID string, delta int) error
+	// AddWarning counts a warning an admin gave the user
+	AddWarning(ctx context.Context, id string) error
+	// Suspend locks the user out until the given time
+	Suspend(ctx context.Context, id string, until time.Time, reason string) error
+	// IncrementTokenVersion bumps the user's token version and returns the new one
+	IncrementTokenVersion(ctx context.Context, id string) (int, error)
+
+	// SetMFASecret stores the secret of an enrolment that is not confirmed yet
+	SetMFASecret(ctx context.Context, id, secret string) error
+	// EnableMFA turns MFA on and replaces the hashed recovery codes and the salt they were hashed with
+	EnableMFA(ctx context.Context, id, recoverySalt string, recoveryCodeHashes []string) error
+	// DisableMFA turns MFA off and forgets the secret and recovery codes
+	DisableMFA(ctx context.Context, id string) error
+	// ClaimMFAStep records the time step of an accepted code. It returns false if that step or a
+	// later one was already used, so a code cannot be replayed.
+	ClaimMFAStep(ctx context.Context, id string, step int64) (bool, error)
+	// UseRecoveryCode removes the hashed recovery code, returning false if the user has no such code
+	UseRecoveryCode(ctx context.Context, id, codeHash string) (bool, error)
 }
@@ -0,0 +1,96 @@
+package models
+
+// Kinds of AI suggestion
+const (
+	// AISuggestionTypeImprovement suggests rewrites for an existing post
+	AISuggestionTypeImprovement = "improvement"
+	// AISuggestionTypeIdeas proposes a new post from keywords
+	AISuggestionTypeIdeas = "ideas"
+	// AISuggestionTypeTitle proposes alternative titles for a post
+	AISuggestionTypeTitle = "title"
+	// AISuggestionTypeSummary writes a TL;DR of a post
+	AISuggestionTypeSummary = "summary"
+	// AISuggestionTypeDraft expands a saved idea into a full post body
+	AISuggestionTypeDraft = "draft"
+	// AISuggestionTypeTags proposes tags for a post
+	AISuggestionTypeTags = "tags"
+	// AISuggestionTypeModeration screens a post or comment before it goes live
+	AISuggestionTypeModeration = "moderation"
+)
+
+// AIGenerationRequest is what an AI provider is asked to generate
+type AIGenerationRequest struct {
+	Type     string   `json:"type"`
+	Keywords []string `json:"keywords"`
+	Tone     string   `json:"tone"`
+	Content  string   `json:"content,omitempty"`
+
+	// draft: the idea to expand; tags and moderation: the post's title
+	Title     string   `json:"title,omitempty"`
+	Audience  string   `json:"audience,omitempty"`
+	Headlines []string `json:"headlines,omitempty"`
+}
+
+// AIGeneration is a provider's structured answer. Which fields are set depends on Type.
+type AIGeneration struct {
+	Type string `json:"type"`
+
+	// ideas: a post idea with its audience and section headlines
+	Title     string   `json:"title,omitempty"`
+	Audience  string   `json:"audience,omitempty"`
+	Headlines []string `json:"headlines,omitempty"`
+
+	// improvement: concrete rewrite suggestions
+	Improvements []string `json:"improvements,omitempty"`
+
+	// title: alternative titles
+	Titles []string `json:"titles,omitempty"`
+
+	// summary: a short TL;DR
+	Summary string `json:"summary,omitempty"`
+
+	// draft: a post body with one section per headline
+	Intro      string           `json:"intro,omitempty"`
+	Sections   []AIDraftSection `json:"sections,omitempty"`
+	Conclusion string           `json:"conclusion,omitempty"`
+
+	// tags: tags for the post
+	Tags []string `json:"tags,omitempty"`
+
+	// moderation: allow, review or reject, and why
+	Verdict string   `json:"verdict,omitempty"`
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Usage is what the call cost, as reported by the backend or estimated from the text
+	Usage AITokenUsage `json:"usage"`
+
+	// Cache tells whether the generation came from the response cache; empty when uncached
+	Cache string `json:"-"`
+}
+
+// How the response cache served a generation
+const (
+	// AICacheHit was answered from the cache without calling the backend
+	AICacheHit = "hit"
+	// AICacheMiss called the backend and cached the answer
+	AICacheMiss = "miss"
+	// AICacheShared waited on an identical call already in flight and shared its answer
+	AICacheShared = "shared"
+)
+
+// AITokenUsage counts the tokens of one AI call
+type AITokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens" bson:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens" bson:"completion_tokens"`
+}
+
+// Total is the number of tokens billed for the call
+func (u AITokenUsage) Total() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// AIDraftSection is one headed section of a generated draft
+type AIDraftSection struct {
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AIUsageRecord is one metered request to an AI endpoint. A request can make several
+// upstream calls; their tokens and latency are added up.
+type AIUsageRecord struct {
+	ID               string    `json:"id" bson:"_id,omitempty"`
+	UserID           string    `json:"user_id" bson:"user_id"`
+	Role             string    `json:"role" bson:"role"`
+	Endpoint         string    `json:"endpoint" bson:"endpoint"`
+	Calls            int       `json:"calls" bson:"calls"`
+	PromptTokens     int       `json:"prompt_tokens" bson:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens" bson:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens" bson:"total_tokens"`
+	LatencyMS        int64     `json:"latency_ms" bson:"latency_ms"`
+	Success          bool      `json:"success" bson:"success"`
+	CreatedAt        time.Time `json:"created_at" bson:"created_at"`
+}
+
+// AIQuota caps a role's AI use. A zero limit means unlimited.
+type AIQuota struct {
+	DailyRequests   int64 `json:"daily_requests"`
+	MonthlyRequests int64 `json:"monthly_requests"`
+	DailyTokens     int64 `json:"daily_tokens"`
+	MonthlyTokens   int64 `json:"monthly_tokens"`
+}
+
+// AIUsageTotals sums the requests of a period, failed ones included
+type AIUsageTotals struct {
+	Requests int64 `json:"requests" bson:"requests"`
+	Tokens   int64 `json:"tokens" bson:"tokens"`
+}
+
+// AIUsageWindow is a user's usage against the limits of one quota period
+type AIUsageWindow struct {
+	Requests     int64     `json:"requests"`
+	RequestLimit int64     `json:"request_limit"`
+	Tokens       int64     `json:"tokens"`
+	TokenLimit   int64     `json:"token_limit"`
+	ResetAt      time.Time `json:"reset_at"`
+}
+
+// Exhausted reports whether either limit of the window has been reached
+func (w AIUsageWindow) Exhausted() bool {
+	return (w.RequestLimit > 0 && w.Requests >= w.RequestLimit) ||
+		(w.TokenLimit > 0 && w.Tokens >= w.TokenLimit)
+}
+
+// AIUsageStatus is a user's standing against their role's quota. Windows are calendar days
+// and months in UTC.
+type AIUsageStatus struct {
+	Role     string        `json:"role"`
+	Daily    AIUsageWindow `json:"daily"`
+	Monthly  AIUsageWindow `json:"monthly"`
+	Exceeded bool          `json:"exceeded"`
+	// ResetAt is when AI requests are allowed again; set only when Exceeded
+	ResetAt *time.Time `json:"reset_at,omitempty"`
+}
+
+// AIQuotaReservation holds one request's place in its user's quota counters until it is settled
+// with what the request used, or released when it made no AI call
+type AIQuotaReservation struct {
+	UserID string
+	Keys   []string
+}
+
+// AIQuotaExceededError is returned when a request would go over the caller's quota
+type AIQuotaExceededError struct {
+	ResetAt time.Time
+}
+
+func (e *AIQuotaExceededError) Error() string {
+	return "AI usage quota exceeded"
+}
+
+// AIUsageSummary is one user's line in the admin usage report
+type AIUsageSummary struct {
+	UserID           string    `json:"user_id" bson:"_id"`
+	Requests         int64     `json:"requests" bson:"requests"`
+	FailedRequests   int64     `json:"failed_requests" bson:"failed_requests"`
+	PromptTokens     int64     `json:"prompt_tokens" bson:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens" bson:"completion_tokens"`
+	TotalTokens      int64     `json:"total_tokens" bson:"total_tokens"`
+	AvgLatencyMS     float64   `json:"avg_latency_ms" bson:"avg_latency_ms"`
+	MaxLatencyMS     int64     `json:"max_latency_ms" bson:"max_latency_ms"`
+	LastUsedAt       time.Time `json:"last_used_at" bson:"last_used_at"`
+}
+
+// AIUsageReport is AI usage across all users over [From, To), heaviest users first
+type AIUsageReport struct {
+	From        time.Time        `json:"from"`
+	To          time.Time        `json:"to"`
+	Requests    int64            `json:"requests"`
+	TotalTokens int64            `json:"total_tokens"`
+	Users       []AIUsageSummary `json:"users"`
+}
+
+// AIUsageMeter adds up the upstream AI calls made while serving one request
+type AIUsageMeter struct {
+	mu      sync.Mutex
+	calls   int
+	failed  int
+	usage   AITokenUsage
+	latency time.Duration
+}
+
+// Add records one upstream call
+func (m *AIUsageMeter) Add(usage AITokenUsage, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if err != nil {
+		m.failed++
+	}
+	m.usage.PromptTokens += usage.PromptTokens
+	m.usage.CompletionTokens += usage.CompletionTokens
+	m.latency += latency
+}
+
+// Merge adds the calls recorded by another meter
+func (m *AIUsageMeter) Merge(other *AIUsageMeter) {
+	other.mu.Lock()
+	calls, failed, usage, latency := other.calls, other.failed, other.usage, other.latency
+	other.mu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls += calls
+	m.failed += failed
+	m.usage.PromptTokens += usage.PromptTokens
+	m.usage.CompletionTokens += usage.CompletionTokens
+	m.latency += latency
+}
+
+// Record turns the calls so far into a ledger entry; ok is false when no call was made
+func (m *AIUsageMeter) Record() (record AIUsageRecord, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.calls == 0 {
+		return AIUsageRecord{}, false
+	}
+
+	return AIUsageRecord{
+		Calls:            m.calls,
+		PromptTokens:     m.usage.PromptTokens,
+		CompletionTokens: m.usage.CompletionTokens,
+		TotalTokens:      m.usage.Total(),
+		LatencyMS:        m.latency.Milliseconds(),
+		Success:          m.failed == 0,
+	}, true
+}
+
+type aiUsageMeterKey struct{}
+
+// ContextWithAIUsageMeter attaches a meter that AI calls made with the returned context report to
+func ContextWithAIUsageMeter(ctx context.Context, meter *AIUsageMeter) context.Context {
+	return context.WithValue(ctx, aiUsageMeterKey{}, meter)
+}
+
+// AIUsageMeterFromContext returns the meter attached to ctx, or nil
+func AIUsageMeterFromContext(ctx context.Context) *AIUsageMeter {
+	meter, _ := ctx.Value(aiUsageMeterKey{}).(*AIUsageMeter)
+	return meter
+}
@@ -1,34 +1,69 @@
 package models
 
 import (
+	"errors"
 	"time"
 )
 
+// ErrBlogNotFound is returned when a blog does not exist or is not visible to the caller
+var ErrBlogNotFound = errors.New("blog not found")
+
 // Blog represents a blog post in the system
 // Following clean architecture: domain layer should be independent of infrastructure
 type Blog struct {
-	ID          string    `json:"id" bson:"_id,omitempty"`
-	Title       string    `json:"title" bson:"title"`
-	Content     string    `json:"content" bson:"content"`
-	AuthorID    string    `json:"author_id" bson:"author_id"`
-	AuthorName  string    `json:"author_name" bson:"author_name"`
-	Tags        []string  `json:"tags" bson:"tags"`
-	ViewCount   int       `json:"view_count" bson:"view_count"`
-	Likes       int       `json:"likes" bson:"likes"`
-	Dislikes    int       `json:"dislikes" bson:"dislikes"`
-	Comments    []Comment `json:"comments" bson:"comments"`
-	IsPublished bool      `json:"is_published" bson:"is_published"`
-	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+	ID           string     `json:"id" bson:"_id,omitempty"`
+	Title        string     `json:"title" bson:"title"`
+	Slug         string     `json:"slug" bson:"slug"`
+	Content      string     `json:"content" bson:"content"`
+	AuthorID     string     `json:"author_id" bson:"author_id"`
+	AuthorName   string     `json:"author_name" bson:"author_name"`
+	Tags         []string   `json:"tags" bson:"tags"`
+	ViewCount    int        `json:"view_count" bson:"view_count"`
+	Likes        int        `json:"likes" bson:"likes"`
+	Dislikes     int        `json:"dislikes" bson:"dislikes"`
+	CommentCount int        `json:"comment_count" bson:"comment_count"`
+	IsPublished  bool       `json:"is_published" bson:"is_published"`
+	PublishAt    *time.Time `json:"publish_at,omitempty" bson:"publish_at,omitempty"` // when a draft is scheduled to go live
+	Version      int        `json:"version" bson:"version"`
+	CreatedAt    time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" bson:"updated_at"`
+
+	// SourceSuggestionID is the AI suggestion the blog was drafted from, if any
+	SourceSuggestionID string `json:"source_suggestion_id,omitempty" bson:"source_suggestion_id,omitempty"`
+
+	// ModerationStatus is set once moderation held the blog back; held blogs stay unpublished
+	ModerationStatus string `json:"moderation_status,omitempty" bson:"moderation_status,omitempty"`
 }
 
-// Comment represents a comment on a blog post
+// Blog status filters for an author's own posts
+const (
+	BlogStatusAll       = "all"
+	BlogStatusDraft     = "draft"
+	BlogStatusScheduled = "scheduled"
+	BlogStatusPublished = "published"
+)
+
+// Comment represents a comment on a blog post.
+// Comments live in their own collection and form threads through ParentID;
+// RootID points at the top-level comment so a whole thread can be loaded at once.
 type Comment struct {
-	ID         string    `json:"id" bson:"_id,omitempty"`
-	BlogID     string    `json:"blog_id" bson:"blog_id"`
-	AuthorID   string    `json:"author_id" bson:"author_id"`
-	AuthorName string    `json:"author_name" bson:"author_name"`
-	Content    string    `json:"content" bson:"content"`
-	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+	ID         string     `json:"id" bson:"_id,omitempty"`
+	BlogID     string     `json:"blog_id" bson:"blog_id"`
+	ParentID   string     `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	RootID     string     `json:"root_id,omitempty" bson:"root_id,omitempty"`
+	Depth      int        `json:"depth" bson:"depth"`
+	AuthorID   string     `json:"author_id" bson:"author_id"`
+	AuthorName string     `json:"author_name" bson:"author_name"`
+	Content    string     `json:"content" bson:"content"`
+	IsDeleted  bool       `json:"is_deleted" bson:"is_deleted"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" bson:"updated_at"`
+	Replies    []Comment  `json:"replies,omitempty" bson:"-"`
+
+	// ModerationStatus is set once moderation held the comment back; held comments are left out of threads
+	ModerationStatus string `json:"moderation_status,omitempty" bson:"moderation_status,omitempty"`
 }
+
+// MaxCommentDepth is the deepest level a reply can be nested at (top-level comments are depth 0)
+const MaxCommentDepth = 3
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// Tag matching modes for BlogQuery
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// SortByRelevance orders results by text score and is only meaningful when a text query is given
+const SortByRelevance = "relevance"
+
+// BlogQuery combines every filter supported by the faceted blog listing.
+// Zero values mean "no constraint".
+type BlogQuery struct {
+	Text      string
+	Tags      []string
+	TagMatch  string // TagMatchAny or TagMatchAll
+	AuthorID  string
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+	MinLikes  int
+	MinViews  int
+	SortBy    string
+	SortOrder int // 1 ascending, -1 descending
+	Page      int
+	Limit     int
+}
+
+// FacetCount is the number of matching blogs sharing one value of a facet
+type FacetCount struct {
+	Value string `json:"value" bson:"_id"`
+	Label string `json:"label,omitempty" bson:"label,omitempty"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// BlogFacets breaks the full result set of a BlogQuery down by tag, author and month
+type BlogFacets struct {
+	Tags    []FacetCount `json:"tags"`
+	Authors []FacetCount `json:"authors"`
+	Months  []FacetCount `json:"months"` // value formatted as YYYY-MM
+}
+
+// BlogQueryResult is one page of a BlogQuery with the total and facets of all matches
+type BlogQueryResult struct {
+	Blogs  []Blog     `json:"blogs"`
+	Total  int64      `json:"total"`
+	Facets BlogFacets `json:"facets"`
+}
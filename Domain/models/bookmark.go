@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// DefaultBookmarkCollection is the collection posts are bookmarked into when none is given. It
+// is created the first time it is needed.
+const DefaultBookmarkCollection = "Reading list"
+
+// BookmarkCollection is a user's named list of bookmarked posts
+type BookmarkCollection struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	UserID    string    `json:"user_id" bson:"user_id"`
+	Name      string    `json:"name" bson:"name"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Bookmark is a post saved in a collection. Position orders the collection; Blog is filled in
+// when listing and left empty once the post is gone or taken down.
+type Bookmark struct {
+	ID           string     `json:"id" bson:"_id,omitempty"`
+	UserID       string     `json:"user_id" bson:"user_id"`
+	CollectionID string     `json:"collection_id" bson:"collection_id"`
+	BlogID       string     `json:"blog_id" bson:"blog_id"`
+	Position     int        `json:"position" bson:"position"`
+	IsRead       bool       `json:"is_read" bson:"is_read"`
+	ReadAt       *time.Time `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" bson:"created_at"`
+	Blog         *Blog      `json:"blog,omitempty" bson:"-"`
+}
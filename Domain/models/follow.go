@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Follow records that a user follows an author
+type Follow struct {
+	ID         string    `json:"id" bson:"_id,omitempty"`
+	FollowerID string    `json:"follower_id" bson:"follower_id"`
+	FolloweeID string    `json:"followee_id" bson:"followee_id"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+}
+
+// AuthorProfile is the public view of a user. IsFollowing is whether the viewer follows them.
+type AuthorProfile struct {
+	ID             string `json:"id"`
+	Username       string `json:"username"`
+	Bio            string `json:"bio,omitempty"`
+	Picture        string `json:"picture,omitempty"`
+	FollowersCount int64  `json:"followers_count"`
+	FollowingCount int64  `json:"following_count"`
+	IsFollowing    bool   `json:"is_following"`
+}
+
+// AuthorTopic is the UserInterest topic for interest in an author
+func AuthorTopic(authorID string) string {
+	return "author:" + authorID
+}
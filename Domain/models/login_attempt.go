@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// AttemptCounter counts the recent attempts made under one key, such as an account or a client IP
+type AttemptCounter struct {
+	Key    string    `json:"key" bson:"_id"`
+	Count  int       `json:"count" bson:"count"`
+	LastAt time.Time `json:"last_at" bson:"last_at"`
+}
+
+// AttemptPolicy limits how often something may be tried under one key. The first Free attempts
+// are not delayed; after that each attempt doubles the wait from BaseDelay up to MaxDelay, and
+// LockoutAt attempts lock the key for LockoutDuration. Counting starts over once Window passes
+// without an attempt, so Window should be at least LockoutDuration.
+type AttemptPolicy struct {
+	Free            int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAt       int
+	LockoutDuration time.Duration
+	Window          time.Duration
+}
+
+// RetryAt returns when the next attempt is allowed, or the zero time when it is allowed right away
+func (p AttemptPolicy) RetryAt(counter AttemptCounter, now time.Time) time.Time {
+	if counter.Count == 0 || !now.Before(counter.LastAt.Add(p.Window)) {
+		return time.Time{}
+	}
+
+	var delay time.Duration
+	switch {
+	case p.LockoutAt > 0 && counter.Count >= p.LockoutAt:
+		delay = p.LockoutDuration
+	case counter.Count < p.Free:
+		return time.Time{}
+	default:
+		delay = p.BaseDelay
+		for i := p.Free; i < counter.Count && delay < p.MaxDelay; i++ {
+			delay *= 2
+		}
+		if delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+
+	retryAt := counter.LastAt.Add(delay)
+	if !now.Before(retryAt) {
+		return time.Time{}
+	}
+	return retryAt
+}
+
+// ThrottledError is returned while a login or password reset must wait. Its message is the same
+// whether or not the account exists.
+type ThrottledError struct {
+	RetryAt time.Time
+}
+
+func (e *ThrottledError) Error() string {
+	return "too many attempts, try again later"
+}
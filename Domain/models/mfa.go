@@ -0,0 +1,14 @@
+package models
+
+// MFAEnrollment is a new TOTP secret, shown once so it can be added to an authenticator app
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// MFAStatus is a user's two-factor setup
+type MFAStatus struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// Kinds of content that are moderated
+const (
+	ModerationContentBlog    = "blog"
+	ModerationContentComment = "comment"
+)
+
+// Moderator decisions, from most to least lenient
+const (
+	// ModerationAllow lets content go live straight away
+	ModerationAllow = "allow"
+	// ModerationReview holds content back until an admin approves it
+	ModerationReview = "review"
+	// ModerationReject keeps content hidden; an admin can still approve it
+	ModerationReject = "reject"
+)
+
+// Moderation states of held content and of the queue items tracking it. Content that was never
+// held has no moderation status.
+const (
+	ModerationStatusPending  = "pending"
+	ModerationStatusApproved = "approved"
+	ModerationStatusRejected = "rejected"
+)
+
+// IsModerationHeld reports whether content in the given moderation status must stay hidden
+func IsModerationHeld(status string) bool {
+	return status == ModerationStatusPending || status == ModerationStatusRejected
+}
+
+// ModerationContent is a post or comment as submitted for moderation
+type ModerationContent struct {
+	Type     string
+	ID       string
+	BlogID   string
+	AuthorID string
+	Title    string
+	Body     string
+}
+
+// ModerationVerdict is a moderator's decision with the reasons behind it
+type ModerationVerdict struct {
+	Decision string   `json:"decision"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+var moderationSeverity = map[string]int{
+	ModerationAllow:  0,
+	ModerationReview: 1,
+	ModerationReject: 2,
+}
+
+// Merge keeps the stricter decision of the two and the reasons of both
+func (v ModerationVerdict) Merge(other ModerationVerdict) ModerationVerdict {
+	merged := ModerationVerdict{Decision: v.Decision, Reasons: append(append([]string{}, v.Reasons...), other.Reasons...)}
+	if merged.Decision == "" || moderationSeverity[other.Decision] > moderationSeverity[merged.Decision] {
+		merged.Decision = other.Decision
+	}
+	if len(merged.Reasons) == 0 {
+		merged.Reasons = nil
+	}
+	return merged
+}
+
+// Status is the moderation status content with this verdict is stored with; empty when allowed
+func (v ModerationVerdict) Status() string {
+	switch v.Decision {
+	case ModerationReview:
+		return ModerationStatusPending
+	case ModerationReject:
+		return ModerationStatusRejected
+	}
+	return ""
+}
+
+// ModerationItem is an entry of the moderation queue: one held post or comment. Publish records
+// whether the author asked for a post to go live, so approving it publishes it. TakenDown records
+// that the item took live content down, as reports do, rather than holding it back when written.
+type ModerationItem struct {
+	ID          string     `json:"id" bson:"_id,omitempty"`
+	ContentType string     `json:"content_type" bson:"content_type"`
+	ContentID   string     `json:"content_id" bson:"content_id"`
+	BlogID      string     `json:"blog_id,omitempty" bson:"blog_id,omitempty"`
+	AuthorID    string     `json:"author_id" bson:"author_id"`
+	Title       string     `json:"title,omitempty" bson:"title,omitempty"`
+	Excerpt     string     `json:"excerpt" bson:"excerpt"`
+	Reasons     []string   `json:"reasons" bson:"reasons"`
+	Status      string     `json:"status" bson:"status"`
+	Publish     bool       `json:"publish" bson:"publish"`
+	TakenDown   bool       `json:"taken_down" bson:"taken_down"`
+	ReviewerID  string     `json:"reviewer_id,omitempty" bson:"reviewer_id,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty" bson:"review_note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+}
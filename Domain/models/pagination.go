@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Cursor marks a position in a keyset-paginated list by the sort key and _id of an item.
+// Lists ordered by creation time use CreatedAt, recommendation lists use Score.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	Score     float64   `json:"s,omitempty"`
+	ID        string    `json:"id"`
+	// Backward pages towards the items that precede the cursor
+	Backward bool `json:"b,omitempty"`
+}
+
+// PageRequest asks for the page that follows (or precedes) Cursor. A nil cursor is the first page.
+type PageRequest struct {
+	Cursor    *Cursor
+	Limit     int
+	WithTotal bool
+}
+
+// PageInfo links a page to its neighbours. Total is only counted when the request asked for it.
+type PageInfo struct {
+	Next  *Cursor
+	Prev  *Cursor
+	Total *int64
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidReaction is returned for a reaction type other than like or dislike
+var ErrInvalidReaction = errors.New("invalid reaction type")
+
+// Reaction represents a single user's like or dislike on a blog post.
+// A user holds at most one reaction per blog; liking replaces a dislike and vice versa.
+type Reaction struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	UserID    string    `json:"user_id" bson:"user_id"`
+	BlogID    string    `json:"blog_id" bson:"blog_id"`
+	Type      string    `json:"type" bson:"type"` // like, dislike
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Reaction types
+const (
+	ReactionLike    = "like"
+	ReactionDislike = "dislike"
+	ReactionNone    = "none"
+)
@@ -52,6 +52,8 @@ type RecommendationResponse struct {
 	Recommendations []BlogRecommendation `json:"recommendations"`
 	GeneratedAt     time.Time            `json:"generated_at"`
 	TotalCount      int                  `json:"total_count"`
+	// Page links the response to its neighbouring pages; the controller encodes them as cursors
+	Page PageInfo `json:"-"`
 }
 
 // BlogRecommendation represents a recommended blog with metadata
@@ -98,6 +100,10 @@ const (
 	WeightComment  = 3.0
 	WeightShare    = 4.0
 	WeightBookmark = 2.0
+
+	// WeightFollow is what following an author adds to interest in them. Unlike actions it
+	// does not fade with time.
+	WeightFollow = 10.0
 )
 
 // Recommendation categories
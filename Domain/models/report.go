@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Reasons a reader can give when reporting a post or comment
+const (
+	ReportReasonSpam           = "spam"
+	ReportReasonHarassment     = "harassment"
+	ReportReasonHate           = "hate"
+	ReportReasonMisinformation = "misinformation"
+	ReportReasonOther          = "other"
+)
+
+// ReportReasons lists the accepted reason codes
+var ReportReasons = []string{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonHate,
+	ReportReasonMisinformation,
+	ReportReasonOther,
+}
+
+// IsReportReason reports whether reason is one of ReportReasons
+func IsReportReason(reason string) bool {
+	for _, r := range ReportReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Report states. Resolving a report resolves every open report of the same content.
+const (
+	ReportStatusOpen     = "open"
+	ReportStatusResolved = "resolved"
+)
+
+// Actions an admin can take when resolving reports
+const (
+	// ReportActionDismiss finds nothing wrong; content the reports took down goes back up
+	ReportActionDismiss = "dismiss"
+	// ReportActionHide hides the content
+	ReportActionHide = "hide"
+	// ReportActionWarn hides the content and warns its author
+	ReportActionWarn = "warn"
+	// ReportActionSuspend hides the content and suspends its author
+	ReportActionSuspend = "suspend"
+)
+
+// Report is one reader's complaint about a post or comment. A reader can report each piece of
+// content once.
+type Report struct {
+	ID          string     `json:"id" bson:"_id,omitempty"`
+	ContentType string     `json:"content_type" bson:"content_type"`
+	ContentID   string     `json:"content_id" bson:"content_id"`
+	BlogID      string     `json:"blog_id" bson:"blog_id"`
+	AuthorID    string     `json:"author_id" bson:"author_id"`
+	ReporterID  string     `json:"reporter_id" bson:"reporter_id"`
+	Reason      string     `json:"reason" bson:"reason"`
+	Details     string     `json:"details,omitempty" bson:"details,omitempty"`
+	Status      string     `json:"status" bson:"status"`
+	Action      string     `json:"action,omitempty" bson:"action,omitempty"`
+	ResolverID  string     `json:"resolver_id,omitempty" bson:"resolver_id,omitempty"`
+	Note        string     `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBlogModified means another edit got to the blog first; the caller should reload and retry
+var ErrBlogModified = errors.New("blog was modified concurrently, please retry")
+
+// BlogRevision is an immutable snapshot of a blog's editable fields.
+// A snapshot of the current state is archived every time the title, content or tags change,
+// so versions 1..N-1 live in the revisions collection and version N is the blog itself.
+type BlogRevision struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	BlogID    string    `json:"blog_id" bson:"blog_id"`
+	Version   int       `json:"version" bson:"version"`
+	Title     string    `json:"title" bson:"title"`
+	Content   string    `json:"content" bson:"content"`
+	Tags      []string  `json:"tags" bson:"tags"`
+	IsCurrent bool      `json:"is_current" bson:"-"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"` // when this version was written
+}
+
+// RevisionDiff is a line-level comparison between two versions of a blog
+type RevisionDiff struct {
+	BlogID      string     `json:"blog_id"`
+	FromVersion int        `json:"from_version"`
+	ToVersion   int        `json:"to_version"`
+	FromTitle   string     `json:"from_title"`
+	ToTitle     string     `json:"to_title"`
+	Lines       []DiffLine `json:"lines"`
+	Added       int        `json:"added"`
+	Removed     int        `json:"removed"`
+}
+
+// DiffLine is one line of a diff with the operation that produced it
+type DiffLine struct {
+	Op   string `json:"op"` // equal, insert, delete
+	Text string `json:"text"`
+}
+
+// Diff operations
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
@@ -0,0 +1,9 @@
+package models
+
+// BlogSearchResult is a blog matched by full-text search together with its relevance
+type BlogSearchResult struct {
+	Blog  Blog    `json:"blog"`
+	Score float64 `json:"score"`
+	// HTML-escaped excerpts of the content with matching terms wrapped in <mark>
+	Highlights []string `json:"highlights"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// BlogSlug reserves a URL slug for a blog. Records live as long as the blog, so a slug a
+// blog used before being renamed keeps resolving and can redirect to the current one.
+type BlogSlug struct {
+	Slug      string    `json:"slug" bson:"_id"`
+	BlogID    string    `json:"blog_id" bson:"blog_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Tag is a canonical tag of the vocabulary. Aliases are other spellings that blogs are
+// normalized from, e.g. "golang" and "go-lang" for "go".
+type Tag struct {
+	Name      string    `json:"name" bson:"_id"`
+	Aliases   []string  `json:"aliases" bson:"aliases"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// TagMergeResult is the canonical tag after a merge and how many blogs were rewritten to use it
+type TagMergeResult struct {
+	Tag          Tag   `json:"tag"`
+	BlogsUpdated int64 `json:"blogs_updated"`
+}
+
+// Where suggested tags came from
+const (
+	// TagSuggestionSourceAI tags were proposed by the AI provider
+	TagSuggestionSourceAI = "ai"
+	// TagSuggestionSourceKeywords tags were extracted from the most frequent words of the post
+	TagSuggestionSourceKeywords = "keywords"
+)
+
+// TagSuggestion is a set of tags proposed for a draft, already mapped onto the vocabulary
+type TagSuggestion struct {
+	Tags   []string `json:"tags"`
+	Source string   `json:"source"`
+}
@@ -13,12 +13,38 @@ type Token struct {
 	ExpiresAt time.Time
 	IP        string
 	Device    string
+	// FamilyID groups a refresh token with the tokens it was rotated from and into. It is the ID
+	// of the token issued at login.
+	FamilyID string
+	// RotatedAt is set once a refresh token has been exchanged; presenting it again means it leaked
+	RotatedAt *time.Time
+	// LoginAt is when the family's session was started; LastUsedAt is when this token was issued
+	LoginAt    time.Time
+	LastUsedAt time.Time
+}
+
+// ClientInfo describes the client a session is used from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// Session is a signed-in device: the live refresh token of one token family. ID is the family ID.
+type Session struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
 
 type UserAccessClaims struct {
 	UserID    string
 	Email     string
 	Role      string
+	Version   int
+	MFA       bool
 	ExpiresAt time.Time
 	CreatedAt time.Time
 }
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // User represents the domain model for a user
 // Following clean architecture: domain layer should be independent of infrastructure
 type User struct {
@@ -13,4 +15,30 @@ type User struct {
 	Bio     string `bson:"bio,omitempty" json:"bio,omitempty"`
 	Picture string `bson:"picture,omitempty" json:"picture,omitempty"`
 	Contact string `bson:"contact,omitempty" json:"contact,omitempty"`
+
+	// Follower counts are kept on the user so profiles need no extra lookups
+	FollowersCount int64 `bson:"followers_count,omitempty" json:"followers_count"`
+	FollowingCount int64 `bson:"following_count,omitempty" json:"following_count"`
+
+	// Warnings counts the warnings admins gave the user over reported content
+	Warnings         int        `bson:"warnings,omitempty" json:"warnings,omitempty"`
+	SuspendedUntil   *time.Time `bson:"suspended_until,omitempty" json:"suspended_until,omitempty"`
+	SuspensionReason string     `bson:"suspension_reason,omitempty" json:"suspension_reason,omitempty"`
+
+	// TokenVersion is stamped into access tokens; bumping it revokes every access token issued before
+	TokenVersion int `bson:"token_version,omitempty" json:"-"`
+
+	// MFASecret is the TOTP secret, set at enrolment and in use once MFAEnabled. Recovery codes
+	// are kept hashed with MFARecoverySalt, which changes with every new set of codes;
+	// MFALastStep is the time step of the last accepted code, so none is used twice.
+	MFAEnabled       bool     `bson:"mfa_enabled,omitempty" json:"mfa_enabled"`
+	MFASecret        string   `bson:"mfa_secret,omitempty" json:"-"`
+	MFARecoveryCodes []string `bson:"mfa_recovery_codes,omitempty" json:"-"`
+	MFARecoverySalt  string   `bson:"mfa_recovery_salt,omitempty" json:"-"`
+	MFALastStep      int64    `bson:"mfa_last_step,omitempty" json:"-"`
+}
+
+// IsSuspended reports whether the user is suspended at the given time
+func (u User) IsSuspended(now time.Time) bool {
+	return u.SuspendedUntil != nil && now.Before(*u.SuspendedUntil)
 }
@@ -6,38 +6,50 @@ import (
 )
 
 type Token struct {
-	ID        string    `bson:"_id"`
-	UserID    string    `bson:"user_id"`
-	TokenHash string    `bson:"token_hash"`
-	CreatedAt time.Time `bson:"created_at"`
-	ExpiresAt time.Time `bson:"expires_at"`
-	IP        string    `bson:"ip"`
-	Device    string    `bson:"device"`
-	Email     string    `bson:"email"`
+	ID         string     `bson:"_id"`
+	UserID     string     `bson:"user_id"`
+	TokenHash  string     `bson:"token_hash"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	ExpiresAt  time.Time  `bson:"expires_at"`
+	IP         string     `bson:"ip"`
+	Device     string     `bson:"device"`
+	Email      string     `bson:"email"`
+	FamilyID   string     `bson:"family_id,omitempty"`
+	RotatedAt  *time.Time `bson:"rotated_at,omitempty"`
+	LoginAt    time.Time  `bson:"login_at,omitempty"`
+	LastUsedAt time.Time  `bson:"last_used_at,omitempty"`
 }
 
 func FromDomainToken(token *models.Token) *Token {
 	return &Token{
-		ID:        token.ID,
-		UserID:    token.UserID,
-		TokenHash: token.Token,
-		CreatedAt: token.CreatedAt,
-		ExpiresAt: token.ExpiresAt,
-		IP:        token.IP,
-		Device:    token.Device,
-		Email:     token.Email,
+		ID:         token.ID,
+		UserID:     token.UserID,
+		TokenHash:  token.Token,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		IP:         token.IP,
+		Device:     token.Device,
+		Email:      token.Email,
+		FamilyID:   token.FamilyID,
+		RotatedAt:  token.RotatedAt,
+		LoginAt:    token.LoginAt,
+		LastUsedAt: token.LastUsedAt,
 	}
 }
 
 func ToDomainToken(token *Token) *models.Token {
 	return &models.Token{
-		ID:        token.ID,
-		UserID:    token.UserID,
-		Token:     token.TokenHash,
-		CreatedAt: token.CreatedAt,
-		ExpiresAt: token.ExpiresAt,
-		IP:        token.IP,
-		Device:    token.Device,
-		Email:     token.Email,
+		ID:         token.ID,
+		UserID:     token.UserID,
+		Token:      token.TokenHash,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		IP:         token.IP,
+		Device:     token.Device,
+		Email:      token.Email,
+		FamilyID:   token.FamilyID,
+		RotatedAt:  token.RotatedAt,
+		LoginAt:    token.LoginAt,
+		LastUsedAt: token.LastUsedAt,
 	}
 }
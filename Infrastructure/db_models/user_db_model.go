@@ -3,6 +3,7 @@ package db_models
 
 import (
 	"blog-api/Domain/models"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -16,6 +17,18 @@ type UserModel struct {
 	PasswordHash string             `bson:"password_hash"`
 	Role         string             `bson:"role"`
 	Verified     bool               `bson:"verified"`
+
+	FollowersCount   int64      `bson:"followers_count,omitempty"`
+	FollowingCount   int64      `bson:"following_count,omitempty"`
+	Warnings         int        `bson:"warnings,omitempty"`
+	SuspendedUntil   *time.Time `bson:"suspended_until,omitempty"`
+	SuspensionReason string     `bson:"suspension_reason,omitempty"`
+	TokenVersion     int        `bson:"token_version,omitempty"`
+	MFAEnabled       bool       `bson:"mfa_enabled,omitempty"`
+	MFASecret        string     `bson:"mfa_secret,omitempty"`
+	MFARecoveryCodes []string   `bson:"mfa_recovery_codes,omitempty"`
+	MFARecoverySalt  string     `bson:"mfa_recovery_salt,omitempty"`
+	MFALastStep      int64      `bson:"mfa_last_step,omitempty"`
 }
 
 // FromDomainUser converts a domain User to a MongoDB UserModel
@@ -34,6 +47,18 @@ func FromDomainUser(u *models.User) *UserModel {
 		PasswordHash: u.Password,
 		Role:         u.Role,
 		Verified:     u.Verified,
+
+		FollowersCount:   u.FollowersCount,
+		FollowingCount:   u.FollowingCount,
+		Warnings:         u.Warnings,
+		SuspendedUntil:   u.SuspendedUntil,
+		SuspensionReason: u.SuspensionReason,
+		TokenVersion:     u.TokenVersion,
+		MFAEnabled:       u.MFAEnabled,
+		MFASecret:        u.MFASecret,
+		MFARecoveryCodes: u.MFARecoveryCodes,
+		MFARecoverySalt:  u.MFARecoverySalt,
+		MFALastStep:      u.MFALastStep,
 	}
 }
 
@@ -46,5 +71,17 @@ func ToDomainUser(m *UserModel) *models.User {
 		Password: m.PasswordHash,
 		Role:     m.Role,
 		Verified: m.Verified,
+
+		FollowersCount:   m.FollowersCount,
+		FollowingCount:   m.FollowingCount,
+		Warnings:         m.Warnings,
+		SuspendedUntil:   m.SuspendedUntil,
+		SuspensionReason: m.SuspensionReason,
+		TokenVersion:     m.TokenVersion,
+		MFAEnabled:       m.MFAEnabled,
+		MFASecret:        m.MFASecret,
+		MFARecoveryCodes: m.MFARecoveryCodes,
+		MFARecoverySalt:  m.MFARecoverySalt,
+		MFALastStep:      m.MFALastStep,
 	}
 }
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type aiQuotaCounterMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewAIQuotaCounterMongoRepo(col *mongo.Collection) *aiQuotaCounterMongoRepo {
+	return &aiQuotaCounterMongoRepo{collection: col}
+}
+
+// EnsureIndexes creates a TTL index that lets Mongo remove counters once their window has passed
+func (qr *aiQuotaCounterMongoRepo) EnsureIndexes() error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("ai_quota_counter_expiry").SetExpireAfterSeconds(0),
+	}
+
+	_, err := qr.collection.Indexes().CreateOne(context.TODO(), index)
+	return err
+}
+
+// ReserveAIRequest increments the counter only while it matches the limits, so concurrent
+// requests cannot all pass on the same reading. A counter at its limit does not match, and the
+// upsert then fails on the duplicate _id.
+func (qr *aiQuotaCounterMongoRepo) ReserveAIRequest(key string, requestLimit, tokenLimit int64, expiresAt time.Time) (bool, error) {
+	filter := bson.M{"_id": key}
+	if requestLimit > 0 {
+		filter["requests"] = bson.M{"$lt": requestLimit}
+	}
+	if tokenLimit > 0 {
+		filter["tokens"] = bson.M{"$lt": tokenLimit}
+	}
+	update := bson.M{
+		"$inc": bson.M{"requests": 1, "tokens": 0},
+		"$set": bson.M{"expires_at": expiresAt},
+	}
+
+	_, err := qr.collection.UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (qr *aiQuotaCounterMongoRepo) AddAIUsage(key string, requests, tokens int64) error {
+	update := bson.M{"$inc": bson.M{"requests": requests, "tokens": tokens}}
+	_, err := qr.collection.UpdateOne(context.TODO(), bson.M{"_id": key}, update)
+	return err
+}
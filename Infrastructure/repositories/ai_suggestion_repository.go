@@ -118,6 +118,28 @@ func (ar *aiSuggestionMongoRepo) UpdateAISuggestion(suggestion models.AISuggesti
 	return suggestion, nil
 }
 
+// MarkAISuggestionConverted only matches a suggestion that is not converted yet, so of two
+// conversions running at once only one claims it
+func (ar *aiSuggestionMongoRepo) MarkAISuggestionConverted(suggestionID string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(suggestionID)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": objectID, "status": bson.M{"$ne": models.AISuggestionStatusConvertedToDraft}}
+	update := bson.M{"$set": bson.M{
+		"status":     models.AISuggestionStatusConvertedToDraft,
+		"updated_at": time.Now(),
+	}}
+
+	result, err := ar.collection.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
 // DeleteAISuggestion deletes an AI suggestion
 func (ar *aiSuggestionMongoRepo) DeleteAISuggestion(suggestionID string) error {
 	objectID, err := primitive.ObjectIDFromHex(suggestionID)
@@ -158,6 +180,42 @@ func (ar *aiSuggestionMongoRepo) GetAISuggestionsByStatus(userID string, status
 	return suggestions, nil
 }
 
+// GetAISuggestionsByCursor retrieves the page of a user's AI suggestions next to the cursor, newest first.
+// An empty status lists every suggestion.
+func (ar *aiSuggestionMongoRepo) GetAISuggestionsByCursor(userID string, status string, page models.PageRequest) ([]models.AISuggestion, bool, error) {
+	filter, opts, err := keysetFilter(aiSuggestionFilter(userID, status), "created_at", -1, page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cursor, err := ar.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var suggestions []models.AISuggestion
+	if err = cursor.All(context.TODO(), &suggestions); err != nil {
+		return nil, false, err
+	}
+
+	suggestions, hasMore := keysetPage(suggestions, page)
+	return suggestions, hasMore, nil
+}
+
+// CountAISuggestions counts a user's AI suggestions, optionally restricted to one status
+func (ar *aiSuggestionMongoRepo) CountAISuggestions(userID string, status string) (int64, error) {
+	return ar.collection.CountDocuments(context.TODO(), aiSuggestionFilter(userID, status))
+}
+
+func aiSuggestionFilter(userID string, status string) bson.M {
+	filter := bson.M{"user_id": userID}
+	if status != "" {
+		filter["status"] = status
+	}
+	return filter
+}
+
 // ConvertSuggestionToDraft converts an AI suggestion to a blog draft
 func (ar *aiSuggestionMongoRepo) ConvertSuggestionToDraft(suggestionID string, userID string) (models.Blog, error) {
 	// This method will be implemented in the use case layer
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type aiUsageMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewAIUsageMongoRepo(col *mongo.Collection) *aiUsageMongoRepo {
+	return &aiUsageMongoRepo{collection: col}
+}
+
+// EnsureIndexes creates the index quota checks rely on. Creating an identical index again is a
+// no-op, so this is safe to call on every start.
+func (ur *aiUsageMongoRepo) EnsureIndexes() error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("ai_usage_user_created"),
+	}
+
+	_, err := ur.collection.Indexes().CreateOne(context.TODO(), index)
+	return err
+}
+
+// RecordAIUsage appends a request to the ledger
+func (ur *aiUsageMongoRepo) RecordAIUsage(record models.AIUsageRecord) (models.AIUsageRecord, error) {
+	objectID := primitive.NewObjectID()
+	record.ID = objectID.Hex()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	recordModel := bson.M{
+		"_id":               objectID,
+		"user_id":           record.UserID,
+		"role":              record.Role,
+		"endpoint":          record.Endpoint,
+		"calls":             record.Calls,
+		"prompt_tokens":     record.PromptTokens,
+		"completion_tokens": record.CompletionTokens,
+		"total_tokens":      record.TotalTokens,
+		"latency_ms":        record.LatencyMS,
+		"success":           record.Success,
+		"created_at":        record.CreatedAt,
+	}
+
+	if _, err := ur.collection.InsertOne(context.TODO(), recordModel); err != nil {
+		return models.AIUsageRecord{}, err
+	}
+
+	return record, nil
+}
+
+// SumAIUsage totals the user's requests since the given time. Failed upstream calls are counted
+// too, since they are billed for what they used.
+func (ur *aiUsageMongoRepo) SumAIUsage(userID string, since time.Time) (models.AIUsageTotals, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"user_id":    userID,
+			"created_at": bson.M{"$gte": since},
+		}},
+		{"$group": bson.M{
+			"_id":      nil,
+			"requests": bson.M{"$sum": 1},
+			"tokens":   bson.M{"$sum": "$total_tokens"},
+		}},
+	}
+
+	cursor, err := ur.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return models.AIUsageTotals{}, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var totals models.AIUsageTotals
+	if cursor.Next(context.TODO()) {
+		if err := cursor.Decode(&totals); err != nil {
+			return models.AIUsageTotals{}, err
+		}
+	}
+
+	return totals, cursor.Err()
+}
+
+// GetAIUsageSummaries groups the requests made in [from, to) per user, most tokens first
+func (ur *aiUsageMongoRepo) GetAIUsageSummaries(from, to time.Time) ([]models.AIUsageSummary, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}},
+		{"$group": bson.M{
+			"_id":               "$user_id",
+			"requests":          bson.M{"$sum": 1},
+			"failed_requests":   bson.M{"$sum": bson.M{"$cond": bson.A{"$success", 0, 1}}},
+			"prompt_tokens":     bson.M{"$sum": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$completion_tokens"},
+			"total_tokens":      bson.M{"$sum": "$total_tokens"},
+			"avg_latency_ms":    bson.M{"$avg": "$latency_ms"},
+			"max_latency_ms":    bson.M{"$max": "$latency_ms"},
+			"last_used_at":      bson.M{"$max": "$created_at"},
+		}},
+		{"$sort": bson.D{{Key: "total_tokens", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+
+	cursor, err := ur.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	summaries := []models.AIUsageSummary{}
+	if err = cursor.All(context.TODO(), &summaries); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"blog-api/Domain/models"
 	"context"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -11,6 +13,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// maxFacetValues caps how many tag and author buckets a faceted query returns
+const maxFacetValues = 20
+
 type blogMongoRepo struct {
 	collection *mongo.Collection
 }
@@ -24,24 +29,34 @@ func (br *blogMongoRepo) CreateBlog(blog models.Blog) (models.Blog, error) {
 	// Generate a new ObjectID for MongoDB
 	objectID := primitive.NewObjectID()
 	blog.ID = objectID.Hex()
+	blog.Version = 1
 	blog.CreatedAt = time.Now()
 	blog.UpdatedAt = time.Now()
 
 	// Convert to MongoDB model for insertion
 	blogModel := bson.M{
-		"_id":          objectID,
-		"title":        blog.Title,
-		"content":      blog.Content,
-		"author_id":    blog.AuthorID,
-		"author_name":  blog.AuthorName,
-		"tags":         blog.Tags,
-		"view_count":   blog.ViewCount,
-		"likes":        blog.Likes,
-		"dislikes":     blog.Dislikes,
-		"comments":     blog.Comments,
-		"is_published": blog.IsPublished,
-		"created_at":   blog.CreatedAt,
-		"updated_at":   blog.UpdatedAt,
+		"_id":           objectID,
+		"title":         blog.Title,
+		"slug":          blog.Slug,
+		"content":       blog.Content,
+		"author_id":     blog.AuthorID,
+		"author_name":   blog.AuthorName,
+		"tags":          blog.Tags,
+		"view_count":    blog.ViewCount,
+		"likes":         blog.Likes,
+		"dislikes":      blog.Dislikes,
+		"comment_count": blog.CommentCount,
+		"is_published":  blog.IsPublished,
+		"publish_at":    blog.PublishAt,
+		"version":       blog.Version,
+		"created_at":    blog.CreatedAt,
+		"updated_at":    blog.UpdatedAt,
+	}
+	if blog.SourceSuggestionID != "" {
+		blogModel["source_suggestion_id"] = blog.SourceSuggestionID
+	}
+	if blog.ModerationStatus != "" {
+		blogModel["moderation_status"] = blog.ModerationStatus
 	}
 
 	_, err := br.collection.InsertOne(context.TODO(), blogModel)
@@ -75,6 +90,65 @@ func (br *blogMongoRepo) GetPaginatedBlogs(page, limit int) ([]models.Blog, erro
 	return blogs, nil
 }
 
+// GetBlogsByCursor retrieves the page of published blogs next to the cursor, newest first
+func (br *blogMongoRepo) GetBlogsByCursor(page models.PageRequest) ([]models.Blog, bool, error) {
+	filter, opts, err := keysetFilter(bson.M{"is_published": true}, "created_at", -1, page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cursor, err := br.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var blogs []models.Blog
+	if err = cursor.All(context.TODO(), &blogs); err != nil {
+		return nil, false, err
+	}
+
+	blogs, hasMore := keysetPage(blogs, page)
+	return blogs, hasMore, nil
+}
+
+// CountPublishedBlogs counts every published blog
+func (br *blogMongoRepo) CountPublishedBlogs() (int64, error) {
+	return br.collection.CountDocuments(context.TODO(), bson.M{"is_published": true})
+}
+
+// GetBlogsByAuthorsCursor retrieves the page of the given authors' published blogs next to the
+// cursor, newest first
+func (br *blogMongoRepo) GetBlogsByAuthorsCursor(authorIDs []string, page models.PageRequest) ([]models.Blog, bool, error) {
+	filter, opts, err := keysetFilter(publishedByAuthors(authorIDs), "created_at", -1, page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cursor, err := br.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var blogs []models.Blog
+	if err = cursor.All(context.TODO(), &blogs); err != nil {
+		return nil, false, err
+	}
+
+	blogs, hasMore := keysetPage(blogs, page)
+	return blogs, hasMore, nil
+}
+
+// CountPublishedBlogsByAuthors counts the published blogs of the given authors
+func (br *blogMongoRepo) CountPublishedBlogsByAuthors(authorIDs []string) (int64, error) {
+	return br.collection.CountDocuments(context.TODO(), publishedByAuthors(authorIDs))
+}
+
+func publishedByAuthors(authorIDs []string) bson.M {
+	return bson.M{"is_published": true, "author_id": bson.M{"$in": authorIDs}}
+}
+
 // GetBlogByID retrieves a blog by its ID
 func (br *blogMongoRepo) GetBlogByID(blogID string) (models.Blog, error) {
 	objectID, err := primitive.ObjectIDFromHex(blogID)
@@ -92,7 +166,9 @@ func (br *blogMongoRepo) GetBlogByID(blogID string) (models.Blog, error) {
 }
 
 // UpdateBlog updates an existing blog post
-func (br *blogMongoRepo) UpdateBlog(blog models.Blog) (models.Blog, error) {
+// UpdateBlog compares and swaps on the version, so of two edits made from the same version only
+// the first is written
+func (br *blogMongoRepo) UpdateBlog(blog models.Blog, expectedVersion int) (models.Blog, error) {
 	blog.UpdatedAt = time.Now()
 
 	objectID, err := primitive.ObjectIDFromHex(blog.ID)
@@ -100,29 +176,125 @@ func (br *blogMongoRepo) UpdateBlog(blog models.Blog) (models.Blog, error) {
 		return models.Blog{}, err
 	}
 
-	filter := bson.M{"_id": objectID}
-	update := bson.M{"$set": bson.M{
+	// Counters (views, reactions, comments) are maintained with $inc elsewhere and are not overwritten here
+	filter := bson.M{"_id": objectID, "version": expectedVersion}
+	if expectedVersion <= 1 {
+		// Blogs written before versioning have no version field and count as version 1
+		filter["version"] = bson.M{"$in": bson.A{nil, 0, 1}}
+	}
+	fields := bson.M{
 		"title":        blog.Title,
+		"slug":         blog.Slug,
 		"content":      blog.Content,
 		"author_id":    blog.AuthorID,
 		"author_name":  blog.AuthorName,
 		"tags":         blog.Tags,
-		"view_count":   blog.ViewCount,
-		"likes":        blog.Likes,
-		"dislikes":     blog.Dislikes,
-		"comments":     blog.Comments,
 		"is_published": blog.IsPublished,
+		"publish_at":   blog.PublishAt,
+		"version":      blog.Version,
 		"updated_at":   blog.UpdatedAt,
-	}}
+	}
+	if blog.ModerationStatus != "" {
+		fields["moderation_status"] = blog.ModerationStatus
+	}
+	update := bson.M{"$set": fields}
 
-	_, err = br.collection.UpdateOne(context.TODO(), filter, update)
+	result, err := br.collection.UpdateOne(context.TODO(), filter, update)
 	if err != nil {
 		return models.Blog{}, err
 	}
+	if result.MatchedCount == 0 {
+		return models.Blog{}, models.ErrBlogModified
+	}
 
 	return blog, nil
 }
 
+// UpdateSlug sets the current slug of a blog
+func (br *blogMongoRepo) UpdateSlug(blogID, slug string) error {
+	objectID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+
+	_, err = br.collection.UpdateOne(context.TODO(), bson.M{"_id": objectID}, bson.M{"$set": bson.M{"slug": slug}})
+	return err
+}
+
+func (br *blogMongoRepo) GetBlogsWithoutSlug() ([]models.Blog, error) {
+	filter := bson.M{"$or": bson.A{bson.M{"slug": bson.M{"$exists": false}}, bson.M{"slug": ""}}}
+	opts := options.Find().SetProjection(bson.M{"title": 1})
+
+	cursor, err := br.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	blogs := []models.Blog{}
+	if err = cursor.All(context.TODO(), &blogs); err != nil {
+		return nil, err
+	}
+
+	return blogs, nil
+}
+
+// SetModerationStatus records a review outcome, publishing the blog when asked to. A held blog is
+// unpublished, which takes down a live post that is flagged after the fact.
+func (br *blogMongoRepo) SetModerationStatus(blogID, status string, publish bool) error {
+	objectID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+
+	fields := bson.M{"moderation_status": status, "updated_at": time.Now()}
+	if models.IsModerationHeld(status) {
+		fields["is_published"] = false
+	} else if publish {
+		fields["is_published"] = true
+	}
+
+	_, err = br.collection.UpdateOne(context.TODO(), bson.M{"_id": objectID}, bson.M{"$set": fields})
+	return err
+}
+
+// ReplaceTags rewrites every spelling of the source tags and of target to target, matching
+// case-insensitively and ignoring a leading "#", so older posts with free-form tags are caught
+// too. Tag order is kept and duplicates dropped; only blogs that actually change are counted.
+func (br *blogMongoRepo) ReplaceTags(sources []string, target string) (int64, error) {
+	names := append([]string{target}, sources...)
+	spellings := make([]string, len(names))
+	for i, name := range names {
+		spellings[i] = strings.Join(strings.Fields(regexp.QuoteMeta(name)), `\s+`)
+	}
+	pattern := `^[\s#]*(?:` + strings.Join(spellings, "|") + `)\s*$`
+
+	filter := bson.M{"tags": bson.M{"$regex": pattern, "$options": "i"}}
+	update := []bson.M{{"$set": bson.M{"tags": bson.M{"$reduce": bson.M{
+		"input": bson.M{"$map": bson.M{
+			"input": "$tags",
+			"in": bson.M{"$cond": bson.A{
+				bson.M{"$regexMatch": bson.M{"input": "$$this", "regex": pattern, "options": "i"}},
+				target,
+				"$$this",
+			}},
+		}},
+		"initialValue": bson.A{},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{"$$this", "$$value"}},
+			"$$value",
+			bson.M{"$concatArrays": bson.A{"$$value", bson.A{"$$this"}}},
+		}},
+	}}}}}
+
+	result, err := br.collection.UpdateMany(context.TODO(), filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
 // DeleteBlog deletes a blog post
 func (br *blogMongoRepo) DeleteBlog(blogID string) error {
 	objectID, err := primitive.ObjectIDFromHex(blogID)
@@ -134,31 +306,64 @@ func (br *blogMongoRepo) DeleteBlog(blogID string) error {
 	return err
 }
 
-// SearchBlogs searches for blogs by title or content
-func (br *blogMongoRepo) SearchBlogs(query string) ([]models.Blog, error) {
-	filter := bson.M{
-		"$and": []bson.M{
-			{"is_published": true},
-			{"$or": []bson.M{
-				{"title": bson.M{"$regex": query, "$options": "i"}},
-				{"content": bson.M{"$regex": query, "$options": "i"}},
-			}},
+// EnsureSearchIndex creates the weighted text index that SearchBlogs relies on.
+// Creating an identical index again is a no-op, so this is safe to call on every start.
+func (br *blogMongoRepo) EnsureSearchIndex() error {
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "tags", Value: "text"},
+			{Key: "content", Value: "text"},
 		},
+		Options: options.Index().
+			SetName("blog_text_search").
+			SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "tags", Value: 5},
+				{Key: "content", Value: 1},
+			}),
 	}
 
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
-	cursor, err := br.collection.Find(context.TODO(), filter, opts)
+	_, err := br.collection.Indexes().CreateOne(context.TODO(), index)
+	return err
+}
+
+// SearchBlogs searches published blogs through the text index, ordered by relevance
+func (br *blogMongoRepo) SearchBlogs(query string, page, limit int) ([]models.BlogSearchResult, error) {
+	skip := (page - 1) * limit
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip))
+
+	cursor, err := br.collection.Find(context.TODO(), searchFilter(query), opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(context.TODO())
 
-	var blogs []models.Blog
-	if err = cursor.All(context.TODO(), &blogs); err != nil {
+	var docs []struct {
+		models.Blog `bson:",inline"`
+		Score       float64 `bson:"score"`
+	}
+	if err = cursor.All(context.TODO(), &docs); err != nil {
 		return nil, err
 	}
 
-	return blogs, nil
+	results := make([]models.BlogSearchResult, 0, len(docs))
+	for _, doc := range docs {
+		results = append(results, models.BlogSearchResult{Blog: doc.Blog, Score: doc.Score})
+	}
+
+	return results, nil
+}
+
+// CountSearchResults counts all published blogs matching the search
+func (br *blogMongoRepo) CountSearchResults(query string) (int64, error) {
+	return br.collection.CountDocuments(context.TODO(), searchFilter(query))
 }
 
 // FilterBlogs filters blogs by tags, date range, and sort order
@@ -224,6 +429,199 @@ func (br *blogMongoRepo) FilterBlogs(tags []string, dateRange [2]string, sortBy
 	return blogs, nil
 }
 
+// GetBlogsByAuthor retrieves an author's posts of any visibility, newest first
+func (br *blogMongoRepo) GetBlogsByAuthor(authorID, status string, page, limit int) ([]models.Blog, error) {
+	skip := (page - 1) * limit
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := br.collection.Find(context.TODO(), authorStatusFilter(authorID, status), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var blogs []models.Blog
+	if err = cursor.All(context.TODO(), &blogs); err != nil {
+		return nil, err
+	}
+
+	return blogs, nil
+}
+
+// CountBlogsByAuthor counts an author's posts matching the status filter
+func (br *blogMongoRepo) CountBlogsByAuthor(authorID, status string) (int64, error) {
+	return br.collection.CountDocuments(context.TODO(), authorStatusFilter(authorID, status))
+}
+
+// GetScheduledBlogs retrieves an author's drafts that have a publish time, soonest first
+func (br *blogMongoRepo) GetScheduledBlogs(authorID string) ([]models.Blog, error) {
+	filter := bson.M{
+		"author_id":    authorID,
+		"is_published": false,
+		"publish_at":   bson.M{"$ne": nil},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "publish_at", Value: 1}})
+	cursor, err := br.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var blogs []models.Blog
+	if err = cursor.All(context.TODO(), &blogs); err != nil {
+		return nil, err
+	}
+
+	return blogs, nil
+}
+
+// PublishDueBlog flips a single due draft to published.
+// The match and update happen in one FindOneAndUpdate, so when several API instances
+// run the scheduler only one of them can claim a given draft.
+func (br *blogMongoRepo) PublishDueBlog(now time.Time) (*models.Blog, error) {
+	// Drafts held by moderation wait for an admin's approval
+	filter := bson.M{
+		"is_published":      false,
+		"publish_at":        bson.M{"$lte": now},
+		"moderation_status": bson.M{"$nin": bson.A{models.ModerationStatusPending, models.ModerationStatusRejected}},
+	}
+	update := bson.M{
+		"$set":   bson.M{"is_published": true, "updated_at": now},
+		"$unset": bson.M{"publish_at": ""},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "publish_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var blog models.Blog
+	err := br.collection.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&blog)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &blog, nil
+}
+
+// QueryBlogs runs the filters, the page and the facet counts in a single aggregation
+func (br *blogMongoRepo) QueryBlogs(query models.BlogQuery) (models.BlogQueryResult, error) {
+	match := bson.M{"is_published": true}
+	if query.Text != "" {
+		match["$text"] = bson.M{"$search": query.Text}
+	}
+	if len(query.Tags) > 0 {
+		if query.TagMatch == models.TagMatchAll {
+			match["tags"] = bson.M{"$all": query.Tags}
+		} else {
+			match["tags"] = bson.M{"$in": query.Tags}
+		}
+	}
+	if query.AuthorID != "" {
+		match["author_id"] = query.AuthorID
+	}
+	if query.From != nil || query.To != nil {
+		createdAt := bson.M{}
+		if query.From != nil {
+			createdAt["$gte"] = *query.From
+		}
+		if query.To != nil {
+			createdAt["$lt"] = *query.To
+		}
+		match["created_at"] = createdAt
+	}
+	if query.MinLikes > 0 {
+		match["likes"] = bson.M{"$gte": query.MinLikes}
+	}
+	if query.MinViews > 0 {
+		match["view_count"] = bson.M{"$gte": query.MinViews}
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+
+	sort := bson.D{{Key: query.SortBy, Value: query.SortOrder}, {Key: "_id", Value: query.SortOrder}}
+	if query.SortBy == models.SortByRelevance {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+		sort = bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: -1}}
+	}
+
+	byCount := bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"results": bson.A{
+			bson.M{"$sort": sort},
+			bson.M{"$skip": (query.Page - 1) * query.Limit},
+			bson.M{"$limit": query.Limit},
+		},
+		"total": bson.A{
+			bson.M{"$count": "count"},
+		},
+		"tags": bson.A{
+			bson.M{"$unwind": "$tags"},
+			bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": byCount},
+			bson.M{"$limit": maxFacetValues},
+		},
+		"authors": bson.A{
+			bson.M{"$group": bson.M{
+				"_id":   "$author_id",
+				"label": bson.M{"$first": "$author_name"},
+				"count": bson.M{"$sum": 1},
+			}},
+			bson.M{"$sort": byCount},
+			bson.M{"$limit": maxFacetValues},
+		},
+		"months": bson.A{
+			bson.M{"$group": bson.M{
+				"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$created_at"}},
+				"count": bson.M{"$sum": 1},
+			}},
+			bson.M{"$sort": bson.M{"_id": -1}},
+		},
+	}}})
+
+	cursor, err := br.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return models.BlogQueryResult{}, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var output []struct {
+		Results []models.Blog `bson:"results"`
+		Total   []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Tags    []models.FacetCount `bson:"tags"`
+		Authors []models.FacetCount `bson:"authors"`
+		Months  []models.FacetCount `bson:"months"`
+	}
+	if err = cursor.All(context.TODO(), &output); err != nil {
+		return models.BlogQueryResult{}, err
+	}
+
+	result := models.BlogQueryResult{Blogs: []models.Blog{}}
+	if len(output) == 0 {
+		return result, nil
+	}
+
+	result.Blogs = output[0].Results
+	if len(output[0].Total) > 0 {
+		result.Total = output[0].Total[0].Count
+	}
+	result.Facets = models.BlogFacets{
+		Tags:    output[0].Tags,
+		Authors: output[0].Authors,
+		Months:  output[0].Months,
+	}
+
+	return result, nil
+}
+
 // IncrementViewCount increments the view count of a blog
 func (br *blogMongoRepo) IncrementViewCount(blogID string) error {
 	objectID, err := primitive.ObjectIDFromHex(blogID)
@@ -276,43 +674,26 @@ func (br *blogMongoRepo) UpdateDislikes(blogID string, increment bool) error {
 	return err
 }
 
-// AddComment adds a comment to a blog post
-func (br *blogMongoRepo) AddComment(blogID string, comment models.Comment) (models.Comment, error) {
-	objectID, err := primitive.ObjectIDFromHex(blogID)
-	if err != nil {
-		return models.Comment{}, err
-	}
-
-	// Generate a new ObjectID for the comment
-	commentObjectID := primitive.NewObjectID()
-	comment.ID = commentObjectID.Hex()
-	comment.BlogID = blogID
-	comment.CreatedAt = time.Now()
-	comment.UpdatedAt = time.Now()
-
-	filter := bson.M{"_id": objectID}
-	update := bson.M{"$push": bson.M{"comments": comment}}
-
-	_, err = br.collection.UpdateOne(context.TODO(), filter, update)
-	if err != nil {
-		return models.Comment{}, err
+func authorStatusFilter(authorID, status string) bson.M {
+	filter := bson.M{"author_id": authorID}
+
+	switch status {
+	case models.BlogStatusPublished:
+		filter["is_published"] = true
+	case models.BlogStatusDraft:
+		filter["is_published"] = false
+		filter["publish_at"] = nil
+	case models.BlogStatusScheduled:
+		filter["is_published"] = false
+		filter["publish_at"] = bson.M{"$ne": nil}
 	}
 
-	return comment, nil
+	return filter
 }
 
-// GetComments retrieves all comments for a blog post
-func (br *blogMongoRepo) GetComments(blogID string) ([]models.Comment, error) {
-	objectID, err := primitive.ObjectIDFromHex(blogID)
-	if err != nil {
-		return nil, err
+func searchFilter(query string) bson.M {
+	return bson.M{
+		"is_published": true,
+		"$text":        bson.M{"$search": query},
 	}
-
-	var blog models.Blog
-	err = br.collection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&blog)
-	if err != nil {
-		return nil, err
-	}
-
-	return blog.Comments, nil
 }
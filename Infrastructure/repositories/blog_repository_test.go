@@ -37,6 +37,9 @@ func (suite *BlogRepositoryTestSuite) SetupSuite() {
 
 	// Initialize repository
 	suite.repo = NewBlogMongoRepo(suite.collection)
+	if err := suite.repo.EnsureSearchIndex(); err != nil {
+		suite.T().Fatalf("Failed to create search index: %v", err)
+	}
 }
 
 func (suite *BlogRepositoryTestSuite) TearDownSuite() {
@@ -69,7 +72,6 @@ func (suite *BlogRepositoryTestSuite) TestCreateBlog() {
 		AuthorID:    "user123",
 		AuthorName:  "test@example.com",
 		Tags:        []string{"test", "go"},
-		Comments:    []models.Comment{}, // Initialize empty slice
 		IsPublished: true,
 	}
 
@@ -104,7 +106,6 @@ func (suite *BlogRepositoryTestSuite) TestGetBlogByID() {
 		AuthorID:    "user123",
 		AuthorName:  "test@example.com",
 		Tags:        []string{"test", "go"},
-		Comments:    []models.Comment{}, // Initialize empty slice
 		IsPublished: true,
 	}
 	createdBlog, _ := suite.repo.CreateBlog(blog)
@@ -144,7 +145,6 @@ func (suite *BlogRepositoryTestSuite) TestUpdateBlog() {
 		AuthorID:    "user123",
 		AuthorName:  "test@example.com",
 		Tags:        []string{"original"},
-		Comments:    []models.Comment{}, // Initialize empty slice
 		IsPublished: true,
 	}
 	createdBlog, _ := suite.repo.CreateBlog(blog)
@@ -153,8 +153,9 @@ func (suite *BlogRepositoryTestSuite) TestUpdateBlog() {
 	createdBlog.Title = "Updated Title"
 	createdBlog.Content = "Updated Content"
 	createdBlog.Tags = []string{"updated", "go"}
+	createdBlog.Version = 2
 
-	updatedBlog, err := suite.repo.UpdateBlog(createdBlog)
+	updatedBlog, err := suite.repo.UpdateBlog(createdBlog, 1)
 
 	// Assertions
 	suite.NoError(err)
@@ -170,6 +171,27 @@ func (suite *BlogRepositoryTestSuite) TestUpdateBlog() {
 	suite.Equal("Updated Content", retrievedBlog.Content)
 }
 
+func (suite *BlogRepositoryTestSuite) TestUpdateBlog_StaleVersion() {
+	// Create test blog
+	createdBlog, _ := suite.repo.CreateBlog(models.Blog{Title: "Original Title", Content: "Original Content", AuthorID: "user123"})
+
+	// First edit moves the blog to version 2
+	createdBlog.Title = "First Edit"
+	createdBlog.Version = 2
+	_, err := suite.repo.UpdateBlog(createdBlog, 1)
+	suite.NoError(err)
+
+	// A second edit made from version 1 is rejected
+	createdBlog.Title = "Second Edit"
+	_, err = suite.repo.UpdateBlog(createdBlog, 1)
+
+	// Assertions
+	suite.ErrorIs(err, models.ErrBlogModified)
+	retrievedBlog, err := suite.repo.GetBlogByID(createdBlog.ID)
+	suite.NoError(err)
+	suite.Equal("First Edit", retrievedBlog.Title)
+}
+
 func (suite *BlogRepositoryTestSuite) TestDeleteBlog() {
 	// Create test blog
 	blog := models.Blog{
@@ -216,6 +238,55 @@ func (suite *BlogRepositoryTestSuite) TestGetPaginatedBlogs() {
 	suite.Equal("Blog 2", retrievedBlogs[1].Title)
 }
 
+func (suite *BlogRepositoryTestSuite) TestGetBlogsByCursor() {
+	// Create multiple test blogs
+	blogs := []models.Blog{
+		{Title: "Blog 1", Content: "Content 1", AuthorID: "user1", AuthorName: "user1@test.com", IsPublished: true},
+		{Title: "Blog 2", Content: "Content 2", AuthorID: "user2", AuthorName: "user2@test.com", IsPublished: true},
+		{Title: "Blog 3", Content: "Content 3", AuthorID: "user3", AuthorName: "user3@test.com", IsPublished: true},
+		{Title: "Draft Blog", Content: "Draft Content", AuthorID: "user4", AuthorName: "user4@test.com", IsPublished: false},
+	}
+
+	for _, blog := range blogs {
+		suite.repo.CreateBlog(blog)
+	}
+
+	// First page
+	firstPage, hasMore, err := suite.repo.GetBlogsByCursor(models.PageRequest{Limit: 2})
+	suite.NoError(err)
+	suite.True(hasMore)
+	suite.Len(firstPage, 2)
+	suite.Equal("Blog 3", firstPage[0].Title)
+
+	// A blog published while scrolling must not shift the next page
+	suite.repo.CreateBlog(models.Blog{Title: "Blog 4", Content: "Content 4", AuthorID: "user1", AuthorName: "user1@test.com", IsPublished: true})
+
+	last := firstPage[1]
+	secondPage, hasMore, err := suite.repo.GetBlogsByCursor(models.PageRequest{
+		Cursor: &models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID},
+		Limit:  2,
+	})
+	suite.NoError(err)
+	suite.False(hasMore)
+	suite.Len(secondPage, 1)
+	suite.Equal("Blog 1", secondPage[0].Title)
+
+	// Paging back returns the first page in list order
+	first := secondPage[0]
+	backPage, hasMore, err := suite.repo.GetBlogsByCursor(models.PageRequest{
+		Cursor: &models.Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true},
+		Limit:  2,
+	})
+	suite.NoError(err)
+	suite.True(hasMore)
+	suite.Equal("Blog 3", backPage[0].Title)
+	suite.Equal("Blog 2", backPage[1].Title)
+
+	total, err := suite.repo.CountPublishedBlogs()
+	suite.NoError(err)
+	suite.Equal(int64(4), total)
+}
+
 func (suite *BlogRepositoryTestSuite) TestSearchBlogs() {
 	// Create test blogs
 	blogs := []models.Blog{
@@ -230,16 +301,20 @@ func (suite *BlogRepositoryTestSuite) TestSearchBlogs() {
 	}
 
 	// Search for "Go"
-	results, err := suite.repo.SearchBlogs("Go")
+	results, err := suite.repo.SearchBlogs("Go", 1, 10)
 
 	// Assertions
 	suite.NoError(err)
 	suite.Len(results, 2) // Should find "Go Programming" and "Web Development" (published only)
 
-	// Verify search results
-	titles := []string{results[0].Title, results[1].Title}
-	suite.Contains(titles, "Go Programming")
-	suite.Contains(titles, "Web Development")
+	// Title matches outrank content matches
+	suite.Equal("Go Programming", results[0].Blog.Title)
+	suite.Equal("Web Development", results[1].Blog.Title)
+	suite.Greater(results[0].Score, results[1].Score)
+
+	total, err := suite.repo.CountSearchResults("Go")
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
 }
 
 func (suite *BlogRepositoryTestSuite) TestFilterBlogs() {
@@ -273,6 +348,54 @@ func (suite *BlogRepositoryTestSuite) TestFilterBlogs() {
 	suite.Equal("Go Blog", results[0].Title)
 }
 
+func (suite *BlogRepositoryTestSuite) TestQueryBlogs() {
+	// Create test blogs
+	blogs := []models.Blog{
+		{Title: "Go Blog", Content: "Go content", AuthorID: "user1", AuthorName: "user1@test.com", Tags: []string{"go", "programming"}, IsPublished: true},
+		{Title: "Go Web", Content: "Web with Go", AuthorID: "user1", AuthorName: "user1@test.com", Tags: []string{"go", "web"}, IsPublished: true},
+		{Title: "Python Blog", Content: "Python content", AuthorID: "user2", AuthorName: "user2@test.com", Tags: []string{"python", "programming"}, IsPublished: true},
+		{Title: "Go Draft", Content: "Draft", AuthorID: "user2", AuthorName: "user2@test.com", Tags: []string{"go"}, IsPublished: false},
+	}
+
+	for _, blog := range blogs {
+		suite.repo.CreateBlog(blog)
+	}
+
+	// Blogs tagged with both go and programming
+	result, err := suite.repo.QueryBlogs(models.BlogQuery{
+		Tags:      []string{"go", "programming"},
+		TagMatch:  models.TagMatchAll,
+		SortBy:    "created_at",
+		SortOrder: -1,
+		Page:      1,
+		Limit:     10,
+	})
+
+	// Assertions
+	suite.NoError(err)
+	suite.Equal(int64(1), result.Total)
+	suite.Len(result.Blogs, 1)
+	suite.Equal("Go Blog", result.Blogs[0].Title)
+
+	// Any tag; facets cover every published match
+	result, err = suite.repo.QueryBlogs(models.BlogQuery{
+		Tags:      []string{"go"},
+		TagMatch:  models.TagMatchAny,
+		SortBy:    "title",
+		SortOrder: 1,
+		Page:      1,
+		Limit:     1,
+	})
+
+	suite.NoError(err)
+	suite.Equal(int64(2), result.Total)
+	suite.Len(result.Blogs, 1)
+	suite.Equal("Go Blog", result.Blogs[0].Title)
+	suite.Equal(models.FacetCount{Value: "go", Count: 2}, result.Facets.Tags[0])
+	suite.Equal(models.FacetCount{Value: "user1", Label: "user1@test.com", Count: 2}, result.Facets.Authors[0])
+	suite.Len(result.Facets.Months, 1)
+}
+
 func (suite *BlogRepositoryTestSuite) TestIncrementViewCount() {
 	// Create test blog
 	blog := models.Blog{
@@ -350,100 +473,6 @@ func (suite *BlogRepositoryTestSuite) TestUpdateDislikes() {
 	suite.Equal(0, updatedBlog.Dislikes)
 }
 
-func (suite *BlogRepositoryTestSuite) TestAddComment() {
-	// Create test blog
-	blog := models.Blog{
-		Title:       "Test Blog",
-		Content:     "Test Content",
-		AuthorID:    "user123",
-		AuthorName:  "test@example.com",
-		Comments:    []models.Comment{}, // Initialize empty slice
-		IsPublished: true,
-	}
-	createdBlog, _ := suite.repo.CreateBlog(blog)
-
-	// Add comment
-	comment := models.Comment{
-		AuthorID:   "commenter123",
-		AuthorName: "commenter@test.com",
-		Content:    "Great blog post!",
-	}
-
-	createdComment, err := suite.repo.AddComment(createdBlog.ID, comment)
-
-	// Assertions
-	suite.NoError(err)
-	suite.NotEmpty(createdComment.ID)
-	suite.Equal(createdBlog.ID, createdComment.BlogID)
-	suite.Equal(comment.AuthorID, createdComment.AuthorID)
-	suite.Equal(comment.AuthorName, createdComment.AuthorName)
-	suite.Equal(comment.Content, createdComment.Content)
-	suite.False(createdComment.CreatedAt.IsZero())
-	suite.False(createdComment.UpdatedAt.IsZero())
-
-	// Verify comment was added to blog
-	updatedBlog, _ := suite.repo.GetBlogByID(createdBlog.ID)
-	suite.Len(updatedBlog.Comments, 1)
-	suite.Equal(createdComment.ID, updatedBlog.Comments[0].ID)
-}
-
-func (suite *BlogRepositoryTestSuite) TestGetComments() {
-	// Create test blog with comments
-	blog := models.Blog{
-		Title:       "Test Blog",
-		Content:     "Test Content",
-		AuthorID:    "user123",
-		AuthorName:  "test@example.com",
-		Comments:    []models.Comment{}, // Initialize empty slice
-		IsPublished: true,
-	}
-	createdBlog, _ := suite.repo.CreateBlog(blog)
-
-	// Add comments
-	comments := []models.Comment{
-		{AuthorID: "user1", AuthorName: "user1@test.com", Content: "Comment 1"},
-		{AuthorID: "user2", AuthorName: "user2@test.com", Content: "Comment 2"},
-	}
-
-	for _, comment := range comments {
-		suite.repo.AddComment(createdBlog.ID, comment)
-	}
-
-	// Get comments
-	retrievedComments, err := suite.repo.GetComments(createdBlog.ID)
-
-	// Assertions
-	suite.NoError(err)
-	suite.Len(retrievedComments, 2)
-	if len(retrievedComments) >= 1 {
-		suite.Equal("Comment 1", retrievedComments[0].Content)
-	}
-	if len(retrievedComments) >= 2 {
-		suite.Equal("Comment 2", retrievedComments[1].Content)
-	}
-}
-
-func (suite *BlogRepositoryTestSuite) TestGetComments_NoComments() {
-	// Create test blog without comments
-	blog := models.Blog{
-		Title:       "Test Blog",
-		Content:     "Test Content",
-		AuthorID:    "user123",
-		AuthorName:  "test@example.com",
-		Comments:    []models.Comment{}, // Initialize empty slice
-		IsPublished: true,
-	}
-	createdBlog, _ := suite.repo.CreateBlog(blog)
-
-	// Get comments
-	retrievedComments, err := suite.repo.GetComments(createdBlog.ID)
-
-	// Assertions
-	suite.NoError(err)
-	suite.Len(retrievedComments, 0)
-}
-
-// Run the test suite
 func TestBlogRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(BlogRepositoryTestSuite))
 }
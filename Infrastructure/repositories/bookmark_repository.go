@@ -0,0 +1,320 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type bookmarkMongoRepo struct {
+	collectionsCollection *mongo.Collection
+	bookmarksCollection   *mongo.Collection
+}
+
+func NewBookmarkMongoRepo(database *mongo.Database) *bookmarkMongoRepo {
+	return &bookmarkMongoRepo{
+		collectionsCollection: database.Collection("bookmark_collections"),
+		bookmarksCollection:   database.Collection("bookmarks"),
+	}
+}
+
+// EnsureIndexes creates the indexes bookmark lookups rely on. The unique indexes keep collection
+// names distinct per user and a post to one bookmark per collection.
+func (br *bookmarkMongoRepo) EnsureIndexes() error {
+	collectionIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("bookmark_collection_user_name").SetUnique(true),
+		},
+	}
+	if _, err := br.collectionsCollection.Indexes().CreateMany(context.TODO(), collectionIndexes); err != nil {
+		return err
+	}
+
+	bookmarkIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "collection_id", Value: 1}, {Key: "blog_id", Value: 1}},
+			Options: options.Index().SetName("bookmark_collection_blog").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "collection_id", Value: 1}, {Key: "position", Value: 1}},
+			Options: options.Index().SetName("bookmark_collection_position"),
+		},
+	}
+	_, err := br.bookmarksCollection.Indexes().CreateMany(context.TODO(), bookmarkIndexes)
+	return err
+}
+
+// CreateCollection creates a named collection. The unique name index turns a second collection
+// with the same name into an error, even when both arrive at once.
+func (br *bookmarkMongoRepo) CreateCollection(collection models.BookmarkCollection) (models.BookmarkCollection, error) {
+	objectID := primitive.NewObjectID()
+	collection.ID = objectID.Hex()
+	collection.CreatedAt = time.Now()
+	collection.UpdatedAt = collection.CreatedAt
+
+	doc := bson.M{
+		"_id":        objectID,
+		"user_id":    collection.UserID,
+		"name":       collection.Name,
+		"created_at": collection.CreatedAt,
+		"updated_at": collection.UpdatedAt,
+	}
+
+	_, err := br.collectionsCollection.InsertOne(context.TODO(), doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.BookmarkCollection{}, errors.New("a collection with this name already exists")
+	}
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// GetCollectionByID retrieves a collection by its ID
+func (br *bookmarkMongoRepo) GetCollectionByID(collectionID string) (models.BookmarkCollection, error) {
+	objectID, err := primitive.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	var collection models.BookmarkCollection
+	err = br.collectionsCollection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&collection)
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// GetCollectionByName retrieves one of the user's collections by its name
+func (br *bookmarkMongoRepo) GetCollectionByName(userID, name string) (models.BookmarkCollection, error) {
+	var collection models.BookmarkCollection
+	err := br.collectionsCollection.FindOne(context.TODO(), bson.M{"user_id": userID, "name": name}).Decode(&collection)
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// GetCollections lists the user's collections, oldest first
+func (br *bookmarkMongoRepo) GetCollections(userID string) ([]models.BookmarkCollection, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := br.collectionsCollection.Find(context.TODO(), bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	collections := []models.BookmarkCollection{}
+	if err = cursor.All(context.TODO(), &collections); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// RenameCollection renames a collection; the unique name index rejects a name already in use
+func (br *bookmarkMongoRepo) RenameCollection(collectionID, name string) error {
+	objectID, err := primitive.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"name": name, "updated_at": time.Now()}}
+	_, err = br.collectionsCollection.UpdateOne(context.TODO(), bson.M{"_id": objectID}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.New("a collection with this name already exists")
+	}
+	return err
+}
+
+// DeleteCollection removes the collection and then its bookmarks
+func (br *bookmarkMongoRepo) DeleteCollection(collectionID string) error {
+	objectID, err := primitive.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := br.collectionsCollection.DeleteOne(context.TODO(), bson.M{"_id": objectID}); err != nil {
+		return err
+	}
+
+	_, err = br.bookmarksCollection.DeleteMany(context.TODO(), bson.M{"collection_id": collectionID})
+	return err
+}
+
+// AddBookmark appends the post after the last bookmark of the collection. The unique index turns
+// a second bookmark of the same post into a no-op.
+func (br *bookmarkMongoRepo) AddBookmark(bookmark models.Bookmark) (models.Bookmark, bool, error) {
+	var last models.Bookmark
+	opts := options.FindOne().SetSort(bson.D{{Key: "position", Value: -1}})
+	err := br.bookmarksCollection.FindOne(context.TODO(), bson.M{"collection_id": bookmark.CollectionID}, opts).Decode(&last)
+	switch {
+	case err == nil:
+		bookmark.Position = last.Position + 1
+	case errors.Is(err, mongo.ErrNoDocuments):
+		bookmark.Position = 0
+	default:
+		return models.Bookmark{}, false, err
+	}
+
+	objectID := primitive.NewObjectID()
+	bookmark.ID = objectID.Hex()
+	bookmark.CreatedAt = time.Now()
+
+	doc := bson.M{
+		"_id":           objectID,
+		"user_id":       bookmark.UserID,
+		"collection_id": bookmark.CollectionID,
+		"blog_id":       bookmark.BlogID,
+		"position":      bookmark.Position,
+		"is_read":       false,
+		"created_at":    bookmark.CreatedAt,
+	}
+
+	_, err = br.bookmarksCollection.InsertOne(context.TODO(), doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Bookmark{}, false, nil
+	}
+	if err != nil {
+		return models.Bookmark{}, false, err
+	}
+
+	return bookmark, true, nil
+}
+
+// GetBookmarkByID retrieves a bookmark by its ID
+func (br *bookmarkMongoRepo) GetBookmarkByID(bookmarkID string) (models.Bookmark, error) {
+	objectID, err := primitive.ObjectIDFromHex(bookmarkID)
+	if err != nil {
+		return models.Bookmark{}, err
+	}
+
+	var bookmark models.Bookmark
+	err = br.bookmarksCollection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&bookmark)
+	if err != nil {
+		return models.Bookmark{}, err
+	}
+
+	return bookmark, nil
+}
+
+// GetBookmarks retrieves a page of the collection in its order. Bookmarks added at once can share
+// a position, so creation time breaks ties.
+func (br *bookmarkMongoRepo) GetBookmarks(collectionID string, unreadOnly bool, page, limit int) ([]models.Bookmark, error) {
+	skip := (page - 1) * limit
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip)).
+		SetSort(bson.D{{Key: "position", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := br.bookmarksCollection.Find(context.TODO(), bookmarkFilter(collectionID, unreadOnly), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	bookmarks := []models.Bookmark{}
+	if err = cursor.All(context.TODO(), &bookmarks); err != nil {
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
+
+// CountBookmarks counts the bookmarks of the collection
+func (br *bookmarkMongoRepo) CountBookmarks(collectionID string, unreadOnly bool) (int64, error) {
+	return br.bookmarksCollection.CountDocuments(context.TODO(), bookmarkFilter(collectionID, unreadOnly))
+}
+
+// GetBookmarkIDs lists the IDs of every bookmark in the collection in its order
+func (br *bookmarkMongoRepo) GetBookmarkIDs(collectionID string) ([]string, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "position", Value: 1}, {Key: "created_at", Value: 1}}).
+		SetProjection(bson.M{"_id": 1})
+
+	cursor, err := br.bookmarksCollection.Find(context.TODO(), bson.M{"collection_id": collectionID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var bookmarks []models.Bookmark
+	if err = cursor.All(context.TODO(), &bookmarks); err != nil {
+		return nil, err
+	}
+
+	bookmarkIDs := make([]string, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		bookmarkIDs[i] = bookmark.ID
+	}
+
+	return bookmarkIDs, nil
+}
+
+// SetPositions numbers the bookmarks in the given order in one bulk write. Each update is scoped
+// to the collection, so IDs from elsewhere change nothing.
+func (br *bookmarkMongoRepo) SetPositions(collectionID string, bookmarkIDs []string) error {
+	writes := make([]mongo.WriteModel, 0, len(bookmarkIDs))
+	for position, bookmarkID := range bookmarkIDs {
+		objectID, err := primitive.ObjectIDFromHex(bookmarkID)
+		if err != nil {
+			return err
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": objectID, "collection_id": collectionID}).
+			SetUpdate(bson.M{"$set": bson.M{"position": position}}))
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err := br.bookmarksCollection.BulkWrite(context.TODO(), writes)
+	return err
+}
+
+// SetRead marks the bookmark read or unread, recording when it was read
+func (br *bookmarkMongoRepo) SetRead(bookmarkID string, read bool) error {
+	objectID, err := primitive.ObjectIDFromHex(bookmarkID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"is_read": true, "read_at": time.Now()}}
+	if !read {
+		update = bson.M{"$set": bson.M{"is_read": false}, "$unset": bson.M{"read_at": ""}}
+	}
+
+	_, err = br.bookmarksCollection.UpdateOne(context.TODO(), bson.M{"_id": objectID}, update)
+	return err
+}
+
+// RemoveBookmark deletes a bookmark
+func (br *bookmarkMongoRepo) RemoveBookmark(bookmarkID string) error {
+	objectID, err := primitive.ObjectIDFromHex(bookmarkID)
+	if err != nil {
+		return err
+	}
+
+	_, err = br.bookmarksCollection.DeleteOne(context.TODO(), bson.M{"_id": objectID})
+	return err
+}
+
+func bookmarkFilter(collectionID string, unreadOnly bool) bson.M {
+	filter := bson.M{"collection_id": collectionID}
+	if unreadOnly {
+		filter["is_read"] = false
+	}
+	return filter
+}
@@ -0,0 +1,327 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentMongoRepo struct {
+	commentsCollection *mongo.Collection
+	blogsCollection    *mongo.Collection
+}
+
+func NewCommentMongoRepo(database *mongo.Database) *commentMongoRepo {
+	return &commentMongoRepo{
+		commentsCollection: database.Collection("comments"),
+		blogsCollection:    database.Collection("blogs"),
+	}
+}
+
+// embeddedComment is a comment as older versions stored it, in the blog's comments array
+type embeddedComment struct {
+	ID         string    `bson:"_id"`
+	AuthorID   string    `bson:"author_id"`
+	AuthorName string    `bson:"author_name"`
+	Content    string    `bson:"content"`
+	CreatedAt  time.Time `bson:"created_at"`
+	UpdatedAt  time.Time `bson:"updated_at"`
+}
+
+// MigrateEmbeddedComments moves comments still embedded in blog documents into the comments
+// collection as top-level comments. Comments keep their IDs and are upserted, and each blog's
+// array is removed in the same update that counts it, so an interrupted run can be repeated.
+func (cr *commentMongoRepo) MigrateEmbeddedComments() (int, error) {
+	filter := bson.M{"comments": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"comments": 1})
+	cursor, err := cr.blogsCollection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(context.TODO())
+
+	migrated := 0
+	for cursor.Next(context.TODO()) {
+		var blog struct {
+			ID       primitive.ObjectID `bson:"_id"`
+			Comments []embeddedComment  `bson:"comments"`
+		}
+		if err := cursor.Decode(&blog); err != nil {
+			return migrated, err
+		}
+
+		for _, comment := range blog.Comments {
+			objectID, err := primitive.ObjectIDFromHex(comment.ID)
+			if err != nil {
+				objectID = primitive.NewObjectID()
+			}
+			commentModel := bson.M{
+				"blog_id":     blog.ID.Hex(),
+				"depth":       0,
+				"author_id":   comment.AuthorID,
+				"author_name": comment.AuthorName,
+				"content":     comment.Content,
+				"is_deleted":  false,
+				"created_at":  comment.CreatedAt,
+				"updated_at":  comment.UpdatedAt,
+			}
+			_, err = cr.commentsCollection.UpdateOne(context.TODO(), bson.M{"_id": objectID},
+				bson.M{"$setOnInsert": commentModel}, options.Update().SetUpsert(true))
+			if err != nil {
+				return migrated, err
+			}
+		}
+
+		update := bson.M{
+			"$inc":   bson.M{"comment_count": len(blog.Comments)},
+			"$unset": bson.M{"comments": ""},
+		}
+		_, err = cr.blogsCollection.UpdateOne(context.TODO(), bson.M{"_id": blog.ID, "comments": bson.M{"$exists": true}}, update)
+		if err != nil {
+			return migrated, err
+		}
+		migrated += len(blog.Comments)
+	}
+
+	return migrated, cursor.Err()
+}
+
+// visibleComments leaves out comments held by moderation
+var visibleComments = bson.M{"$nin": bson.A{models.ModerationStatusPending, models.ModerationStatusRejected}}
+
+// CreateComment inserts a comment and bumps the blog's comment counter
+func (cr *commentMongoRepo) CreateComment(comment models.Comment) (models.Comment, error) {
+	blogObjectID, err := primitive.ObjectIDFromHex(comment.BlogID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	objectID := primitive.NewObjectID()
+	comment.ID = objectID.Hex()
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = time.Now()
+
+	commentModel := bson.M{
+		"_id":         objectID,
+		"blog_id":     comment.BlogID,
+		"parent_id":   comment.ParentID,
+		"root_id":     comment.RootID,
+		"depth":       comment.Depth,
+		"author_id":   comment.AuthorID,
+		"author_name": comment.AuthorName,
+		"content":     comment.Content,
+		"is_deleted":  false,
+		"created_at":  comment.CreatedAt,
+		"updated_at":  comment.UpdatedAt,
+	}
+	if comment.ModerationStatus != "" {
+		commentModel["moderation_status"] = comment.ModerationStatus
+	}
+
+	_, err = cr.commentsCollection.InsertOne(context.TODO(), commentModel)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	// Held comments are only counted once approved
+	if models.IsModerationHeld(comment.ModerationStatus) {
+		return comment, nil
+	}
+
+	update := bson.M{"$inc": bson.M{"comment_count": 1}}
+	_, err = cr.blogsCollection.UpdateOne(context.TODO(), bson.M{"_id": blogObjectID}, update)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// GetCommentByID retrieves a single comment
+func (cr *commentMongoRepo) GetCommentByID(commentID string) (models.Comment, error) {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	var comment models.Comment
+	err = cr.commentsCollection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&comment)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment replaces the content of a live comment
+func (cr *commentMongoRepo) UpdateComment(commentID string, content string) (models.Comment, error) {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	filter := bson.M{"_id": objectID, "is_deleted": false}
+	update := bson.M{"$set": bson.M{
+		"content":    content,
+		"updated_at": time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var comment models.Comment
+	err = cr.commentsCollection.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&comment)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// SoftDeleteComment turns a comment into a tombstone and decrements the blog's comment counter
+func (cr *commentMongoRepo) SoftDeleteComment(commentID string) error {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	filter := bson.M{"_id": objectID, "is_deleted": false}
+	update := bson.M{"$set": bson.M{
+		"content":    "",
+		"is_deleted": true,
+		"deleted_at": now,
+		"updated_at": now,
+	}}
+
+	var comment models.Comment
+	err = cr.commentsCollection.FindOneAndUpdate(context.TODO(), filter, update).Decode(&comment)
+	if err == mongo.ErrNoDocuments {
+		// Already deleted
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if models.IsModerationHeld(comment.ModerationStatus) {
+		// Never counted
+		return nil
+	}
+
+	return cr.incrementCommentCount(comment.BlogID, -1)
+}
+
+// SetModerationStatus records a review outcome. Approving a held comment counts it on its blog
+// and rejecting a visible one stops counting it.
+func (cr *commentMongoRepo) SetModerationStatus(commentID, status string) error {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"moderation_status": status}}
+
+	var previous models.Comment
+	err = cr.commentsCollection.FindOneAndUpdate(context.TODO(), bson.M{"_id": objectID}, update).Decode(&previous)
+	if err != nil {
+		return err
+	}
+	if previous.IsDeleted {
+		return nil
+	}
+
+	wasCounted := !models.IsModerationHeld(previous.ModerationStatus)
+	isCounted := !models.IsModerationHeld(status)
+	switch {
+	case isCounted && !wasCounted:
+		return cr.incrementCommentCount(previous.BlogID, 1)
+	case wasCounted && !isCounted:
+		return cr.incrementCommentCount(previous.BlogID, -1)
+	}
+	return nil
+}
+
+func (cr *commentMongoRepo) incrementCommentCount(blogID string, delta int) error {
+	blogObjectID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.blogsCollection.UpdateOne(context.TODO(), bson.M{"_id": blogObjectID}, bson.M{"$inc": bson.M{"comment_count": delta}})
+	return err
+}
+
+// GetRootComments retrieves a page of top-level comments for a blog, leaving out held ones
+func (cr *commentMongoRepo) GetRootComments(blogID string, page, limit int) ([]models.Comment, error) {
+	skip := (page - 1) * limit
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	filter := bson.M{"blog_id": blogID, "depth": 0, "moderation_status": visibleComments}
+	cursor, err := cr.commentsCollection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var comments []models.Comment
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// GetRootCommentsByCursor retrieves the page of top-level comments next to the cursor, oldest first
+func (cr *commentMongoRepo) GetRootCommentsByCursor(blogID string, page models.PageRequest) ([]models.Comment, bool, error) {
+	filter, opts, err := keysetFilter(bson.M{"blog_id": blogID, "depth": 0, "moderation_status": visibleComments}, "created_at", 1, page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cursor, err := cr.commentsCollection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var comments []models.Comment
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		return nil, false, err
+	}
+
+	comments, hasMore := keysetPage(comments, page)
+	return comments, hasMore, nil
+}
+
+// CountRootComments counts the top-level comments of a blog
+func (cr *commentMongoRepo) CountRootComments(blogID string) (int64, error) {
+	return cr.commentsCollection.CountDocuments(context.TODO(), bson.M{"blog_id": blogID, "depth": 0, "moderation_status": visibleComments})
+}
+
+// GetThreadReplies retrieves every visible reply under the given top-level comments
+func (cr *commentMongoRepo) GetThreadReplies(rootIDs []string) ([]models.Comment, error) {
+	if len(rootIDs) == 0 {
+		return []models.Comment{}, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := cr.commentsCollection.Find(context.TODO(), bson.M{"root_id": bson.M{"$in": rootIDs}, "moderation_status": visibleComments}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var comments []models.Comment
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
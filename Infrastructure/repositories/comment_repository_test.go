@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepositoryTestSuite struct {
+	suite.Suite
+	client   *mongo.Client
+	database *mongo.Database
+	blogs    *mongo.Collection
+	comments *mongo.Collection
+	repo     *commentMongoRepo
+	ctx      context.Context
+}
+
+func (suite *CommentRepositoryTestSuite) SetupSuite() {
+	// Connect to test database, skipping the suite when there is none
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017").SetServerSelectionTimeout(2 * time.Second)
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		suite.T().Skipf("MongoDB is not reachable: %v", err)
+	}
+	suite.client = client
+
+	// Create test database and collections
+	suite.database = client.Database("blog_comment_test_db")
+	suite.blogs = suite.database.Collection("blogs")
+	suite.comments = suite.database.Collection("comments")
+	suite.ctx = context.Background()
+
+	// Initialize repository
+	suite.repo = NewCommentMongoRepo(suite.database)
+}
+
+func (suite *CommentRepositoryTestSuite) TearDownSuite() {
+	// Drop test database
+	if suite.database != nil {
+		suite.database.Drop(suite.ctx)
+	}
+
+	// Close connection
+	if suite.client != nil {
+		suite.client.Disconnect(suite.ctx)
+	}
+}
+
+func (suite *CommentRepositoryTestSuite) SetupTest() {
+	// Clear collections before each test
+	suite.blogs.DeleteMany(suite.ctx, bson.M{})
+	suite.comments.DeleteMany(suite.ctx, bson.M{})
+}
+
+// insertBlog stores a bare blog and returns its ID
+func (suite *CommentRepositoryTestSuite) insertBlog() string {
+	objectID := primitive.NewObjectID()
+	_, err := suite.blogs.InsertOne(suite.ctx, bson.M{"_id": objectID, "title": "Test Blog", "comment_count": 0})
+	suite.Require().NoError(err)
+	return objectID.Hex()
+}
+
+func (suite *CommentRepositoryTestSuite) commentCount(blogID string) int {
+	objectID, _ := primitive.ObjectIDFromHex(blogID)
+	var blog models.Blog
+	suite.Require().NoError(suite.blogs.FindOne(suite.ctx, bson.M{"_id": objectID}).Decode(&blog))
+	return blog.CommentCount
+}
+
+func (suite *CommentRepositoryTestSuite) TestCreateComment() {
+	blogID := suite.insertBlog()
+
+	// Create comment
+	comment, err := suite.repo.CreateComment(models.Comment{
+		BlogID:     blogID,
+		AuthorID:   "commenter123",
+		AuthorName: "commenter@test.com",
+		Content:    "Great blog post!",
+	})
+
+	// Assertions
+	suite.NoError(err)
+	suite.NotEmpty(comment.ID)
+	suite.False(comment.CreatedAt.IsZero())
+	suite.Equal(1, suite.commentCount(blogID))
+
+	saved, err := suite.repo.GetCommentByID(comment.ID)
+	suite.NoError(err)
+	suite.Equal("Great blog post!", saved.Content)
+	suite.Equal(blogID, saved.BlogID)
+}
+
+func (suite *CommentRepositoryTestSuite) TestCreateComment_HeldIsNotCounted() {
+	blogID := suite.insertBlog()
+
+	// Create held comment
+	comment, err := suite.repo.CreateComment(models.Comment{
+		BlogID:           blogID,
+		AuthorID:         "commenter123",
+		Content:          "Buy now",
+		ModerationStatus: models.ModerationStatusPending,
+	})
+	suite.NoError(err)
+	suite.Equal(0, suite.commentCount(blogID))
+
+	// Approving counts it
+	suite.NoError(suite.repo.SetModerationStatus(comment.ID, models.ModerationStatusApproved))
+	suite.Equal(1, suite.commentCount(blogID))
+
+	// Rejecting stops counting it
+	suite.NoError(suite.repo.SetModerationStatus(comment.ID, models.ModerationStatusRejected))
+	suite.Equal(0, suite.commentCount(blogID))
+}
+
+func (suite *CommentRepositoryTestSuite) TestUpdateComment() {
+	blogID := suite.insertBlog()
+	comment, _ := suite.repo.CreateComment(models.Comment{BlogID: blogID, AuthorID: "user1", Content: "First"})
+
+	// Update comment
+	updated, err := suite.repo.UpdateComment(comment.ID, "Edited")
+
+	// Assertions
+	suite.NoError(err)
+	suite.Equal("Edited", updated.Content)
+
+	// Deleted comments cannot be edited
+	suite.NoError(suite.repo.SoftDeleteComment(comment.ID))
+	_, err = suite.repo.UpdateComment(comment.ID, "Again")
+	suite.ErrorIs(err, mongo.ErrNoDocuments)
+}
+
+func (suite *CommentRepositoryTestSuite) TestSoftDeleteComment() {
+	blogID := suite.insertBlog()
+	comment, _ := suite.repo.CreateComment(models.Comment{BlogID: blogID, AuthorID: "user1", Content: "Bye"})
+
+	// Delete twice
+	suite.NoError(suite.repo.SoftDeleteComment(comment.ID))
+	suite.NoError(suite.repo.SoftDeleteComment(comment.ID))
+
+	// Assertions
+	saved, err := suite.repo.GetCommentByID(comment.ID)
+	suite.NoError(err)
+	suite.True(saved.IsDeleted)
+	suite.Empty(saved.Content)
+	suite.NotNil(saved.DeletedAt)
+	suite.Equal(0, suite.commentCount(blogID))
+}
+
+func (suite *CommentRepositoryTestSuite) TestGetRootCommentsAndReplies() {
+	blogID := suite.insertBlog()
+	first, _ := suite.repo.CreateComment(models.Comment{BlogID: blogID, AuthorID: "user1", Content: "Comment 1"})
+	suite.repo.CreateComment(models.Comment{BlogID: blogID, AuthorID: "user2", Content: "Comment 2"})
+	suite.repo.CreateComment(models.Comment{BlogID: blogID, AuthorID: "user3", Content: "Held", ModerationStatus: models.ModerationStatusPending})
+	suite.repo.CreateComment(models.Comment{BlogID: blogID, ParentID: first.ID, RootID: first.ID, Depth: 1, AuthorID: "user2", Content: "Reply"})
+
+	// Get top-level comments
+	roots, err := suite.repo.GetRootComments(blogID, 1, 10)
+	suite.NoError(err)
+	suite.Len(roots, 2)
+	suite.Equal("Comment 1", roots[0].Content)
+	suite.Equal("Comment 2", roots[1].Content)
+
+	total, err := suite.repo.CountRootComments(blogID)
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+
+	// Get replies
+	replies, err := suite.repo.GetThreadReplies([]string{first.ID})
+	suite.NoError(err)
+	suite.Len(replies, 1)
+	suite.Equal("Reply", replies[0].Content)
+}
+
+func (suite *CommentRepositoryTestSuite) TestGetRootComments_NoComments() {
+	blogID := suite.insertBlog()
+
+	roots, err := suite.repo.GetRootComments(blogID, 1, 10)
+
+	suite.NoError(err)
+	suite.Len(roots, 0)
+}
+
+func (suite *CommentRepositoryTestSuite) TestMigrateEmbeddedComments() {
+	// Blog stored with the old embedded array
+	blogObjectID := primitive.NewObjectID()
+	commentID := primitive.NewObjectID().Hex()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err := suite.blogs.InsertOne(suite.ctx, bson.M{
+		"_id":   blogObjectID,
+		"title": "Old Blog",
+		"comments": bson.A{
+			bson.M{"_id": commentID, "blog_id": blogObjectID.Hex(), "author_id": "user1", "author_name": "user1@test.com", "content": "Comment 1", "created_at": createdAt, "updated_at": createdAt},
+			bson.M{"_id": primitive.NewObjectID().Hex(), "blog_id": blogObjectID.Hex(), "author_id": "user2", "author_name": "user2@test.com", "content": "Comment 2", "created_at": createdAt.Add(time.Minute), "updated_at": createdAt.Add(time.Minute)},
+		},
+	})
+	suite.Require().NoError(err)
+
+	// Migrate twice
+	migrated, err := suite.repo.MigrateEmbeddedComments()
+	suite.NoError(err)
+	suite.Equal(2, migrated)
+	migrated, err = suite.repo.MigrateEmbeddedComments()
+	suite.NoError(err)
+	suite.Equal(0, migrated)
+
+	// Assertions
+	blogID := blogObjectID.Hex()
+	suite.Equal(2, suite.commentCount(blogID))
+	count, _ := suite.blogs.CountDocuments(suite.ctx, bson.M{"comments": bson.M{"$exists": true}})
+	suite.Equal(int64(0), count)
+
+	roots, err := suite.repo.GetRootComments(blogID, 1, 10)
+	suite.NoError(err)
+	suite.Len(roots, 2)
+	suite.Equal(commentID, roots[0].ID)
+	suite.Equal("Comment 1", roots[0].Content)
+	suite.Equal(createdAt, roots[0].CreatedAt.UTC())
+}
+
+// Run the test suite
+func TestCommentRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentRepositoryTestSuite))
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type followMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewFollowMongoRepo(col *mongo.Collection) *followMongoRepo {
+	return &followMongoRepo{collection: col}
+}
+
+// EnsureIndexes creates the indexes follow lookups rely on. The unique pair index is what makes
+// following twice a no-op.
+func (fr *followMongoRepo) EnsureIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "followee_id", Value: 1}},
+			Options: options.Index().SetName("follow_pair").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "followee_id", Value: 1}},
+			Options: options.Index().SetName("follow_followee"),
+		},
+	}
+
+	_, err := fr.collection.Indexes().CreateMany(context.TODO(), indexes)
+	return err
+}
+
+// Follow records the follow. The unique pair index turns a repeated follow into a no-op, even
+// when both requests arrive at once.
+func (fr *followMongoRepo) Follow(followerID, followeeID string) (bool, error) {
+	doc := bson.M{
+		"_id":         primitive.NewObjectID(),
+		"follower_id": followerID,
+		"followee_id": followeeID,
+		"created_at":  time.Now(),
+	}
+
+	_, err := fr.collection.InsertOne(context.TODO(), doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Unfollow removes the follow if there is one
+func (fr *followMongoRepo) Unfollow(followerID, followeeID string) (bool, error) {
+	result, err := fr.collection.DeleteOne(context.TODO(), bson.M{"follower_id": followerID, "followee_id": followeeID})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// IsFollowing reports whether the follower follows the author
+func (fr *followMongoRepo) IsFollowing(followerID, followeeID string) (bool, error) {
+	count, err := fr.collection.CountDocuments(context.TODO(), bson.M{"follower_id": followerID, "followee_id": followeeID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetFollowing lists the IDs of every author the user follows, oldest follow first
+func (fr *followMongoRepo) GetFollowing(followerID string) ([]string, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := fr.collection.Find(context.TODO(), bson.M{"follower_id": followerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var follows []models.Follow
+	if err = cursor.All(context.TODO(), &follows); err != nil {
+		return nil, err
+	}
+
+	authorIDs := make([]string, len(follows))
+	for i, follow := range follows {
+		authorIDs[i] = follow.FolloweeID
+	}
+
+	return authorIDs, nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewLoginAttemptMongoRepo(col *mongo.Collection) *loginAttemptMongoRepo {
+	return &loginAttemptMongoRepo{collection: col}
+}
+
+// EnsureIndexes creates a TTL index that lets Mongo remove counters once their window has passed
+func (lr *loginAttemptMongoRepo) EnsureIndexes() error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("login_attempt_expiry").SetExpireAfterSeconds(0),
+	}
+
+	_, err := lr.collection.Indexes().CreateOne(context.TODO(), index)
+	return err
+}
+
+func (lr *loginAttemptMongoRepo) GetAttempts(key string) (models.AttemptCounter, error) {
+	var counter models.AttemptCounter
+	err := lr.collection.FindOne(context.TODO(), bson.M{"_id": key}).Decode(&counter)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.AttemptCounter{Key: key}, nil
+	}
+	if err != nil {
+		return models.AttemptCounter{}, err
+	}
+
+	return counter, nil
+}
+
+// ReserveAttempt matches the counter on its count and last attempt, so it only changes while
+// nobody else has. A counter that did not exist is inserted, and a concurrent insert of the same
+// key fails on the duplicate _id.
+func (lr *loginAttemptMongoRepo) ReserveAttempt(seen models.AttemptCounter, now time.Time, window time.Duration) (bool, error) {
+	filter := bson.M{"_id": seen.Key, "count": seen.Count, "last_at": seen.LastAt}
+	result, err := lr.collection.UpdateOne(context.TODO(), filter, attemptUpdate(now, window), options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0 || result.UpsertedCount > 0, nil
+}
+
+func (lr *loginAttemptMongoRepo) ReleaseAttempt(key string) error {
+	filter := bson.M{"_id": key, "count": bson.M{"$gt": 0}}
+	_, err := lr.collection.UpdateOne(context.TODO(), filter, bson.M{"$inc": bson.M{"count": -1}})
+	return err
+}
+
+func (lr *loginAttemptMongoRepo) ClearAttempts(key string) error {
+	_, err := lr.collection.DeleteOne(context.TODO(), bson.M{"_id": key})
+	return err
+}
+
+// attemptUpdate counts one attempt at now, starting over at one when the last attempt is older
+// than the window
+func attemptUpdate(now time.Time, window time.Duration) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"count": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$last_at", now.Add(-window)}},
+				bson.M{"$add": bson.A{"$count", 1}},
+				1,
+			}},
+			"last_at":    now,
+			"expires_at": now.Add(window),
+		}}},
+	}
+}
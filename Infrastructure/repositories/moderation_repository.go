@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type moderationMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewModerationMongoRepo(col *mongo.Collection) *moderationMongoRepo {
+	return &moderationMongoRepo{collection: col}
+}
+
+// EnsureIndexes creates the indexes the review queue and re-queueing rely on. Creating an
+// identical index again is a no-op, so this is safe to call on every start.
+func (mr *moderationMongoRepo) EnsureIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("moderation_status_created"),
+		},
+		{
+			Keys:    bson.D{{Key: "content_type", Value: 1}, {Key: "content_id", Value: 1}},
+			Options: options.Index().SetName("moderation_content"),
+		},
+	}
+
+	_, err := mr.collection.Indexes().CreateMany(context.TODO(), indexes)
+	return err
+}
+
+// QueueItem adds held content to the queue. Content that is already waiting for review keeps its
+// place in the queue; its excerpt is refreshed and the new reasons are added to the old ones.
+func (mr *moderationMongoRepo) QueueItem(item models.ModerationItem) (models.ModerationItem, error) {
+	now := time.Now()
+	reasons := item.Reasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	filter := bson.M{
+		"content_type": item.ContentType,
+		"content_id":   item.ContentID,
+		"status":       models.ModerationStatusPending,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"blog_id":    item.BlogID,
+			"author_id":  item.AuthorID,
+			"title":      item.Title,
+			"excerpt":    item.Excerpt,
+			"publish":    item.Publish,
+			"updated_at": now,
+		},
+		"$addToSet": bson.M{"reasons": bson.M{"$each": reasons}},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"taken_down": item.TakenDown,
+			"created_at": now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var queued models.ModerationItem
+	err := mr.collection.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&queued)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	return queued, nil
+}
+
+// GetItemByID retrieves a queue item by its ID
+func (mr *moderationMongoRepo) GetItemByID(itemID string) (models.ModerationItem, error) {
+	objectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	var item models.ModerationItem
+	err = mr.collection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&item)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	return item, nil
+}
+
+// GetPendingItem retrieves the queue item of content that is waiting for review
+func (mr *moderationMongoRepo) GetPendingItem(contentType, contentID string) (models.ModerationItem, error) {
+	filter := bson.M{
+		"content_type": contentType,
+		"content_id":   contentID,
+		"status":       models.ModerationStatusPending,
+	}
+
+	var item models.ModerationItem
+	err := mr.collection.FindOne(context.TODO(), filter).Decode(&item)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	return item, nil
+}
+
+// GetItems retrieves a page of the queue, oldest first so nothing waits forever
+func (mr *moderationMongoRepo) GetItems(status string, page, limit int) ([]models.ModerationItem, error) {
+	skip := (page - 1) * limit
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := mr.collection.Find(context.TODO(), moderationFilter(status), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	items := []models.ModerationItem{}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CountItems counts the queue items in the given status
+func (mr *moderationMongoRepo) CountItems(status string) (int64, error) {
+	return mr.collection.CountDocuments(context.TODO(), moderationFilter(status))
+}
+
+// ResolveItem records a reviewer's decision on a pending queue item. The status is part of the
+// match, so of two admins reviewing the same item at once only the first one wins.
+func (mr *moderationMongoRepo) ResolveItem(itemID, status, reviewerID, note string) (models.ModerationItem, error) {
+	objectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{
+		"status":      status,
+		"reviewer_id": reviewerID,
+		"review_note": note,
+		"reviewed_at": now,
+		"updated_at":  now,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var item models.ModerationItem
+	filter := bson.M{"_id": objectID, "status": models.ModerationStatusPending}
+	err = mr.collection.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&item)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	return item, nil
+}
+
+func moderationFilter(status string) bson.M {
+	if status == "" {
+		return bson.M{}
+	}
+	return bson.M{"status": status}
+}
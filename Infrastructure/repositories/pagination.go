@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"errors"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// keysetFilter narrows filter to the items past the page cursor in a (sortField, _id) ordering
+// and returns the find options for that page. Ties on sortField are broken by _id, so items
+// inserted while a client is scrolling never shift the pages it has not fetched yet.
+// One extra item is fetched so keysetPage can tell whether another page follows.
+func keysetFilter(filter bson.M, sortField string, order int, page models.PageRequest) (bson.M, *options.FindOptions, error) {
+	if page.Cursor != nil && page.Cursor.Backward {
+		order = -order
+	}
+
+	opts := options.Find().
+		SetLimit(int64(page.Limit + 1)).
+		SetSort(bson.D{{Key: sortField, Value: order}, {Key: "_id", Value: order}})
+
+	if page.Cursor == nil {
+		return filter, opts, nil
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(page.Cursor.ID)
+	if err != nil {
+		return nil, nil, errors.New("invalid cursor")
+	}
+
+	// Score-ordered lists key on the score, everything else on creation time
+	var key interface{} = page.Cursor.CreatedAt
+	if sortField == "score" {
+		key = page.Cursor.Score
+	}
+
+	op := "$gt"
+	if order < 0 {
+		op = "$lt"
+	}
+
+	past := bson.M{"$or": []bson.M{
+		{sortField: bson.M{op: key}},
+		{sortField: key, "_id": bson.M{op: objectID}},
+	}}
+
+	return bson.M{"$and": []bson.M{filter, past}}, opts, nil
+}
+
+// keysetPage drops the look-ahead item and puts a backward page back in list order.
+// It reports whether more items exist beyond the page in the direction it was fetched.
+func keysetPage[T any](items []T, page models.PageRequest) ([]T, bool) {
+	hasMore := len(items) > page.Limit
+	if hasMore {
+		items = items[:page.Limit]
+	}
+
+	if page.Cursor != nil && page.Cursor.Backward {
+		slices.Reverse(items)
+	}
+
+	return items, hasMore
+}
@@ -6,9 +6,11 @@ import (
 	Database "blog-api/Infrastructure/database"
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type userRepository struct{}
@@ -136,3 +138,110 @@ func (r *userRepository) Verify(email string) error {
 	_, err := collection.UpdateOne(context.TODO(), filter, update)
 	return err
 }
+
+func (r *userRepository) AdjustFollowCounts(ctx context.Context, followerID, followeeID string, delta int) error {
+	collection := Database.GetCollection("users")
+	followerObjectID, err := primitive.ObjectIDFromHex(followerID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	followeeObjectID, err := primitive.ObjectIDFromHex(followeeID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": followerObjectID}, bson.M{"$inc": bson.M{"following_count": delta}})
+	if err != nil {
+		return err
+	}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": followeeObjectID}, bson.M{"$inc": bson.M{"followers_count": delta}})
+	return err
+}
+
+func (r *userRepository) AddWarning(ctx context.Context, id string) error {
+	collection := Database.GetCollection("users")
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$inc": bson.M{"warnings": 1}})
+	return err
+}
+
+func (r *userRepository) Suspend(ctx context.Context, id string, until time.Time, reason string) error {
+	collection := Database.GetCollection("users")
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	update := bson.M{"$set": bson.M{"suspended_until": until, "suspension_reason": reason}}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id string) (int, error) {
+	collection := Database.GetCollection("users")
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"token_version": 1})
+	var user models.User
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$inc": bson.M{"token_version": 1}}, opts).Decode(&user)
+	if err != nil {
+		return 0, err
+	}
+	return user.TokenVersion, nil
+}
+
+func (r *userRepository) SetMFASecret(ctx context.Context, id, secret string) error {
+	return r.updateByID(ctx, id, bson.M{"$set": bson.M{"mfa_secret": secret}})
+}
+
+func (r *userRepository) EnableMFA(ctx context.Context, id, recoverySalt string, recoveryCodeHashes []string) error {
+	return r.updateByID(ctx, id, bson.M{"$set": bson.M{"mfa_enabled": true, "mfa_recovery_salt": recoverySalt, "mfa_recovery_codes": recoveryCodeHashes}})
+}
+
+func (r *userRepository) DisableMFA(ctx context.Context, id string) error {
+	return r.updateByID(ctx, id, bson.M{"$unset": bson.M{"mfa_enabled": "", "mfa_secret": "", "mfa_recovery_codes": "", "mfa_recovery_salt": "", "mfa_last_step": ""}})
+}
+
+func (r *userRepository) ClaimMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	collection := Database.GetCollection("users")
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid user ID")
+	}
+	filter := bson.M{"_id": objectID, "$or": bson.A{
+		bson.M{"mfa_last_step": bson.M{"$exists": false}},
+		bson.M{"mfa_last_step": bson.M{"$lt": step}},
+	}}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"mfa_last_step": step}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *userRepository) UseRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	collection := Database.GetCollection("users")
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid user ID")
+	}
+	filter := bson.M{"_id": objectID, "mfa_recovery_codes": codeHash}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"mfa_recovery_codes": codeHash}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *userRepository) updateByID(ctx context.Context, id string, update bson.M) error {
+	collection := Database.GetCollection("users")
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type reactionMongoRepo struct {
+	reactionsCollection *mongo.Collection
+	blogsCollection     *mongo.Collection
+}
+
+func NewReactionMongoRepo(database *mongo.Database) *reactionMongoRepo {
+	return &reactionMongoRepo{
+		reactionsCollection: database.Collection("reactions"),
+		blogsCollection:     database.Collection("blogs"),
+	}
+}
+
+// EnsureIndexes indexes the ledger by blog, so a deleted blog's reactions can be removed
+func (rr *reactionMongoRepo) EnsureIndexes() error {
+	_, err := rr.reactionsCollection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "blog_id", Value: 1}},
+		Options: options.Index().SetName("reaction_blog"),
+	})
+	return err
+}
+
+// reactionAttempts bounds how often SetReaction re-reads a ledger entry another request changed under it
+const reactionAttempts = 3
+
+// SetReaction records a like or dislike, then adjusts the blog counters. It needs no transaction:
+// the ledger entry only moves from the state that was read, so each change is counted once even
+// when the same user reacts from two requests at the same time.
+func (rr *reactionMongoRepo) SetReaction(userID, blogID, reactionType string) (models.Reaction, error) {
+	blogObjectID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return models.Reaction{}, err
+	}
+
+	count, err := rr.blogsCollection.CountDocuments(context.TODO(), bson.M{"_id": blogObjectID})
+	if err != nil {
+		return models.Reaction{}, err
+	}
+	if count == 0 {
+		return models.Reaction{}, models.ErrBlogNotFound
+	}
+
+	id := reactionID(userID, blogID)
+	for attempt := 0; attempt < reactionAttempts; attempt++ {
+		var existing models.Reaction
+		err := rr.reactionsCollection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&existing)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return models.Reaction{}, err
+		}
+		found := err == nil
+
+		// Same reaction again is a no-op
+		if found && existing.Type == reactionType {
+			return existing, nil
+		}
+
+		now := time.Now()
+		reaction := models.Reaction{
+			ID:        id,
+			UserID:    userID,
+			BlogID:    blogID,
+			Type:      reactionType,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		inc := bson.M{reactionCounterField(reactionType): 1}
+		if found {
+			// Switching replaces the opposite reaction, unless it changed since it was read
+			reaction.CreatedAt = existing.CreatedAt
+			res, err := rr.reactionsCollection.UpdateOne(context.TODO(),
+				bson.M{"_id": id, "type": existing.Type},
+				bson.M{"$set": bson.M{"type": reactionType, "updated_at": now}})
+			if err != nil {
+				return models.Reaction{}, err
+			}
+			if res.ModifiedCount == 0 {
+				continue
+			}
+			inc[reactionCounterField(existing.Type)] = -1
+		} else {
+			_, err := rr.reactionsCollection.InsertOne(context.TODO(), reaction)
+			if mongo.IsDuplicateKeyError(err) {
+				// Another request reacted first
+				continue
+			}
+			if err != nil {
+				return models.Reaction{}, err
+			}
+		}
+
+		if _, err := rr.blogsCollection.UpdateOne(context.TODO(), bson.M{"_id": blogObjectID}, bson.M{"$inc": inc}); err != nil {
+			return models.Reaction{}, err
+		}
+
+		return reaction, nil
+	}
+
+	return models.Reaction{}, errors.New("reaction changed concurrently, please retry")
+}
+
+// RemoveReaction deletes the user's reaction if it matches and decrements the blog counter. Only
+// the request that deletes the entry decrements, and never below zero.
+func (rr *reactionMongoRepo) RemoveReaction(userID, blogID, reactionType string) error {
+	blogObjectID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return err
+	}
+
+	res, err := rr.reactionsCollection.DeleteOne(context.TODO(), bson.M{
+		"_id":  reactionID(userID, blogID),
+		"type": reactionType,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Nothing to undo
+	if res.DeletedCount == 0 {
+		return nil
+	}
+
+	field := reactionCounterField(reactionType)
+	_, err = rr.blogsCollection.UpdateOne(context.TODO(),
+		bson.M{"_id": blogObjectID, field: bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{field: -1}})
+	return err
+}
+
+// GetReaction retrieves the user's current reaction on a blog
+func (rr *reactionMongoRepo) GetReaction(userID, blogID string) (*models.Reaction, error) {
+	var reaction models.Reaction
+	err := rr.reactionsCollection.FindOne(context.TODO(), bson.M{"_id": reactionID(userID, blogID)}).Decode(&reaction)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &reaction, nil
+}
+
+// DeleteBlogReactions removes every reaction recorded on a blog
+func (rr *reactionMongoRepo) DeleteBlogReactions(blogID string) error {
+	_, err := rr.reactionsCollection.DeleteMany(context.TODO(), bson.M{"blog_id": blogID})
+	return err
+}
+
+// reactionID keys the ledger by (user, blog) so each user holds at most one reaction per blog
+func reactionID(userID, blogID string) string {
+	return userID + ":" + blogID
+}
+
+func reactionCounterField(reactionType string) string {
+	if reactionType == models.ReactionDislike {
+		return "dislikes"
+	}
+	return "likes"
+}
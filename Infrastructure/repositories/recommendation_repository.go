@@ -187,6 +187,49 @@ func (r *recommendationMongoRepo) GetUserRecommendations(userID string, limit in
 	return recommendations, nil
 }
 
+// GetUserRecommendationsByCursor retrieves the page of live recommendations next to the cursor, best score first
+func (r *recommendationMongoRepo) GetUserRecommendationsByCursor(userID string, category string, page models.PageRequest) ([]models.UserRecommendation, bool, error) {
+	filter, opts, err := keysetFilter(userRecommendationFilter(userID, category), "score", -1, page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cursor, err := r.recommendationsCollection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var recommendations []models.UserRecommendation
+	if err = cursor.All(context.TODO(), &recommendations); err != nil {
+		return nil, false, err
+	}
+
+	recommendations, hasMore := keysetPage(recommendations, page)
+	return recommendations, hasMore, nil
+}
+
+// CountUserRecommendations counts a user's live recommendations in a category
+func (r *recommendationMongoRepo) CountUserRecommendations(userID string, category string) (int64, error) {
+	return r.recommendationsCollection.CountDocuments(context.TODO(), userRecommendationFilter(userID, category))
+}
+
+// DeleteUserRecommendations drops a user's stored recommendations before a new batch is generated
+func (r *recommendationMongoRepo) DeleteUserRecommendations(userID string) error {
+	_, err := r.recommendationsCollection.DeleteMany(context.TODO(), bson.M{"user_id": userID})
+	return err
+}
+
+func userRecommendationFilter(userID string, category string) bson.M {
+	filter := bson.M{"user_id": userID, "expires_at": bson.M{"$gt": time.Now()}}
+
+	if category != "" && category != models.CategoryAll {
+		filter["category"] = category
+	}
+
+	return filter
+}
+
 func (r *recommendationMongoRepo) UpdateRecommendationViewed(recommendationID string) error {
 	objectID, err := primitive.ObjectIDFromHex(recommendationID)
 	if err != nil {
@@ -281,6 +324,13 @@ func (r *recommendationMongoRepo) GetTopUserInterests(userID string, limit int)
 	return interests[:limit], nil
 }
 
+// DeleteUserInterests drops the given topics from a user's interest profile
+func (r *recommendationMongoRepo) DeleteUserInterests(userID string, topics []string) error {
+	filter := bson.M{"user_id": userID, "topic": bson.M{"$in": topics}}
+	_, err := r.interestsCollection.DeleteMany(context.TODO(), filter)
+	return err
+}
+
 // Content Analysis
 
 func (r *recommendationMongoRepo) GetPopularTags(limit int) ([]string, error) {
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type reportMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewReportMongoRepo(col *mongo.Collection) *reportMongoRepo {
+	return &reportMongoRepo{collection: col}
+}
+
+// EnsureIndexes creates the indexes report listing and counting rely on. The unique reporter index
+// is what keeps a reader to one report per post or comment.
+func (rr *reportMongoRepo) EnsureIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "content_type", Value: 1},
+				{Key: "content_id", Value: 1},
+				{Key: "reporter_id", Value: 1},
+			},
+			Options: options.Index().SetName("report_content_reporter").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("report_status_created"),
+		},
+	}
+
+	_, err := rr.collection.Indexes().CreateMany(context.TODO(), indexes)
+	return err
+}
+
+// CreateReport files a report. The unique reporter index turns a second report of the same
+// content by the same reader into an error, even when both arrive at once.
+func (rr *reportMongoRepo) CreateReport(report models.Report) (models.Report, error) {
+	objectID := primitive.NewObjectID()
+	report.ID = objectID.Hex()
+
+	doc := bson.M{
+		"_id":          objectID,
+		"content_type": report.ContentType,
+		"content_id":   report.ContentID,
+		"blog_id":      report.BlogID,
+		"author_id":    report.AuthorID,
+		"reporter_id":  report.ReporterID,
+		"reason":       report.Reason,
+		"status":       report.Status,
+		"created_at":   report.CreatedAt,
+	}
+	if report.Details != "" {
+		doc["details"] = report.Details
+	}
+
+	_, err := rr.collection.InsertOne(context.TODO(), doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Report{}, errors.New("you have already reported this")
+	}
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	return report, nil
+}
+
+// GetReportByID retrieves a report by its ID
+func (rr *reportMongoRepo) GetReportByID(reportID string) (models.Report, error) {
+	objectID, err := primitive.ObjectIDFromHex(reportID)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	var report models.Report
+	err = rr.collection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&report)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	return report, nil
+}
+
+// GetReports retrieves a page of reports, oldest first so nothing waits forever
+func (rr *reportMongoRepo) GetReports(status string, page, limit int) ([]models.Report, error) {
+	skip := (page - 1) * limit
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(skip)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := rr.collection.Find(context.TODO(), reportFilter(status), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	reports := []models.Report{}
+	if err = cursor.All(context.TODO(), &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// CountReports counts the reports in the given status
+func (rr *reportMongoRepo) CountReports(status string) (int64, error) {
+	return rr.collection.CountDocuments(context.TODO(), reportFilter(status))
+}
+
+// CountOpenReports counts the open reports of one post or comment
+func (rr *reportMongoRepo) CountOpenReports(contentType, contentID string) (int64, error) {
+	filter := bson.M{
+		"content_type": contentType,
+		"content_id":   contentID,
+		"status":       models.ReportStatusOpen,
+	}
+	return rr.collection.CountDocuments(context.TODO(), filter)
+}
+
+// ResolveReports closes the open reports of one post or comment. Only open reports match, so of
+// two admins resolving the same content at once the second one closes nothing.
+func (rr *reportMongoRepo) ResolveReports(contentType, contentID, resolverID, action, note string) (int64, error) {
+	filter := bson.M{
+		"content_type": contentType,
+		"content_id":   contentID,
+		"status":       models.ReportStatusOpen,
+	}
+	fields := bson.M{
+		"status":      models.ReportStatusResolved,
+		"action":      action,
+		"resolver_id": resolverID,
+		"resolved_at": time.Now(),
+	}
+	if note != "" {
+		fields["note"] = note
+	}
+
+	result, err := rr.collection.UpdateMany(context.TODO(), filter, bson.M{"$set": fields})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func reportFilter(status string) bson.M {
+	if status == "" {
+		return bson.M{}
+	}
+	return bson.M{"status": status}
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revisionMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewRevisionMongoRepo(col *mongo.Collection) *revisionMongoRepo {
+	return &revisionMongoRepo{collection: col}
+}
+
+// CreateRevision archives a blog version. The (blog, version) key makes each version write-once.
+func (rr *revisionMongoRepo) CreateRevision(revision models.BlogRevision) (models.BlogRevision, error) {
+	revision.ID = revisionID(revision.BlogID, revision.Version)
+
+	_, err := rr.collection.InsertOne(context.TODO(), revision)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.BlogRevision{}, models.ErrBlogModified
+	}
+	if err != nil {
+		return models.BlogRevision{}, err
+	}
+
+	return revision, nil
+}
+
+// GetRevisions retrieves all archived versions of a blog, newest first
+func (rr *revisionMongoRepo) GetRevisions(blogID string) ([]models.BlogRevision, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := rr.collection.Find(context.TODO(), bson.M{"blog_id": blogID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var revisions []models.BlogRevision
+	if err = cursor.All(context.TODO(), &revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetRevision retrieves a single archived version
+func (rr *revisionMongoRepo) GetRevision(blogID string, version int) (models.BlogRevision, error) {
+	var revision models.BlogRevision
+	err := rr.collection.FindOne(context.TODO(), bson.M{"_id": revisionID(blogID, version)}).Decode(&revision)
+	if err != nil {
+		return models.BlogRevision{}, err
+	}
+
+	return revision, nil
+}
+
+// DeleteRevision removes an archived version
+func (rr *revisionMongoRepo) DeleteRevision(blogID string, version int) error {
+	_, err := rr.collection.DeleteOne(context.TODO(), bson.M{"_id": revisionID(blogID, version)})
+	return err
+}
+
+func revisionID(blogID string, version int) string {
+	return blogID + ":" + strconv.Itoa(version)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type slugMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewSlugMongoRepo(col *mongo.Collection) *slugMongoRepo {
+	return &slugMongoRepo{collection: col}
+}
+
+// ReserveSlug inserts the slug keyed by itself, so the first blog to claim it wins
+func (sr *slugMongoRepo) ReserveSlug(slug, blogID string) (bool, error) {
+	record := models.BlogSlug{
+		Slug:      slug,
+		BlogID:    blogID,
+		CreatedAt: time.Now(),
+	}
+
+	_, err := sr.collection.InsertOne(context.TODO(), record)
+	if err == nil {
+		return true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	// A blog may take back a slug it used before
+	existing, err := sr.GetSlug(slug)
+	if err != nil {
+		return false, err
+	}
+	return existing != nil && existing.BlogID == blogID, nil
+}
+
+func (sr *slugMongoRepo) ReleaseSlug(slug, blogID string) error {
+	_, err := sr.collection.DeleteOne(context.TODO(), bson.M{"_id": slug, "blog_id": blogID})
+	return err
+}
+
+func (sr *slugMongoRepo) ReleaseBlogSlugs(blogID string) error {
+	_, err := sr.collection.DeleteMany(context.TODO(), bson.M{"blog_id": blogID})
+	return err
+}
+
+// GetSlug retrieves the reservation for a slug
+func (sr *slugMongoRepo) GetSlug(slug string) (*models.BlogSlug, error) {
+	var record models.BlogSlug
+	err := sr.collection.FindOne(context.TODO(), bson.M{"_id": slug}).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type tagMongoRepo struct {
+	collection *mongo.Collection
+}
+
+func NewTagMongoRepo(col *mongo.Collection) *tagMongoRepo {
+	return &tagMongoRepo{collection: col}
+}
+
+// EnsureIndexes indexes aliases, which every blog write looks tags up by. Creating an identical
+// index again is a no-op, so this is safe to call on every start.
+func (tr *tagMongoRepo) EnsureIndexes() error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "aliases", Value: 1}},
+		Options: options.Index().SetName("tags_aliases"),
+	}
+
+	_, err := tr.collection.Indexes().CreateOne(context.TODO(), index)
+	return err
+}
+
+// GetTags lists the vocabulary alphabetically
+func (tr *tagMongoRepo) GetTags() ([]models.Tag, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := tr.collection.Find(context.TODO(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	tags := []models.Tag{}
+	if err = cursor.All(context.TODO(), &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// FindTags retrieves the tags whose name or one of whose aliases is among names
+func (tr *tagMongoRepo) FindTags(names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	filter := bson.M{"$or": []bson.M{
+		{"_id": bson.M{"$in": names}},
+		{"aliases": bson.M{"$in": names}},
+	}}
+	cursor, err := tr.collection.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	tags := []models.Tag{}
+	if err = cursor.All(context.TODO(), &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// MergeTags moves the sources and their aliases onto target, creating it if needed. Sources are
+// taken off any other tag, so an alias only ever resolves to one canonical tag. The target takes
+// the aliases before anything is removed, so a failure part way loses nothing and running the
+// merge again finishes it.
+func (tr *tagMongoRepo) MergeTags(sources []string, target string) (models.Tag, error) {
+	aliases := append([]string{}, sources...)
+
+	cursor, err := tr.collection.Find(context.TODO(), bson.M{"_id": bson.M{"$in": sources}})
+	if err != nil {
+		return models.Tag{}, err
+	}
+	var merged []models.Tag
+	if err = cursor.All(context.TODO(), &merged); err != nil {
+		return models.Tag{}, err
+	}
+	for _, tag := range merged {
+		for _, alias := range tag.Aliases {
+			if alias != target {
+				aliases = append(aliases, alias)
+			}
+		}
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$addToSet":    bson.M{"aliases": bson.M{"$each": aliases}},
+		"$set":         bson.M{"updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var tag models.Tag
+	err = tr.collection.FindOneAndUpdate(context.TODO(), bson.M{"_id": target}, update, opts).Decode(&tag)
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	_, err = tr.collection.UpdateMany(context.TODO(),
+		bson.M{"_id": bson.M{"$ne": target}, "aliases": bson.M{"$in": aliases}},
+		bson.M{"$pull": bson.M{"aliases": bson.M{"$in": aliases}}})
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	if _, err = tr.collection.DeleteMany(context.TODO(), bson.M{"_id": bson.M{"$in": sources}}); err != nil {
+		return models.Tag{}, err
+	}
+
+	return tag, nil
+}
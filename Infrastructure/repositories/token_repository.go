@@ -4,9 +4,11 @@ import (
 	"blog-api/Domain/models"
 	"blog-api/Infrastructure/db_models"
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type tokenMongoRepo struct {
@@ -17,6 +19,28 @@ func NewTokenMongoRepo(col *mongo.Collection) *tokenMongoRepo {
 	return &tokenMongoRepo{collection: col}
 }
 
+// EnsureIndexes creates the family and user indexes used to list and revoke sessions, and a TTL index
+// that lets Mongo remove tokens once they expire, rotated refresh tokens included
+func (tr *tokenMongoRepo) EnsureIndexes() error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "family_id", Value: 1}},
+			Options: options.Index().SetName("token_family").SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("token_user"),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("token_expiry").SetExpireAfterSeconds(0),
+		},
+	}
+
+	_, err := tr.collection.Indexes().CreateMany(context.TODO(), indexes)
+	return err
+}
+
 func (tr *tokenMongoRepo) CreateToken(token *models.Token) error {
 	db_token := db_models.FromDomainToken(token)
 	_, err := tr.collection.InsertOne(context.TODO(), db_token)
@@ -46,3 +70,70 @@ func (tr *tokenMongoRepo) GetToken(tokenID string) (*models.Token, error) {
 	domainToken := db_models.ToDomainToken(&token)
 	return domainToken, nil
 }
+
+// RotateToken marks the token exchanged unless it already was. The filter makes this atomic, so
+// only one of two concurrent refreshes with the same token sees true.
+func (tr *tokenMongoRepo) RotateToken(tokenID string) (bool, error) {
+	filter := bson.M{"_id": tokenID, "rotated_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"rotated_at": time.Now()}}
+
+	result, err := tr.collection.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// DeleteTokenFamily deletes every token of the family. The token that started the family is
+// matched by its ID too, as tokens issued before rotation have no family_id.
+func (tr *tokenMongoRepo) DeleteTokenFamily(familyID string) error {
+	filter := bson.M{"$or": bson.A{bson.M{"family_id": familyID}, bson.M{"_id": familyID}}}
+	_, err := tr.collection.DeleteMany(context.TODO(), filter)
+	return err
+}
+
+// GetActiveTokens lists the user's live refresh tokens, most recently used first. Only refresh
+// tokens carry a user ID, so verification and reset tokens never show up.
+func (tr *tokenMongoRepo) GetActiveTokens(userID string) ([]*models.Token, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"rotated_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}})
+
+	cursor, err := tr.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var dbTokens []db_models.Token
+	if err = cursor.All(context.TODO(), &dbTokens); err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*models.Token, len(dbTokens))
+	for i := range dbTokens {
+		tokens[i] = db_models.ToDomainToken(&dbTokens[i])
+	}
+
+	return tokens, nil
+}
+
+// DeleteUserTokens deletes the user's refresh tokens, keeping the given family if there is one
+func (tr *tokenMongoRepo) DeleteUserTokens(userID, keepFamilyID string) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	if keepFamilyID != "" {
+		filter["family_id"] = bson.M{"$ne": keepFamilyID}
+		filter["_id"] = bson.M{"$ne": keepFamilyID}
+	}
+
+	result, err := tr.collection.DeleteMany(context.TODO(), filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
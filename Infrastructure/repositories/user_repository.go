@@ -5,10 +5,12 @@ import (
 	"blog-api/Infrastructure/db_models"
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type userMongoRepo struct {
@@ -111,3 +113,125 @@ func (ur *userMongoRepo) UpdateUserProfile(ctx context.Context, id string, updat
 
 	return ur.GetUserByID(ctx, id)
 }
+
+// AdjustFollowCounts moves the follower's following count and the followee's followers count by delta
+func (ur *userMongoRepo) AdjustFollowCounts(ctx context.Context, followerID, followeeID string, delta int) error {
+	followerObjectID, err := primitive.ObjectIDFromHex(followerID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	followeeObjectID, err := primitive.ObjectIDFromHex(followeeID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	_, err = ur.collection.UpdateOne(ctx, bson.M{"_id": followerObjectID}, bson.M{"$inc": bson.M{"following_count": delta}})
+	if err != nil {
+		return err
+	}
+
+	_, err = ur.collection.UpdateOne(ctx, bson.M{"_id": followeeObjectID}, bson.M{"$inc": bson.M{"followers_count": delta}})
+	return err
+}
+
+// AddWarning counts a warning an admin gave the user
+func (ur *userMongoRepo) AddWarning(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	_, err = ur.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$inc": bson.M{"warnings": 1}})
+	return err
+}
+
+// Suspend locks the user out until the given time, replacing any earlier suspension
+func (ur *userMongoRepo) Suspend(ctx context.Context, id string, until time.Time, reason string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	update := bson.M{"$set": bson.M{"suspended_until": until, "suspension_reason": reason}}
+	_, err = ur.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+// IncrementTokenVersion bumps the user's token version atomically and returns the new one
+func (ur *userMongoRepo) IncrementTokenVersion(ctx context.Context, id string) (int, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return 0, errors.New("invalid user ID")
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"token_version": 1})
+
+	var user db_models.UserModel
+	err = ur.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$inc": bson.M{"token_version": 1}}, opts).Decode(&user)
+	if err != nil {
+		return 0, err
+	}
+
+	return user.TokenVersion, nil
+}
+
+func (ur *userMongoRepo) SetMFASecret(ctx context.Context, id, secret string) error {
+	return ur.updateByID(ctx, id, bson.M{"$set": bson.M{"mfa_secret": secret}})
+}
+
+func (ur *userMongoRepo) EnableMFA(ctx context.Context, id, recoverySalt string, recoveryCodeHashes []string) error {
+	return ur.updateByID(ctx, id, bson.M{"$set": bson.M{"mfa_enabled": true, "mfa_recovery_salt": recoverySalt, "mfa_recovery_codes": recoveryCodeHashes}})
+}
+
+func (ur *userMongoRepo) DisableMFA(ctx context.Context, id string) error {
+	update := bson.M{"$unset": bson.M{"mfa_enabled": "", "mfa_secret": "", "mfa_recovery_codes": "", "mfa_recovery_salt": "", "mfa_last_step": ""}}
+	return ur.updateByID(ctx, id, update)
+}
+
+// ClaimMFAStep only matches while the stored step is older, so of two logins with the same code
+// only one gets through
+func (ur *userMongoRepo) ClaimMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid user ID")
+	}
+
+	filter := bson.M{"_id": objectID, "$or": bson.A{
+		bson.M{"mfa_last_step": bson.M{"$exists": false}},
+		bson.M{"mfa_last_step": bson.M{"$lt": step}},
+	}}
+	result, err := ur.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"mfa_last_step": step}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// UseRecoveryCode pulls the code in the same update that finds it, so each code works once
+func (ur *userMongoRepo) UseRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid user ID")
+	}
+
+	filter := bson.M{"_id": objectID, "mfa_recovery_codes": codeHash}
+	result, err := ur.collection.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"mfa_recovery_codes": codeHash}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (ur *userMongoRepo) updateByID(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	_, err = ur.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
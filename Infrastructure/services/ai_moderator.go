@@ -0,0 +1,71 @@
+package services
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"fmt"
+	"log"
+)
+
+// AIModerator asks the AI backend to classify content
+type AIModerator struct {
+	provider interfaces.AIProvider
+}
+
+func NewAIModerator(provider interfaces.AIProvider) *AIModerator {
+	return &AIModerator{provider: provider}
+}
+
+// Moderate sends content for review when the model's verdict is not one it knows
+func (m *AIModerator) Moderate(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error) {
+	generation, err := m.provider.Generate(ctx, models.AIGenerationRequest{
+		Type:    models.AISuggestionTypeModeration,
+		Title:   content.Title,
+		Content: content.Body,
+	})
+	if err != nil {
+		return models.ModerationVerdict{}, fmt.Errorf("AI moderation failed: %w", err)
+	}
+
+	switch generation.Verdict {
+	case models.ModerationAllow:
+		return models.ModerationVerdict{Decision: models.ModerationAllow}, nil
+	case models.ModerationReview, models.ModerationReject:
+		verdict := models.ModerationVerdict{Decision: generation.Verdict}
+		for _, reason := range generation.Reasons {
+			verdict.Reasons = append(verdict.Reasons, "AI: "+reason)
+		}
+		return verdict, nil
+	}
+	return review("AI moderator gave no clear verdict"), nil
+}
+
+// ChainModerator runs moderators in order and keeps the strictest verdict. A moderator that
+// fails is skipped, so an AI outage does not block posting; once content is rejected the
+// remaining moderators are not asked.
+type ChainModerator struct {
+	moderators []interfaces.Moderator
+}
+
+func NewChainModerator(moderators ...interfaces.Moderator) *ChainModerator {
+	return &ChainModerator{moderators: moderators}
+}
+
+func (m *ChainModerator) Moderate(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error) {
+	verdict := models.ModerationVerdict{Decision: models.ModerationAllow}
+
+	for _, moderator := range m.moderators {
+		next, err := moderator.Moderate(ctx, content)
+		if err != nil {
+			log.Printf("Moderation of %s %s skipped a check: %v", content.Type, content.ID, err)
+			continue
+		}
+		verdict = verdict.Merge(next)
+		if verdict.Decision == models.ModerationReject {
+			break
+		}
+	}
+
+	return verdict, nil
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxPromptContent caps how much of a post is sent to the model
+const maxPromptContent = 12000
+
+// promptTemplate is the prompt for one kind of suggestion: format describes the answer layout
+// the parser expects, task states what to write
+type promptTemplate struct {
+	format string
+	task   func(req models.AIGenerationRequest) string
+}
+
+var promptTemplates = map[string]promptTemplate{
+	models.AISuggestionTypeIdeas: {
+		format: `Answer in exactly this format:
+Blog Post Idea: <title>
+Target Audience: <audience>
+* <section headline>
+* <section headline>
+* <section headline>`,
+		task: func(req models.AIGenerationRequest) string {
+			task := fmt.Sprintf("Write a %s blog post idea using these keywords: %s", req.Tone, strings.Join(req.Keywords, ", "))
+			if req.Content != "" {
+				task += "\n\nBuild on this draft:\n" + promptContent(req.Content)
+			}
+			return task
+		},
+	},
+	models.AISuggestionTypeImprovement: {
+		format: `Answer with one suggestion per line, each starting with "* ". Quote the original sentence and give the rewritten version.`,
+		task: func(req models.AIGenerationRequest) string {
+			task := fmt.Sprintf("Suggest up to 5 concrete rewrites that make this blog post clearer and more %s.", req.Tone)
+			if len(req.Keywords) > 0 {
+				task += " Work in these keywords where they fit: " + strings.Join(req.Keywords, ", ")
+			}
+			return task + "\n\nPost:\n" + promptContent(req.Content)
+		},
+	},
+	models.AISuggestionTypeTitle: {
+		format: `Answer with one title per line, each starting with "* ". No numbering or commentary.`,
+		task: func(req models.AIGenerationRequest) string {
+			task := fmt.Sprintf("Suggest 5 %s titles for this blog post.", req.Tone)
+			if len(req.Keywords) > 0 {
+				task += " Prefer these keywords: " + strings.Join(req.Keywords, ", ")
+			}
+			return task + "\n\nPost:\n" + promptContent(req.Content)
+		},
+	},
+	models.AISuggestionTypeSummary: {
+		format: `Answer in exactly this format:
+TL;DR: <summary of at most 3 sentences>`,
+		task: func(req models.AIGenerationRequest) string {
+			return fmt.Sprintf("Write a %s TL;DR of this blog post for readers deciding whether to read it.\n\nPost:\n%s",
+				req.Tone, promptContent(req.Content))
+		},
+	},
+	models.AISuggestionTypeDraft: {
+		format: `Answer in exactly this format, using markdown inside the paragraphs:
+Introduction: <one paragraph>
+## <section headline>
+<one to three paragraphs>
+## <section headline>
+<one to three paragraphs>
+Conclusion: <one paragraph>`,
+		task: func(req models.AIGenerationRequest) string {
+			task := fmt.Sprintf("Write a %s blog post titled %q.", req.Tone, req.Title)
+			if req.Audience != "" {
+				task += " It is written for " + req.Audience + "."
+			}
+			if len(req.Headlines) > 0 {
+				task += " Write one section per headline, in this order, using the headline as its heading:\n- " + strings.Join(req.Headlines, "\n- ")
+			}
+			if len(req.Keywords) > 0 {
+				task += "\n\nWork in these keywords: " + strings.Join(req.Keywords, ", ")
+			}
+			return task
+		},
+	},
+	models.AISuggestionTypeTags: {
+		format: `Answer with one tag per line, each starting with "* ". Tags are lower-case, one to three words, without "#".`,
+		task: func(req models.AIGenerationRequest) string {
+			task := fmt.Sprintf("Suggest up to 5 tags for this blog post titled %q.", req.Title)
+			if len(req.Keywords) > 0 {
+				task += " Prefer these existing tags where they fit: " + strings.Join(req.Keywords, ", ")
+			}
+			return task + "\n\nPost:\n" + promptContent(req.Content)
+		},
+	},
+	models.AISuggestionTypeModeration: {
+		format: `Answer in exactly this format, with one line per reason and no reasons when allowing:
+Verdict: <allow|review|reject>
+* <reason>`,
+		task: func(req models.AIGenerationRequest) string {
+			task := "Decide whether this text can be published on a community blog. Reject spam, scams, hate speech and harassment, answer review when unsure and allow everything else."
+			if req.Title != "" {
+				task += fmt.Sprintf("\n\nTitle: %s", req.Title)
+			}
+			return task + "\n\nText:\n" + promptContent(req.Content)
+		},
+	},
+}
+
+// promptTemplateFor falls back to the ideas prompt for an unknown type
+func promptTemplateFor(suggestionType string) promptTemplate {
+	if template, ok := promptTemplates[suggestionType]; ok {
+		return template
+	}
+	return promptTemplates[models.AISuggestionTypeIdeas]
+}
+
+// buildPrompt joins the format and task into a single prompt for backends without a system message
+func buildPrompt(req models.AIGenerationRequest) string {
+	template := promptTemplateFor(req.Type)
+	return template.format + "\n\n" + template.task(req)
+}
+
+// withUsage sets the usage the backend reported, estimating any count it left out
+func withUsage(generation models.AIGeneration, usage models.AITokenUsage, prompt, answer string) models.AIGeneration {
+	if usage.PromptTokens == 0 {
+		usage.PromptTokens = estimateTokens(prompt)
+	}
+	if usage.CompletionTokens == 0 {
+		usage.CompletionTokens = estimateTokens(answer)
+	}
+	generation.Usage = usage
+	return generation
+}
+
+// estimateTokens uses the rule of thumb of about four characters per token
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+func promptContent(content string) string {
+	runes := []rune(content)
+	if len(runes) > maxPromptContent {
+		return string(runes[:maxPromptContent]) + "…"
+	}
+	return content
+}
+
+// parseGeneration reads a model answer written in the layout of the type's template,
+// tolerating markdown emphasis around labels
+func parseGeneration(suggestionType string, text string) models.AIGeneration {
+	if _, ok := promptTemplates[suggestionType]; !ok {
+		suggestionType = models.AISuggestionTypeIdeas
+	}
+
+	if suggestionType == models.AISuggestionTypeDraft {
+		return parseDraft(text)
+	}
+
+	generation := models.AIGeneration{Type: suggestionType}
+	var bullets []string
+	var plain []string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+		case strings.Contains(line, "Blog Post Idea:"):
+			generation.Title = cleanLabelValue(line, "Blog Post Idea:")
+		case strings.Contains(line, "Target Audience:"):
+			generation.Audience = cleanLabelValue(line, "Target Audience:")
+		case strings.Contains(line, "TL;DR:"):
+			generation.Summary = cleanLabelValue(line, "TL;DR:")
+		case strings.Contains(line, "Verdict:"):
+			generation.Verdict = strings.ToLower(cleanLabelValue(line, "Verdict:"))
+		case strings.HasPrefix(line, "* "), strings.HasPrefix(line, "- "):
+			bullets = append(bullets, strings.TrimSpace(line[2:]))
+		default:
+			plain = append(plain, line)
+		}
+	}
+
+	switch suggestionType {
+	case models.AISuggestionTypeIdeas:
+		generation.Headlines = bullets
+	case models.AISuggestionTypeImprovement:
+		generation.Improvements = bullets
+	case models.AISuggestionTypeTitle:
+		generation.Titles = bullets
+	case models.AISuggestionTypeTags:
+		generation.Tags = bullets
+	case models.AISuggestionTypeModeration:
+		generation.Reasons = bullets
+	case models.AISuggestionTypeSummary:
+		// Models sometimes drop the label; take the prose as the summary then
+		if generation.Summary == "" {
+			generation.Summary = strings.Join(plain, " ")
+		}
+	}
+
+	return generation
+}
+
+// parseDraft splits a draft answer at its "Introduction:", "## " and "Conclusion:" markers,
+// keeping the lines in between as they are
+func parseDraft(text string) models.AIGeneration {
+	generation := models.AIGeneration{Type: models.AISuggestionTypeDraft}
+	var target *string
+	var lines []string
+
+	flush := func() {
+		if target != nil {
+			*target = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		lines = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		label := strings.TrimLeft(trimmed, "*")
+
+		switch {
+		case strings.HasPrefix(label, "Introduction:"):
+			flush()
+			target = &generation.Intro
+			lines = append(lines, cleanLabelValue(trimmed, "Introduction:"))
+		case strings.HasPrefix(label, "Conclusion:"):
+			flush()
+			target = &generation.Conclusion
+			lines = append(lines, cleanLabelValue(trimmed, "Conclusion:"))
+		case strings.HasPrefix(trimmed, "## "):
+			flush()
+			generation.Sections = append(generation.Sections, models.AIDraftSection{Heading: strings.TrimSpace(trimmed[3:])})
+			target = &generation.Sections[len(generation.Sections)-1].Body
+		default:
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	return generation
+}
+
+// formatGeneration renders a generation in the layout of its type's template, so that
+// parseGeneration(g.Type, formatGeneration(g)) gives g back
+func formatGeneration(generation models.AIGeneration) string {
+	var text strings.Builder
+
+	switch generation.Type {
+	case models.AISuggestionTypeImprovement:
+		writeBullets(&text, generation.Improvements)
+	case models.AISuggestionTypeTitle:
+		writeBullets(&text, generation.Titles)
+	case models.AISuggestionTypeTags:
+		writeBullets(&text, generation.Tags)
+	case models.AISuggestionTypeModeration:
+		text.WriteString("Verdict: " + generation.Verdict + "\n")
+		writeBullets(&text, generation.Reasons)
+	case models.AISuggestionTypeSummary:
+		text.WriteString("TL;DR: " + generation.Summary + "\n")
+	case models.AISuggestionTypeDraft:
+		text.WriteString("Introduction: " + generation.Intro + "\n")
+		for _, section := range generation.Sections {
+			text.WriteString("## " + section.Heading + "\n" + section.Body + "\n")
+		}
+		text.WriteString("Conclusion: " + generation.Conclusion + "\n")
+	default:
+		text.WriteString("Blog Post Idea: " + generation.Title + "\n")
+		text.WriteString("Target Audience: " + generation.Audience + "\n")
+		writeBullets(&text, generation.Headlines)
+	}
+
+	return text.String()
+}
+
+func writeBullets(text *strings.Builder, items []string) {
+	for _, item := range items {
+		text.WriteString("* " + item + "\n")
+	}
+}
+
+func cleanLabelValue(line, label string) string {
+	value := line[strings.Index(line, label)+len(label):]
+	return strings.Trim(value, "* \t")
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPrompt(t *testing.T) {
+	content := "Goroutines are cheap. Channels connect them."
+
+	tests := []struct {
+		name     string
+		req      models.AIGenerationRequest
+		contains []string
+	}{
+		{
+			name:     "ideas from keywords",
+			req:      models.AIGenerationRequest{Type: models.AISuggestionTypeIdeas, Keywords: []string{"go"}, Tone: "casual"},
+			contains: []string{"Blog Post Idea:", "casual blog post idea", "go"},
+		},
+		{
+			name:     "improvement rewrites the post",
+			req:      models.AIGenerationRequest{Type: models.AISuggestionTypeImprovement, Tone: "professional", Content: content},
+			contains: []string{"rewrites", "more professional", content},
+		},
+		{
+			name:     "title alternatives",
+			req:      models.AIGenerationRequest{Type: models.AISuggestionTypeTitle, Tone: "casual", Content: content},
+			contains: []string{"one title per line", content},
+		},
+		{
+			name:     "summary",
+			req:      models.AIGenerationRequest{Type: models.AISuggestionTypeSummary, Tone: "casual", Content: content},
+			contains: []string{"TL;DR:", content},
+		},
+		{
+			name: "draft expands the headlines",
+			req: models.AIGenerationRequest{
+				Type:      models.AISuggestionTypeDraft,
+				Tone:      "casual",
+				Title:     "Go in Practice",
+				Audience:  "backend developers",
+				Headlines: []string{"Goroutines", "Channels"},
+			},
+			contains: []string{"Introduction:", `"Go in Practice"`, "backend developers", "- Goroutines\n- Channels"},
+		},
+		{
+			name:     "unknown type falls back to ideas",
+			req:      models.AIGenerationRequest{Type: "poem", Keywords: []string{"go"}},
+			contains: []string{"Blog Post Idea:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := buildPrompt(tt.req)
+			for _, want := range tt.contains {
+				assert.Contains(t, prompt, want)
+			}
+		})
+	}
+}
+
+func TestBuildPrompt_TruncatesContent(t *testing.T) {
+	content := strings.Repeat("é", maxPromptContent+10)
+	prompt := buildPrompt(models.AIGenerationRequest{Type: models.AISuggestionTypeSummary, Content: content})
+
+	assert.NotContains(t, prompt, content)
+	assert.Contains(t, prompt, strings.Repeat("é", maxPromptContent)+"…")
+}
+
+func TestParseGeneration_RoundTrip(t *testing.T) {
+	generations := []models.AIGeneration{
+		{Type: models.AISuggestionTypeIdeas, Title: "Go", Audience: "Gophers", Headlines: []string{"One", "Two"}},
+		{Type: models.AISuggestionTypeImprovement, Improvements: []string{`"Go is fast" -> "Go compiles quickly"`}},
+		{Type: models.AISuggestionTypeTitle, Titles: []string{"Go in Practice", "Shipping Go"}},
+		{Type: models.AISuggestionTypeSummary, Summary: "Go is simple."},
+		{Type: models.AISuggestionTypeTags, Tags: []string{"go", "concurrency"}},
+		{Type: models.AISuggestionTypeModeration, Verdict: models.ModerationReview, Reasons: []string{"Links to a pharmacy"}},
+		{
+			Type:  models.AISuggestionTypeDraft,
+			Intro: "Go is simple.",
+			Sections: []models.AIDraftSection{
+				{Heading: "Goroutines", Body: "They are cheap.\n\n* Start one with `go`"},
+				{Heading: "Channels", Body: "They connect goroutines."},
+			},
+			Conclusion: "Try it.",
+		},
+	}
+
+	for _, generation := range generations {
+		t.Run(generation.Type, func(t *testing.T) {
+			assert.Equal(t, generation, parseGeneration(generation.Type, formatGeneration(generation)))
+		})
+	}
+}
+
+func TestParseGeneration_DraftWithEmphasis(t *testing.T) {
+	text := "**Introduction:** Go is simple.\nIt compiles fast.\n\n## Goroutines\nCheap threads.\n**Conclusion:** Try it."
+	generation := parseGeneration(models.AISuggestionTypeDraft, text)
+
+	assert.Equal(t, "Go is simple.\nIt compiles fast.", generation.Intro)
+	assert.Equal(t, []models.AIDraftSection{{Heading: "Goroutines", Body: "Cheap threads."}}, generation.Sections)
+	assert.Equal(t, "Try it.", generation.Conclusion)
+}
+
+func TestParseGeneration_UnlabelledSummary(t *testing.T) {
+	generation := parseGeneration(models.AISuggestionTypeSummary, "Go is simple.\nIt compiles fast.")
+
+	assert.Equal(t, "Go is simple. It compiles fast.", generation.Summary)
+}
@@ -0,0 +1,609 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSidecarAIProvider_Generate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/generate", r.URL.Path)
+
+		var body sidecarRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []string{"go", "testing"}, body.Keywords)
+		assert.Equal(t, "casual", body.Tone)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"title":     "Testing in Go",
+			"audience":  "Go developers",
+			"headlines": []string{"Table-driven tests", "Mocks"},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewSidecarAIProvider(server.URL+"/", time.Second)
+	generation, err := provider.Generate(context.Background(), models.AIGenerationRequest{
+		Keywords: []string{"go", "testing"},
+		Tone:     "casual",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AISuggestionTypeIdeas, generation.Type)
+	assert.Equal(t, "Testing in Go", generation.Title)
+	assert.Equal(t, "Go developers", generation.Audience)
+	assert.Equal(t, []string{"Table-driven tests", "Mocks"}, generation.Headlines)
+}
+
+func TestSidecarAIProvider_GenerateTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body sidecarRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Prompt, "TL;DR")
+		assert.Contains(t, body.Prompt, "Goroutines are cheap threads.")
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"text":  "TL;DR: Goroutines are cheap.",
+			"title": "",
+			"usage": map[string]int{"prompt_tokens": 25, "completion_tokens": 6},
+		})
+	}))
+	defer server.Close()
+
+	generation, err := NewSidecarAIProvider(server.URL, time.Second).Generate(context.Background(), models.AIGenerationRequest{
+		Type:    models.AISuggestionTypeSummary,
+		Tone:    "casual",
+		Content: "Goroutines are cheap threads.",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AISuggestionTypeSummary, generation.Type)
+	assert.Equal(t, "Goroutines are cheap.", generation.Summary)
+	assert.Equal(t, models.AITokenUsage{PromptTokens: 25, CompletionTokens: 6}, generation.Usage)
+}
+
+func TestSidecarAIProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken/generate" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to generate content. Please try again."})
+	}))
+	defer server.Close()
+
+	// Sidecar reported a generation failure
+	_, err := NewSidecarAIProvider(server.URL, time.Second).Generate(context.Background(), models.AIGenerationRequest{})
+	assert.EqualError(t, err, "Failed to generate content. Please try again.")
+
+	// Non-200 status
+	_, err = NewSidecarAIProvider(server.URL+"/broken", time.Second).Generate(context.Background(), models.AIGenerationRequest{})
+	assert.Error(t, err)
+
+	// Not configured
+	_, err = NewSidecarAIProvider("", time.Second).Generate(context.Background(), models.AIGenerationRequest{})
+	assert.EqualError(t, err, "AI_SERVICE_URL not configured")
+}
+
+func TestSidecarAIProvider_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	_, err := NewSidecarAIProvider(server.URL, 50*time.Millisecond).Generate(context.Background(), models.AIGenerationRequest{})
+	assert.Error(t, err)
+}
+
+func TestOpenAIProvider_Generate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		var body chatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "test-model", body.Model)
+		assert.Len(t, body.Messages, 2)
+		assert.Contains(t, body.Messages[1].Content, "go, concurrency")
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{
+					"role":    "assistant",
+					"content": "**Blog Post Idea:** Concurrency in Go\n**Target Audience:** Backend developers\n* Goroutines\n- Channels\n",
+				}},
+			},
+			"usage": map[string]int{"prompt_tokens": 42, "completion_tokens": 17, "total_tokens": 59},
+		})
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(server.URL+"/v1", "test-key", "test-model", time.Second)
+	generation, err := provider.Generate(context.Background(), models.AIGenerationRequest{
+		Keywords: []string{"go", "concurrency"},
+		Tone:     "professional",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Concurrency in Go", generation.Title)
+	assert.Equal(t, "Backend developers", generation.Audience)
+	assert.Equal(t, []string{"Goroutines", "Channels"}, generation.Headlines)
+	assert.Equal(t, models.AITokenUsage{PromptTokens: 42, CompletionTokens: 17}, generation.Usage)
+
+	_, err = NewOpenAIProvider(server.URL, "", "test-model", time.Second).Generate(context.Background(), models.AIGenerationRequest{})
+	assert.EqualError(t, err, "OPENAI_API_KEY not configured")
+}
+
+func TestOpenAIProvider_GenerateTitles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body chatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Messages[0].Content, "one title per line")
+		assert.Contains(t, body.Messages[1].Content, "Channels let goroutines talk.")
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": "* Talking Goroutines\n* Channels 101\n"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	generation, err := NewOpenAIProvider(server.URL, "test-key", "test-model", time.Second).Generate(context.Background(), models.AIGenerationRequest{
+		Type:    models.AISuggestionTypeTitle,
+		Tone:    "casual",
+		Content: "Channels let goroutines talk.",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AISuggestionTypeTitle, generation.Type)
+	assert.Equal(t, []string{"Talking Goroutines", "Channels 101"}, generation.Titles)
+}
+
+func TestOpenAIProvider_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body chatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.True(t, body.Stream)
+		assert.True(t, body.StreamOptions.IncludeUsage)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range []string{"Blog Post Idea: ", "Streams", "\nTarget Audience: Devs\n", "* One\n"} {
+			chunk, _ := json.Marshal(map[string]interface{}{
+				"choices": []map[string]interface{}{{"delta": map[string]string{"content": delta}}},
+			})
+			w.Write([]byte("data: " + string(chunk) + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(`data: {"choices":[],"usage":{"prompt_tokens":30,"completion_tokens":9}}` + "\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	var tokens []string
+	provider := NewOpenAIProvider(server.URL, "test-key", "test-model", time.Second)
+	generation, err := provider.Stream(context.Background(), models.AIGenerationRequest{Keywords: []string{"go"}}, func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 4)
+	assert.Equal(t, "Streams", generation.Title)
+	assert.Equal(t, 39, generation.Usage.Total())
+	assert.Equal(t, []string{"One"}, generation.Headlines)
+}
+
+func TestSidecarAIProvider_Stream(t *testing.T) {
+	chunks := []string{"Blog Post Idea: Café culture\n", "Target Audience: Everyone\n", "* Espresso\n"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/generate/stream", r.URL.Path)
+		for _, chunk := range chunks {
+			event, _ := json.Marshal(map[string]string{"text": chunk})
+			w.Write([]byte("data: " + string(event) + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	var tokens []string
+	generation, err := NewSidecarAIProvider(server.URL, time.Second).Stream(context.Background(), models.AIGenerationRequest{}, func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	text := strings.Join(chunks, "")
+	assert.NoError(t, err)
+	assert.Equal(t, chunks, tokens)
+	assert.Equal(t, "Café culture", generation.Title)
+	assert.Equal(t, estimateTokens(text), generation.Usage.CompletionTokens)
+	assert.Equal(t, []string{"Espresso"}, generation.Headlines)
+}
+
+func TestSidecarAIProvider_StreamErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "upstream failed mid-stream",
+			body:    "data: {\"text\": \"Blog Post Idea: \"}\n\ndata: {\"error\": \"Gemini returned status 503\"}\n\n",
+			wantErr: "AI service stream failed: Gemini returned status 503",
+		},
+		{
+			name:    "stream cut off before its terminator",
+			body:    "data: {\"text\": \"Blog Post Idea: \"}\n\n",
+			wantErr: "AI service stream ended unexpectedly",
+		},
+		{
+			name:    "stream without text",
+			body:    "data: [DONE]\n\n",
+			wantErr: "AI service returned an empty stream",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewSidecarAIProvider(server.URL, time.Second).Stream(context.Background(), models.AIGenerationRequest{}, func(token string) error {
+				return nil
+			})
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSidecarAIProvider_StreamFallback(t *testing.T) {
+	// A sidecar without /generate/stream
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/generate" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"title": "Fallback", "headlines": []string{"One"}})
+	}))
+	defer server.Close()
+
+	var tokens []string
+	generation, err := NewSidecarAIProvider(server.URL, time.Second).Stream(context.Background(), models.AIGenerationRequest{}, func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Fallback", generation.Title)
+	assert.Len(t, tokens, 1)
+	assert.Contains(t, tokens[0], "Blog Post Idea: Fallback")
+}
+
+func TestFakeAIProvider_Stream(t *testing.T) {
+	provider := NewFakeAIProvider()
+	req := models.AIGenerationRequest{Keywords: []string{"go"}, Tone: "casual"}
+
+	var text strings.Builder
+	generation, err := provider.Stream(context.Background(), req, func(token string) error {
+		text.WriteString(token)
+		return nil
+	})
+	assert.NoError(t, err)
+	parsed := parseGeneration(models.AISuggestionTypeIdeas, text.String())
+	parsed.Usage = generation.Usage
+	assert.Equal(t, parsed, generation)
+	assert.NotZero(t, generation.Usage.Total())
+
+	// An error from the callback stops the stream
+	calls := 0
+	_, err = provider.Stream(context.Background(), req, func(token string) error {
+		calls++
+		return context.Canceled
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestFakeAIProvider_Generate(t *testing.T) {
+	provider := NewFakeAIProvider()
+	req := models.AIGenerationRequest{Keywords: []string{"go", "mongo"}, Tone: "casual"}
+
+	first, err := provider.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	second, _ := provider.Generate(context.Background(), req)
+
+	// Same request, same answer
+	assert.Equal(t, first, second)
+	assert.Equal(t, models.AISuggestionTypeIdeas, first.Type)
+	assert.Equal(t, "A casual guide to go, mongo", first.Title)
+	assert.Len(t, first.Headlines, 2)
+	assert.Len(t, provider.Requests, 2)
+
+	// Each type answers in its own shape
+	req.Content = "Go and Mongo work well together."
+	req.Type = models.AISuggestionTypeImprovement
+	improvement, _ := provider.Generate(context.Background(), req)
+	assert.Len(t, improvement.Improvements, 3)
+	req.Type = models.AISuggestionTypeTitle
+	titles, _ := provider.Generate(context.Background(), req)
+	assert.NotEmpty(t, titles.Titles)
+	req.Type = models.AISuggestionTypeSummary
+	summary, _ := provider.Generate(context.Background(), req)
+	assert.Equal(t, "Go and Mongo work well together.", summary.Summary)
+
+	// Cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = provider.Generate(ctx, req)
+	assert.Error(t, err)
+}
+
+func TestMeteredAIProvider(t *testing.T) {
+	fake := NewFakeAIProvider()
+	provider := NewMeteredAIProvider(fake)
+	req := models.AIGenerationRequest{Keywords: []string{"go"}, Tone: "casual"}
+
+	// Without a meter calls just pass through
+	_, err := provider.Generate(context.Background(), req)
+	assert.NoError(t, err)
+
+	meter := &models.AIUsageMeter{}
+	ctx := models.ContextWithAIUsageMeter(context.Background(), meter)
+
+	generation, err := provider.Generate(ctx, req)
+	assert.NoError(t, err)
+	streamed, err := provider.Stream(ctx, req, func(token string) error { return nil })
+	assert.NoError(t, err)
+
+	record, ok := meter.Record()
+	assert.True(t, ok)
+	assert.Equal(t, 2, record.Calls)
+	assert.Equal(t, generation.Usage.Total()+streamed.Usage.Total(), record.TotalTokens)
+	assert.True(t, record.Success)
+
+	// A failed call marks the request as failed
+	fake.Err = errors.New("AI service request failed")
+	_, err = provider.Generate(ctx, req)
+	assert.Error(t, err)
+
+	record, _ = meter.Record()
+	assert.Equal(t, 3, record.Calls)
+	assert.False(t, record.Success)
+}
+
+// gatedAIProvider holds every Generate call until release is closed
+type gatedAIProvider struct {
+	*FakeAIProvider
+	release chan struct{}
+}
+
+func (p *gatedAIProvider) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	select {
+	case <-p.release:
+	case <-ctx.Done():
+		return models.AIGeneration{}, ctx.Err()
+	}
+	return p.FakeAIProvider.Generate(ctx, req)
+}
+
+func TestCachingAIProvider(t *testing.T) {
+	fake := NewFakeAIProvider()
+	provider := NewCachingAIProvider(fake, time.Minute, 10)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+
+	req := models.AIGenerationRequest{Type: models.AISuggestionTypeIdeas, Keywords: []string{"Go", "mongo"}, Tone: "casual"}
+
+	first, err := provider.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, first.Cache)
+	assert.NotZero(t, first.Usage.Total())
+
+	// Case, keyword order and duplicates do not matter
+	same := models.AIGenerationRequest{Type: "Ideas", Keywords: []string{" mongo", "go", "GO"}, Tone: "Casual "}
+	second, err := provider.Generate(context.Background(), same)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheHit, second.Cache)
+	assert.Zero(t, second.Usage.Total())
+	assert.Equal(t, first.Headlines, second.Headlines)
+	assert.Len(t, fake.Requests, 1)
+
+	// Different content is a different request
+	_, err = provider.Generate(context.Background(), models.AIGenerationRequest{Type: models.AISuggestionTypeSummary, Keywords: []string{"go"}, Tone: "casual", Content: "First post"})
+	assert.NoError(t, err)
+	other, err := provider.Generate(context.Background(), models.AIGenerationRequest{Type: models.AISuggestionTypeSummary, Keywords: []string{"go"}, Tone: "casual", Content: "Second post"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, other.Cache)
+	assert.Len(t, fake.Requests, 3)
+
+	// Expired answers are generated again
+	now = now.Add(time.Minute)
+	third, err := provider.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, third.Cache)
+	assert.Len(t, fake.Requests, 4)
+
+	// Failures are not cached
+	fake.Err = errors.New("AI service request failed")
+	_, err = provider.Generate(context.Background(), models.AIGenerationRequest{Keywords: []string{"rust"}, Tone: "casual"})
+	assert.Error(t, err)
+	fake.Err = nil
+	retried, err := provider.Generate(context.Background(), models.AIGenerationRequest{Keywords: []string{"rust"}, Tone: "casual"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, retried.Cache)
+}
+
+func TestCachingAIProvider_Evicts(t *testing.T) {
+	fake := NewFakeAIProvider()
+	provider := NewCachingAIProvider(fake, time.Minute, 2)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+
+	for _, keyword := range []string{"go", "mongo", "gin"} {
+		_, err := provider.Generate(context.Background(), models.AIGenerationRequest{Keywords: []string{keyword}, Tone: "casual"})
+		assert.NoError(t, err)
+		now = now.Add(time.Second)
+	}
+	assert.Len(t, provider.entries, 2)
+
+	// The oldest answer made room for the newest
+	generation, err := provider.Generate(context.Background(), models.AIGenerationRequest{Keywords: []string{"go"}, Tone: "casual"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, generation.Cache)
+}
+
+func TestCachingAIProvider_SharesInflightCalls(t *testing.T) {
+	gated := &gatedAIProvider{FakeAIProvider: NewFakeAIProvider(), release: make(chan struct{})}
+	provider := NewCachingAIProvider(gated, time.Minute, 10)
+	req := models.AIGenerationRequest{Type: models.AISuggestionTypeIdeas, Keywords: []string{"go"}, Tone: "casual"}
+
+	const callers = 5
+	results := make(chan models.AIGeneration, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			generation, err := provider.Generate(context.Background(), req)
+			assert.NoError(t, err)
+			results <- generation
+		}()
+	}
+
+	// Let every caller join the call before it answers
+	assert.Eventually(t, func() bool {
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+		call := provider.inflight[aiCacheKey(req)]
+		return call != nil && call.waiters == callers
+	}, time.Second, time.Millisecond)
+	close(gated.release)
+
+	statuses := map[string]int{}
+	charged := 0
+	for i := 0; i < callers; i++ {
+		generation := <-results
+		statuses[generation.Cache]++
+		if generation.Usage.Total() > 0 {
+			charged++
+		}
+	}
+	assert.Equal(t, map[string]int{models.AICacheMiss: 1, models.AICacheShared: callers - 1}, statuses)
+	// One call, paid for once
+	assert.Equal(t, 1, charged)
+	assert.Len(t, gated.Requests, 1)
+}
+
+func TestCachingAIProvider_ChargesAWaitingCaller(t *testing.T) {
+	gated := &gatedAIProvider{FakeAIProvider: NewFakeAIProvider(), release: make(chan struct{})}
+	provider := NewCachingAIProvider(NewMeteredAIProvider(gated), time.Minute, 10)
+	req := models.AIGenerationRequest{Type: models.AISuggestionTypeIdeas, Keywords: []string{"go"}, Tone: "casual"}
+
+	// The caller that starts the call disconnects before it answers
+	firstMeter := &models.AIUsageMeter{}
+	firstCtx, cancelFirst := context.WithCancel(models.ContextWithAIUsageMeter(context.Background(), firstMeter))
+	firstDone := make(chan error)
+	go func() {
+		_, err := provider.Generate(firstCtx, req)
+		firstDone <- err
+	}()
+	assert.Eventually(t, func() bool {
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+		return provider.inflight[aiCacheKey(req)] != nil
+	}, time.Second, time.Millisecond)
+
+	secondMeter := &models.AIUsageMeter{}
+	secondDone := make(chan models.AIGeneration)
+	go func() {
+		generation, err := provider.Generate(models.ContextWithAIUsageMeter(context.Background(), secondMeter), req)
+		assert.NoError(t, err)
+		secondDone <- generation
+	}()
+	assert.Eventually(t, func() bool {
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+		call := provider.inflight[aiCacheKey(req)]
+		return call != nil && call.waiters == 2
+	}, time.Second, time.Millisecond)
+
+	cancelFirst()
+	assert.ErrorIs(t, <-firstDone, context.Canceled)
+	close(gated.release)
+	generation := <-secondDone
+
+	// The caller still waiting pays for the call, so it is not lost with the one that left
+	_, charged := firstMeter.Record()
+	assert.False(t, charged)
+	record, ok := secondMeter.Record()
+	assert.True(t, ok)
+	assert.Equal(t, 1, record.Calls)
+	assert.NotZero(t, record.TotalTokens)
+	assert.Equal(t, generation.Usage.Total(), record.TotalTokens)
+}
+
+func TestCachingAIProvider_LastWaiterCancels(t *testing.T) {
+	gated := &gatedAIProvider{FakeAIProvider: NewFakeAIProvider(), release: make(chan struct{})}
+	provider := NewCachingAIProvider(gated, time.Minute, 10)
+	req := models.AIGenerationRequest{Keywords: []string{"go"}, Tone: "casual"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := provider.Generate(ctx, req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Nobody was left waiting, so the upstream call was abandoned and nothing cached
+	assert.Eventually(t, func() bool {
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+		return len(provider.inflight) == 0
+	}, time.Second, time.Millisecond)
+	assert.Empty(t, gated.Requests)
+
+	close(gated.release)
+	generation, err := provider.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, generation.Cache)
+}
+
+func TestCachingAIProvider_Stream(t *testing.T) {
+	fake := NewFakeAIProvider()
+	provider := NewCachingAIProvider(fake, time.Minute, 10)
+	req := models.AIGenerationRequest{Type: models.AISuggestionTypeTitle, Keywords: []string{"go"}, Tone: "casual", Content: "Goroutines are cheap."}
+
+	var streamed []string
+	first, err := provider.Stream(context.Background(), req, func(token string) error {
+		streamed = append(streamed, token)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheMiss, first.Cache)
+	assert.NotEmpty(t, streamed)
+
+	// A cached answer, from a stream or not, is replayed whole
+	var replayed []string
+	second, err := provider.Stream(context.Background(), req, func(token string) error {
+		replayed = append(replayed, token)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheHit, second.Cache)
+	assert.Equal(t, []string{formatGeneration(first)}, replayed)
+	assert.Equal(t, first.Titles, second.Titles)
+
+	generated, err := provider.Generate(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AICacheHit, generated.Cache)
+	assert.Len(t, fake.Requests, 1)
+}
@@ -1 +1,223 @@
 package services
+
+import (
+	"blog-api/Domain/models"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SidecarAIProvider talks to the Python AI service in ai_service/
+type SidecarAIProvider struct {
+	baseURL      string
+	client       *http.Client
+	streamClient *http.Client
+}
+
+func NewSidecarAIProvider(baseURL string, timeout time.Duration) *SidecarAIProvider {
+	return &SidecarAIProvider{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		client:       &http.Client{Timeout: timeout},
+		streamClient: newStreamClient(timeout),
+	}
+}
+
+// sidecarRequest matches the sidecar's SuggestRequest model. The sidecar uses Prompt as is
+// when it is set, so the prompt templates live in one place.
+type sidecarRequest struct {
+	Keywords []string `json:"keywords"`
+	Tone     string   `json:"tone"`
+	Prompt   string   `json:"prompt,omitempty"`
+}
+
+// sidecarResponse is the sidecar's raw answer with its own parse of it, or an error message
+type sidecarResponse struct {
+	Text      string              `json:"text"`
+	Title     string              `json:"title"`
+	Audience  string              `json:"audience"`
+	Headlines []string            `json:"headlines"`
+	Usage     models.AITokenUsage `json:"usage"`
+	Error     string              `json:"error"`
+}
+
+// Generate posts the templated prompt to the sidecar's /generate endpoint
+func (p *SidecarAIProvider) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	if p.baseURL == "" {
+		return models.AIGeneration{}, errors.New("AI_SERVICE_URL not configured")
+	}
+
+	var result sidecarResponse
+	payload := sidecarPayload(req)
+	if err := postJSON(ctx, p.client, p.baseURL+"/generate", nil, payload, &result); err != nil {
+		return models.AIGeneration{}, err
+	}
+	if result.Error != "" {
+		return models.AIGeneration{}, errors.New(result.Error)
+	}
+
+	// Sidecars that predate the raw text only know the ideas layout
+	if result.Text == "" {
+		generation := models.AIGeneration{
+			Type:      models.AISuggestionTypeIdeas,
+			Title:     result.Title,
+			Audience:  result.Audience,
+			Headlines: result.Headlines,
+		}
+		return withUsage(generation, result.Usage, payload.Prompt, formatGeneration(generation)), nil
+	}
+
+	return withUsage(parseGeneration(req.Type, result.Text), result.Usage, payload.Prompt, result.Text), nil
+}
+
+// Stream relays the text events of the sidecar's /generate/stream endpoint. Sidecars that predate
+// the endpoint answer 404; for those the whole generation is sent as a single token.
+func (p *SidecarAIProvider) Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	if p.baseURL == "" {
+		return models.AIGeneration{}, errors.New("AI_SERVICE_URL not configured")
+	}
+
+	payload := sidecarPayload(req)
+	resp, err := openStream(ctx, p.streamClient, p.baseURL+"/generate/stream", nil, payload)
+	var statusErr *aiStatusError
+	if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+		generation, err := p.Generate(ctx, req)
+		if err != nil {
+			return models.AIGeneration{}, err
+		}
+		return generation, onToken(formatGeneration(generation))
+	}
+	if err != nil {
+		return models.AIGeneration{}, err
+	}
+	defer resp.Body.Close()
+
+	var text strings.Builder
+	done := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			done = true
+			break
+		}
+
+		var event sidecarStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return models.AIGeneration{}, fmt.Errorf("failed to parse AI stream: %w", err)
+		}
+		if event.Error != "" {
+			return models.AIGeneration{}, errors.New("AI service stream failed: " + event.Error)
+		}
+		if event.Text == "" {
+			continue
+		}
+
+		text.WriteString(event.Text)
+		if err := onToken(event.Text); err != nil {
+			return models.AIGeneration{}, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return models.AIGeneration{}, fmt.Errorf("AI service stream failed: %w", err)
+	}
+	if err := streamEnd(done, text.String()); err != nil {
+		return models.AIGeneration{}, err
+	}
+
+	// The raw stream carries no usage, so it is estimated
+	return withUsage(parseGeneration(req.Type, text.String()), models.AITokenUsage{}, payload.Prompt, text.String()), nil
+}
+
+// sidecarStreamEvent is one "data:" line of the sidecar's stream: a chunk of text, or the error
+// that ended it
+type sidecarStreamEvent struct {
+	Text  string `json:"text"`
+	Error string `json:"error"`
+}
+
+// streamEnd rejects a stream that closed before its terminator or carried no text; that is how
+// an upstream failing mid-generation shows up when no error event makes it through
+func streamEnd(done bool, text string) error {
+	if !done {
+		return errors.New("AI service stream ended unexpectedly")
+	}
+	if strings.TrimSpace(text) == "" {
+		return errors.New("AI service returned an empty stream")
+	}
+	return nil
+}
+
+func sidecarPayload(req models.AIGenerationRequest) sidecarRequest {
+	return sidecarRequest{Keywords: req.Keywords, Tone: req.Tone, Prompt: buildPrompt(req)}
+}
+
+// aiStatusError is a non-200 answer from an AI backend
+type aiStatusError struct {
+	status string
+	code   int
+}
+
+func (e *aiStatusError) Error() string {
+	return "AI service returned error status: " + e.status
+}
+
+// newStreamClient bounds the wait for response headers only, so long generations can keep streaming.
+// Client disconnects still cancel the call through the request context.
+func newStreamClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = timeout
+	return &http.Client{Transport: transport}
+}
+
+// postJSON sends body as JSON and decodes a 200 response into out
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	resp, err := openStream(ctx, client, url, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse AI response: %w", err)
+	}
+
+	return nil
+}
+
+// openStream sends body as JSON and returns the open 200 response; the caller closes its body
+func openStream(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode AI request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("AI service request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &aiStatusError{status: resp.Status, code: resp.StatusCode}
+	}
+
+	return resp, nil
+}
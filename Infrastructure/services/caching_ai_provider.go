@@ -0,0 +1,275 @@
+package services
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachingAIProvider answers repeated requests from memory for ttl. Requests are keyed by their
+// normalized type, keywords and tone and a hash of the content they work on. Identical requests
+// arriving while one is in flight wait for its answer instead of calling the backend again.
+type CachingAIProvider struct {
+	provider   interfaces.AIProvider
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]aiCacheEntry
+	inflight map[string]*aiInflightCall
+}
+
+type aiCacheEntry struct {
+	generation models.AIGeneration
+	expiresAt  time.Time
+}
+
+// aiInflightCall is one backend call shared by every identical request waiting on it. The call
+// reports its usage to its own meter, which is charged to one caller still waiting for the answer.
+type aiInflightCall struct {
+	done       chan struct{}
+	generation models.AIGeneration
+	err        error
+	waiters    int
+	cancel     context.CancelFunc
+	meter      *models.AIUsageMeter
+	charged    bool
+}
+
+func NewCachingAIProvider(provider interfaces.AIProvider, ttl time.Duration, maxEntries int) *CachingAIProvider {
+	return &CachingAIProvider{
+		provider:   provider,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]aiCacheEntry),
+		inflight:   make(map[string]*aiInflightCall),
+	}
+}
+
+// Generate answers from the cache, joins an identical call in flight, or calls the backend.
+// The backend call runs until its last waiter gives up, so one caller disconnecting does not
+// fail the others, and its usage goes to the first caller that collects the answer.
+func (p *CachingAIProvider) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	key := aiCacheKey(req)
+
+	p.mu.Lock()
+	if generation, ok := p.lookup(key); ok {
+		p.mu.Unlock()
+		return cachedGeneration(generation, models.AICacheHit), nil
+	}
+	call, shared := p.inflight[key]
+	if !shared {
+		// Detached from the caller's cancellation, and metered on its own since this caller may
+		// leave before the call ends
+		meter := &models.AIUsageMeter{}
+		callCtx, cancel := context.WithCancel(models.ContextWithAIUsageMeter(context.WithoutCancel(ctx), meter))
+		call = &aiInflightCall{done: make(chan struct{}), cancel: cancel, meter: meter}
+		p.inflight[key] = call
+		go p.run(callCtx, key, req, call)
+	}
+	call.waiters++
+	p.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		select {
+		case <-call.done:
+			// Answered as well, so this caller can still be charged for it
+		default:
+			p.leave(key, call)
+			return models.AIGeneration{}, ctx.Err()
+		}
+	}
+
+	charged := p.charge(ctx, call)
+	if call.err != nil {
+		return models.AIGeneration{}, call.err
+	}
+
+	status := models.AICacheMiss
+	if shared {
+		status = models.AICacheShared
+	}
+	generation := cachedGeneration(call.generation, status)
+	if charged {
+		generation.Usage = call.generation.Usage
+	} else {
+		generation.Usage = models.AITokenUsage{}
+	}
+	return generation, nil
+}
+
+// charge hands the call's usage to the meter of the first caller to collect the answer, and
+// reports whether that was this caller
+func (p *CachingAIProvider) charge(ctx context.Context, call *aiInflightCall) bool {
+	p.mu.Lock()
+	first := !call.charged
+	call.charged = true
+	p.mu.Unlock()
+
+	if !first {
+		return false
+	}
+	if meter := models.AIUsageMeterFromContext(ctx); meter != nil {
+		meter.Merge(call.meter)
+	}
+	return true
+}
+
+// Stream replays a cached generation as a single token. Otherwise it streams from the backend
+// and caches the result; streams never join a call in flight since each has its own listener.
+func (p *CachingAIProvider) Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	key := aiCacheKey(req)
+
+	p.mu.Lock()
+	generation, ok := p.lookup(key)
+	p.mu.Unlock()
+	if ok {
+		generation = cachedGeneration(generation, models.AICacheHit)
+		return generation, onToken(formatGeneration(generation))
+	}
+
+	generation, err := p.provider.Stream(ctx, req, onToken)
+	if err != nil {
+		return generation, err
+	}
+
+	p.mu.Lock()
+	p.store(key, generation)
+	p.mu.Unlock()
+
+	return cachedGeneration(generation, models.AICacheMiss), nil
+}
+
+// run makes the shared backend call and hands its answer to every waiter
+func (p *CachingAIProvider) run(ctx context.Context, key string, req models.AIGenerationRequest, call *aiInflightCall) {
+	defer call.cancel()
+
+	generation, err := p.provider.Generate(ctx, req)
+
+	p.mu.Lock()
+	if p.inflight[key] == call {
+		delete(p.inflight, key)
+	}
+	if err == nil {
+		p.store(key, generation)
+	}
+	call.generation, call.err = generation, err
+	p.mu.Unlock()
+
+	close(call.done)
+}
+
+// leave drops a waiter that gave up and cancels the backend call once nobody waits on it
+func (p *CachingAIProvider) leave(key string, call *aiInflightCall) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	// Later requests must not join a call that is being cancelled
+	if p.inflight[key] == call {
+		delete(p.inflight, key)
+	}
+	call.cancel()
+}
+
+// lookup returns the live entry for key; the caller holds mu
+func (p *CachingAIProvider) lookup(key string) (models.AIGeneration, bool) {
+	entry, ok := p.entries[key]
+	if !ok {
+		return models.AIGeneration{}, false
+	}
+	if !p.now().Before(entry.expiresAt) {
+		delete(p.entries, key)
+		return models.AIGeneration{}, false
+	}
+	return entry.generation, true
+}
+
+// store caches a generation, making room by dropping expired entries and then the entry
+// closest to expiry; the caller holds mu
+func (p *CachingAIProvider) store(key string, generation models.AIGeneration) {
+	now := p.now()
+
+	if _, exists := p.entries[key]; !exists && p.maxEntries > 0 && len(p.entries) >= p.maxEntries {
+		for k, entry := range p.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(p.entries, k)
+			}
+		}
+		if len(p.entries) >= p.maxEntries {
+			var oldestKey string
+			var oldest time.Time
+			for k, entry := range p.entries {
+				if oldestKey == "" || entry.expiresAt.Before(oldest) {
+					oldestKey, oldest = k, entry.expiresAt
+				}
+			}
+			delete(p.entries, oldestKey)
+		}
+	}
+
+	p.entries[key] = aiCacheEntry{generation: generation, expiresAt: now.Add(p.ttl)}
+}
+
+// cachedGeneration marks how the cache served a generation. Answers served from the cache cost
+// nothing, so they carry no usage.
+func cachedGeneration(generation models.AIGeneration, status string) models.AIGeneration {
+	generation.Cache = status
+	if status != models.AICacheMiss {
+		generation.Usage = models.AITokenUsage{}
+	}
+	return generation
+}
+
+// aiCacheKey hashes the parts of a request that shape the answer. Type, tone and keywords are
+// compared case-insensitively, keywords in any order; content is compared by its hash.
+func aiCacheKey(req models.AIGenerationRequest) string {
+	keywords := []string{}
+	seen := make(map[string]bool)
+	for _, keyword := range req.Keywords {
+		keyword = strings.ToLower(strings.Join(strings.Fields(keyword), " "))
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	content := sha256.Sum256([]byte(strings.TrimSpace(req.Content)))
+
+	normalized, _ := json.Marshal(struct {
+		Type      string   `json:"type"`
+		Keywords  []string `json:"keywords"`
+		Tone      string   `json:"tone"`
+		Content   string   `json:"content"`
+		Title     string   `json:"title"`
+		Audience  string   `json:"audience"`
+		Headlines []string `json:"headlines"`
+	}{
+		Type:      strings.ToLower(strings.TrimSpace(req.Type)),
+		Keywords:  keywords,
+		Tone:      strings.ToLower(strings.TrimSpace(req.Tone)),
+		Content:   hex.EncodeToString(content[:]),
+		Title:     strings.TrimSpace(req.Title),
+		Audience:  strings.TrimSpace(req.Audience),
+		Headlines: req.Headlines,
+	})
+
+	key := sha256.Sum256(normalized)
+	return hex.EncodeToString(key[:])
+}
@@ -1,11 +1,14 @@
 package services
 
 import (
+	"blog-api/Domain/models"
 	"crypto/tls"
 	"fmt"
+	"html"
 	"log"
 	"net/smtp"
 	"strconv"
+	"time"
 )
 
 type EmailService struct {
@@ -159,3 +162,56 @@ func (es *EmailService) SendPasswordResetEmail(username, email, token string) er
 
 	return es.SendEmail(email, subject, body)
 }
+
+func (es *EmailService) SendModerationResultEmail(username, email, title, status, note string) error {
+	subject := "Your content was reviewed"
+	heading := "✅ Approved"
+	message := "has been approved and is now visible to readers."
+	if status != models.ModerationStatusApproved {
+		heading = "🚫 Not approved"
+		message = "was not approved and stays hidden."
+	}
+
+	noteBlock := ""
+	if note != "" {
+		noteBlock = fmt.Sprintf(`<p style="color: #555;">Reviewer's note: <em>%s</em></p>`, html.EscapeString(note))
+	}
+
+	body := fmt.Sprintf(`
+		<div style="font-family: Arial, sans-serif; max-width: 600px; margin: auto; padding: 20px; border: 1px solid #eee; border-radius: 10px;">
+			<h2 style="color: #333;">%s</h2>
+			<p style="color: #555;">Hello %s, your content "<strong>%s</strong>" %s</p>
+			%s
+			<p style="font-size: 0.9em; color: #aaa;">Reply to this email if you have questions about the decision.</p>
+		</div>`, heading, html.EscapeString(username), html.EscapeString(title), message, noteBlock)
+
+	return es.SendEmail(email, subject, body)
+}
+
+func (es *EmailService) SendWarningEmail(username, email, reason string) error {
+	subject := "A warning about your content"
+
+	body := fmt.Sprintf(`
+		<div style="font-family: Arial, sans-serif; max-width: 600px; margin: auto; padding: 20px; border: 1px solid #eee; border-radius: 10px;">
+			<h2 style="color: #333;">⚠️ Warning</h2>
+			<p style="color: #555;">Hello %s, readers reported content you posted and an admin has hidden it.</p>
+			<p style="color: #555;">Reason: <em>%s</em></p>
+			<p style="font-size: 0.9em; color: #aaa;">Repeated violations can lead to your account being suspended.</p>
+		</div>`, html.EscapeString(username), html.EscapeString(reason))
+
+	return es.SendEmail(email, subject, body)
+}
+
+func (es *EmailService) SendSuspensionEmail(username, email, reason string, until time.Time) error {
+	subject := "Your account has been suspended"
+
+	body := fmt.Sprintf(`
+		<div style="font-family: Arial, sans-serif; max-width: 600px; margin: auto; padding: 20px; border: 1px solid #eee; border-radius: 10px;">
+			<h2 style="color: #333;">⛔ Account suspended</h2>
+			<p style="color: #555;">Hello %s, your account has been suspended until <strong>%s</strong> over content readers reported.</p>
+			<p style="color: #555;">Reason: <em>%s</em></p>
+			<p style="font-size: 0.9em; color: #aaa;">Reply to this email if you have questions about the decision.</p>
+		</div>`, html.EscapeString(username), until.UTC().Format("January 2, 2006 15:04 MST"), html.EscapeString(reason))
+
+	return es.SendEmail(email, subject, body)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FakeAIProvider answers deterministically from the request alone. It backs tests and
+// local development without an AI backend (AI_PROVIDER=fake).
+type FakeAIProvider struct {
+	mu sync.Mutex
+	// Err, when set, is returned by every call
+	Err error
+	// Requests records every request received
+	Requests []models.AIGenerationRequest
+}
+
+func NewFakeAIProvider() *FakeAIProvider {
+	return &FakeAIProvider{}
+}
+
+// Generate answers in the shape of the requested type, built from the tone, keywords and content
+func (p *FakeAIProvider) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	p.mu.Lock()
+	p.Requests = append(p.Requests, req)
+	p.mu.Unlock()
+
+	if p.Err != nil {
+		return models.AIGeneration{}, p.Err
+	}
+	if err := ctx.Err(); err != nil {
+		return models.AIGeneration{}, err
+	}
+
+	generation := fakeGeneration(req)
+	return withUsage(generation, models.AITokenUsage{}, buildPrompt(req), formatGeneration(generation)), nil
+}
+
+func fakeGeneration(req models.AIGenerationRequest) models.AIGeneration {
+	keywords := strings.Join(req.Keywords, ", ")
+	switch req.Type {
+	case models.AISuggestionTypeImprovement:
+		generation := models.AIGeneration{Type: req.Type}
+		for _, keyword := range req.Keywords {
+			generation.Improvements = append(generation.Improvements, fmt.Sprintf("Expand on %s with a concrete example", keyword))
+		}
+		generation.Improvements = append(generation.Improvements, fmt.Sprintf("Keep the opening %s and under three sentences", req.Tone))
+		return generation
+	case models.AISuggestionTypeTitle:
+		return models.AIGeneration{
+			Type: req.Type,
+			Titles: []string{
+				fmt.Sprintf("A %s guide to %s", req.Tone, keywords),
+				"What you need to know about " + keywords,
+				"Getting started with " + keywords,
+			},
+		}
+	case models.AISuggestionTypeSummary:
+		words := strings.Fields(req.Content)
+		if len(words) > 20 {
+			return models.AIGeneration{Type: req.Type, Summary: strings.Join(words[:20], " ") + "…"}
+		}
+		return models.AIGeneration{Type: req.Type, Summary: strings.Join(words, " ")}
+	case models.AISuggestionTypeDraft:
+		generation := models.AIGeneration{
+			Type:       req.Type,
+			Intro:      fmt.Sprintf("This post is a %s look at %s.", req.Tone, req.Title),
+			Conclusion: "That covers " + req.Title + ".",
+		}
+		for _, headline := range req.Headlines {
+			generation.Sections = append(generation.Sections, models.AIDraftSection{
+				Heading: headline,
+				Body:    "More on " + strings.ToLower(headline) + ".",
+			})
+		}
+		return generation
+	case models.AISuggestionTypeTags:
+		// The known tags the post mentions, then the longer words of its title
+		generation := models.AIGeneration{Type: req.Type, Tags: []string{}}
+		text := strings.ToLower(req.Title + " " + req.Content)
+		for _, keyword := range req.Keywords {
+			if strings.Contains(text, strings.ToLower(keyword)) {
+				generation.Tags = append(generation.Tags, keyword)
+			}
+		}
+		for _, word := range strings.Fields(strings.ToLower(req.Title)) {
+			if len(generation.Tags) == 5 {
+				break
+			}
+			if len(word) > 3 {
+				generation.Tags = append(generation.Tags, word)
+			}
+		}
+		return generation
+	case models.AISuggestionTypeModeration:
+		// Everything goes through unless it calls itself spam
+		if strings.Contains(strings.ToLower(req.Title+" "+req.Content), "spam") {
+			return models.AIGeneration{Type: req.Type, Verdict: models.ModerationReview, Reasons: []string{"Mentions spam"}}
+		}
+		return models.AIGeneration{Type: req.Type, Verdict: models.ModerationAllow}
+	}
+
+	generation := models.AIGeneration{
+		Type:      models.AISuggestionTypeIdeas,
+		Title:     fmt.Sprintf("A %s guide to %s", req.Tone, keywords),
+		Audience:  "Readers interested in " + keywords,
+		Headlines: make([]string, 0, len(req.Keywords)),
+	}
+	for _, keyword := range req.Keywords {
+		generation.Headlines = append(generation.Headlines, "Getting started with "+keyword)
+	}
+
+	return generation
+}
+
+// Stream sends the Generate answer word by word
+func (p *FakeAIProvider) Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	generation, err := p.Generate(ctx, req)
+	if err != nil {
+		return models.AIGeneration{}, err
+	}
+
+	for _, token := range strings.SplitAfter(formatGeneration(generation), " ") {
+		if err := ctx.Err(); err != nil {
+			return models.AIGeneration{}, err
+		}
+		if err := onToken(token); err != nil {
+			return models.AIGeneration{}, err
+		}
+	}
+
+	return generation, nil
+}
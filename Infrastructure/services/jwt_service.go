@@ -11,6 +11,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// mfaTokenTTL is how long a user has to enter their code after the password was checked
+const mfaTokenTTL = 5 * time.Minute
+
 type JWTService struct {
 	accessSecretKey  string
 	refreshSecretKey string
@@ -27,13 +30,15 @@ func NewJWTService(accessSecret, refreshSecret string, accessTTL, refreshTTL tim
 	}
 }
 
-func (j *JWTService) GenerateAccessToken(userID, email, role string) (string, error) {
+func (j *JWTService) GenerateAccessToken(userID, email, role string, version int, mfa bool) (string, error) {
 	exp := time.Now().Add(j.accessTokenTTL).Unix()
 
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
 		"role":    role,
+		"ver":     version,
+		"mfa":     mfa,
 		"exp":     exp,
 	}
 
@@ -70,8 +75,6 @@ func (j *JWTService) GenerateRefreshToken(userID, email, role string) (*models.T
 		ExpiresAt: exp,
 		CreatedAt: iat,
 		UserID:    userID,
-		// IP: '',
-		// Device: '',
 	}, nil
 }
 func (j *JWTService) VerifyAccessToken(tokenStr string) (*models.UserAccessClaims, error) {
@@ -82,10 +85,19 @@ func (j *JWTService) VerifyAccessToken(tokenStr string) (*models.UserAccessClaim
 		return nil, errors.New("invalid token")
 	}
 	claims := token.Claims.(jwt.MapClaims)
+	// MFA challenges are signed with the same key but are no access tokens
+	if _, ok := claims["purpose"]; ok {
+		return nil, errors.New("invalid token")
+	}
+	// Tokens issued before versioning carry no version, which reads as 0
+	version, _ := claims["ver"].(float64)
+	mfa, _ := claims["mfa"].(bool)
 	return &models.UserAccessClaims{
-		UserID: claims["user_id"].(string),
-		Email:  claims["email"].(string),
-		Role:   claims["role"].(string),
+		UserID:  claims["user_id"].(string),
+		Email:   claims["email"].(string),
+		Role:    claims["role"].(string),
+		Version: int(version),
+		MFA:     mfa,
 	}, nil
 }
 
@@ -147,8 +159,12 @@ func (s *JWTService) VerifyJWT(tokenStr string) (models.TokenClaims, error) {
 	if !ok {
 		return models.TokenClaims{}, errors.New("invalid claims")
 	}
+	tokenID, ok := claims["token_id"].(string)
+	if !ok {
+		return models.TokenClaims{}, errors.New("invalid claims")
+	}
 	newclaims := models.TokenClaims{
-		TokenID:   claims["token_id"].(string),
+		TokenID:   tokenID,
 		ExpiresAt: time.Unix(int64(claims["exp"].(float64)), 0),
 	}
 	return newclaims, nil
@@ -162,3 +178,30 @@ func (j *JWTService) HashToken(token string) string {
 func (j *JWTService) VerifyToken(hashed, token string) bool {
 	return hashed == j.HashToken(token)
 }
+
+func (j *JWTService) GenerateMFAToken(userID string) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"purpose": "mfa",
+		"exp":     time.Now().Add(mfaTokenTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.accessSecretKey))
+}
+
+func (j *JWTService) VerifyMFAToken(tokenStr string) (string, error) {
+	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+		return []byte(j.accessSecretKey), nil
+	})
+	if err != nil || !token.Valid {
+		return "", errors.New("invalid token")
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	userID, ok := claims["user_id"].(string)
+	if !ok || claims["purpose"] != "mfa" {
+		return "", errors.New("invalid token")
+	}
+	return userID, nil
+}
@@ -19,7 +19,7 @@ func setupJWTService() *JWTService {
 func TestGenerateAccessToken(t *testing.T) {
 	svc := setupJWTService()
 
-	tokenStr, err := svc.GenerateAccessToken("user123", "test@example.com", "admin")
+	tokenStr, err := svc.GenerateAccessToken("user123", "test@example.com", "admin", 3, true)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenStr)
 
@@ -28,6 +28,28 @@ func TestGenerateAccessToken(t *testing.T) {
 	assert.Equal(t, "user123", claims.UserID)
 	assert.Equal(t, "test@example.com", claims.Email)
 	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, 3, claims.Version)
+	assert.True(t, claims.MFA)
+}
+
+func TestGenerateMFAToken(t *testing.T) {
+	svc := setupJWTService()
+
+	tokenStr, err := svc.GenerateMFAToken("user123")
+	assert.NoError(t, err)
+
+	userID, err := svc.VerifyMFAToken(tokenStr)
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", userID)
+
+	// A challenge is no access token, and an access token is no challenge
+	_, err = svc.VerifyAccessToken(tokenStr)
+	assert.Error(t, err)
+
+	accessToken, err := svc.GenerateAccessToken("user123", "test@example.com", "admin", 0, false)
+	assert.NoError(t, err)
+	_, err = svc.VerifyMFAToken(accessToken)
+	assert.Error(t, err)
 }
 
 func TestGenerateRefreshToken(t *testing.T) {
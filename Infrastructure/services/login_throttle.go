@@ -0,0 +1,118 @@
+package services
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"strings"
+	"time"
+)
+
+// LoginThrottlePolicies sets the limits of each kind of counter
+type LoginThrottlePolicies struct {
+	// Account and IP limit failed logins
+	Account models.AttemptPolicy
+	IP      models.AttemptPolicy
+	// ResetEmail and ResetIP limit password reset requests, failed or not
+	ResetEmail models.AttemptPolicy
+	ResetIP    models.AttemptPolicy
+}
+
+// DefaultLoginThrottlePolicies lets a person mistype a few times, and an office behind one IP
+// share it, while making guessing slow
+var DefaultLoginThrottlePolicies = LoginThrottlePolicies{
+	Account:    models.AttemptPolicy{Free: 3, BaseDelay: 2 * time.Second, MaxDelay: 5 * time.Minute, LockoutAt: 10, LockoutDuration: 30 * time.Minute, Window: time.Hour},
+	IP:         models.AttemptPolicy{Free: 20, BaseDelay: time.Second, MaxDelay: time.Minute, LockoutAt: 100, LockoutDuration: time.Hour, Window: time.Hour},
+	ResetEmail: models.AttemptPolicy{Free: 1, BaseDelay: time.Minute, MaxDelay: 30 * time.Minute, LockoutAt: 5, LockoutDuration: time.Hour, Window: time.Hour},
+	ResetIP:    models.AttemptPolicy{Free: 5, BaseDelay: time.Minute, MaxDelay: 30 * time.Minute, LockoutAt: 20, LockoutDuration: time.Hour, Window: time.Hour},
+}
+
+// maxReserveTries bounds how often an attempt is retried while other attempts keep changing the
+// counter first
+const maxReserveTries = 5
+
+// LoginThrottle keeps its counters in the repository, so limits hold across restarts and
+// instances
+type LoginThrottle struct {
+	attemptRepo interfaces.LoginAttemptRepository
+	policies    LoginThrottlePolicies
+	now         func() time.Time
+}
+
+func NewLoginThrottle(attemptRepo interfaces.LoginAttemptRepository, policies LoginThrottlePolicies) *LoginThrottle {
+	return &LoginThrottle{attemptRepo: attemptRepo, policies: policies, now: time.Now}
+}
+
+func (t *LoginThrottle) AttemptLogin(email, ip string) error {
+	return t.reserve(
+		throttledKey{"login:account:" + normalizeEmail(email), t.policies.Account},
+		throttledKey{"login:ip:" + ip, t.policies.IP},
+	)
+}
+
+func (t *LoginThrottle) LoginSucceeded(email, ip string) error {
+	if err := t.attemptRepo.ClearAttempts("login:account:" + normalizeEmail(email)); err != nil {
+		return err
+	}
+	return t.attemptRepo.ReleaseAttempt("login:ip:" + ip)
+}
+
+func (t *LoginThrottle) AllowPasswordReset(email, ip string) error {
+	return t.reserve(
+		throttledKey{"reset:email:" + normalizeEmail(email), t.policies.ResetEmail},
+		throttledKey{"reset:ip:" + ip, t.policies.ResetIP},
+	)
+}
+
+func (t *LoginThrottle) UnlockAccount(email string) error {
+	return t.attemptRepo.ClearAttempts("login:account:" + normalizeEmail(email))
+}
+
+type throttledKey struct {
+	key    string
+	policy models.AttemptPolicy
+}
+
+// reserve counts an attempt against every key, or against none when one of them has to wait
+func (t *LoginThrottle) reserve(keys ...throttledKey) error {
+	for i, k := range keys {
+		if err := t.reserveKey(k); err != nil {
+			for _, reserved := range keys[:i] {
+				if err := t.attemptRepo.ReleaseAttempt(reserved.key); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// reserveKey decides from the counter as read and counts the attempt only if the counter has not
+// changed since, so concurrent attempts are decided one after the other
+func (t *LoginThrottle) reserveKey(k throttledKey) error {
+	for i := 0; i < maxReserveTries; i++ {
+		counter, err := t.attemptRepo.GetAttempts(k.key)
+		if err != nil {
+			return err
+		}
+		now := t.now()
+		if retryAt := k.policy.RetryAt(counter, now); !retryAt.IsZero() {
+			return &models.ThrottledError{RetryAt: retryAt}
+		}
+
+		reserved, err := t.attemptRepo.ReserveAttempt(counter, now, k.policy.Window)
+		if err != nil {
+			return err
+		}
+		if reserved {
+			return nil
+		}
+	}
+
+	// Other attempts keep getting there first; wait as a delayed attempt would
+	return &models.ThrottledError{RetryAt: t.now().Add(k.policy.BaseDelay)}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
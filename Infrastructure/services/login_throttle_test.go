@@ -0,0 +1,233 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAttemptPolicy_RetryAt(t *testing.T) {
+	policy := models.AttemptPolicy{Free: 3, BaseDelay: 2 * time.Second, MaxDelay: time.Minute, LockoutAt: 10, LockoutDuration: 30 * time.Minute, Window: time.Hour}
+	lastAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		count   int
+		now     time.Time
+		retryAt time.Time
+	}{
+		{name: "Free attempts are not delayed", count: 2, now: lastAt},
+		{name: "First delayed attempt waits the base delay", count: 3, now: lastAt, retryAt: lastAt.Add(2 * time.Second)},
+		{name: "Each further failure doubles the wait", count: 5, now: lastAt, retryAt: lastAt.Add(8 * time.Second)},
+		{name: "The wait is capped", count: 9, now: lastAt, retryAt: lastAt.Add(time.Minute)},
+		{name: "The wait passes", count: 5, now: lastAt.Add(8 * time.Second)},
+		{name: "Enough failures lock the account", count: 10, now: lastAt.Add(10 * time.Minute), retryAt: lastAt.Add(30 * time.Minute)},
+		{name: "The lockout passes", count: 10, now: lastAt.Add(30 * time.Minute)},
+		{name: "Old failures are forgotten", count: 50, now: lastAt.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryAt := policy.RetryAt(models.AttemptCounter{Count: tt.count, LastAt: lastAt}, tt.now)
+			assert.Equal(t, tt.retryAt, retryAt)
+		})
+	}
+}
+
+func TestLoginThrottle_AttemptLogin(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		account       int
+		ip            int
+		retryAt       time.Time
+		reserveIP     bool
+		expectRelease bool
+	}{
+		{name: "Success - Few failures", account: 1, ip: 1, reserveIP: true},
+		{name: "Error - Account locked", account: 10, ip: 1, retryAt: now.Add(30 * time.Minute)},
+		{name: "Error - IP locked", account: 0, ip: 100, retryAt: now.Add(time.Hour), expectRelease: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := models.AttemptCounter{Key: "login:account:john@example.com", Count: tt.account, LastAt: now}
+			ip := models.AttemptCounter{Key: "login:ip:203.0.113.7", Count: tt.ip, LastAt: now}
+			attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+			attemptRepo.On("GetAttempts", account.Key).Return(account, nil)
+			attemptRepo.On("GetAttempts", ip.Key).Return(ip, nil).Maybe()
+			attemptRepo.On("ReserveAttempt", account, now, time.Hour).Return(true, nil).Maybe()
+			if tt.reserveIP {
+				attemptRepo.On("ReserveAttempt", ip, now, time.Hour).Return(true, nil)
+			}
+			if tt.expectRelease {
+				attemptRepo.On("ReleaseAttempt", account.Key).Return(nil)
+			}
+
+			throttle := NewLoginThrottle(attemptRepo, DefaultLoginThrottlePolicies)
+			throttle.now = func() time.Time { return now }
+
+			err := throttle.AttemptLogin(" John@Example.com", "203.0.113.7")
+
+			if !tt.retryAt.IsZero() {
+				var throttled *models.ThrottledError
+				assert.ErrorAs(t, err, &throttled)
+				assert.Equal(t, tt.retryAt, throttled.RetryAt)
+			} else {
+				assert.NoError(t, err)
+			}
+			attemptRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoginThrottle_AttemptLogin_Concurrent(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	attemptRepo := newMemoryAttemptRepo()
+	throttle := NewLoginThrottle(attemptRepo, DefaultLoginThrottlePolicies)
+	throttle.now = func() time.Time { return now }
+
+	// A burst sent at once must not get past the free attempts before any of them is counted
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if throttle.AttemptLogin("john@example.com", "203.0.113.7") == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, DefaultLoginThrottlePolicies.Account.Free, allowed)
+	account, _ := attemptRepo.GetAttempts("login:account:john@example.com")
+	ip, _ := attemptRepo.GetAttempts("login:ip:203.0.113.7")
+	assert.Equal(t, allowed, account.Count)
+	assert.Equal(t, allowed, ip.Count)
+
+	// A success clears the account and gives the IP its attempt back
+	assert.NoError(t, throttle.LoginSucceeded("john@example.com", "203.0.113.7"))
+	account, _ = attemptRepo.GetAttempts("login:account:john@example.com")
+	ip, _ = attemptRepo.GetAttempts("login:ip:203.0.113.7")
+	assert.Equal(t, 0, account.Count)
+	assert.Equal(t, allowed-1, ip.Count)
+}
+
+func TestLoginThrottle_LoginSucceeded(t *testing.T) {
+	attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+	attemptRepo.On("ClearAttempts", "login:account:john@example.com").Return(nil)
+	attemptRepo.On("ReleaseAttempt", "login:ip:203.0.113.7").Return(nil)
+
+	throttle := NewLoginThrottle(attemptRepo, DefaultLoginThrottlePolicies)
+
+	assert.NoError(t, throttle.LoginSucceeded("john@example.com", "203.0.113.7"))
+	assert.NoError(t, throttle.UnlockAccount("John@example.com"))
+
+	attemptRepo.AssertExpectations(t)
+	attemptRepo.AssertNumberOfCalls(t, "ClearAttempts", 2)
+}
+
+func TestLoginThrottle_AllowPasswordReset(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		sent      int
+		lastAt    time.Time
+		throttled bool
+	}{
+		{name: "Success - First request", sent: 0},
+		{name: "Success - Second request after the wait", sent: 1, lastAt: now.Add(-time.Minute)},
+		{name: "Error - Second request right away", sent: 1, lastAt: now.Add(-10 * time.Second), throttled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attemptRepo := &mocks.LoginAttemptRepositoryMock{}
+			attemptRepo.On("GetAttempts", "reset:email:john@example.com").Return(models.AttemptCounter{Count: tt.sent, LastAt: tt.lastAt}, nil)
+			attemptRepo.On("GetAttempts", "reset:ip:203.0.113.7").Return(models.AttemptCounter{Count: tt.sent, LastAt: tt.lastAt}, nil)
+			attemptRepo.On("ReserveAttempt", mock.Anything, now, time.Hour).Return(true, nil)
+
+			throttle := NewLoginThrottle(attemptRepo, DefaultLoginThrottlePolicies)
+			throttle.now = func() time.Time { return now }
+
+			err := throttle.AllowPasswordReset("john@example.com", "203.0.113.7")
+
+			if tt.throttled {
+				var throttled *models.ThrottledError
+				assert.ErrorAs(t, err, &throttled)
+				assert.Equal(t, tt.lastAt.Add(time.Minute), throttled.RetryAt)
+				attemptRepo.AssertNotCalled(t, "ReserveAttempt", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				attemptRepo.AssertNumberOfCalls(t, "ReserveAttempt", 2)
+			}
+		})
+	}
+}
+
+// memoryAttemptRepo keeps counters in memory and reserves attempts the way the Mongo repository
+// does, only while the counter is unchanged
+type memoryAttemptRepo struct {
+	mu       sync.Mutex
+	counters map[string]models.AttemptCounter
+}
+
+func newMemoryAttemptRepo() *memoryAttemptRepo {
+	return &memoryAttemptRepo{counters: make(map[string]models.AttemptCounter)}
+}
+
+func (r *memoryAttemptRepo) GetAttempts(key string) (models.AttemptCounter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if counter, ok := r.counters[key]; ok {
+		return counter, nil
+	}
+	return models.AttemptCounter{Key: key}, nil
+}
+
+func (r *memoryAttemptRepo) ReserveAttempt(seen models.AttemptCounter, now time.Time, window time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.counters[seen.Key]
+	if current.Count != seen.Count || !current.LastAt.Equal(seen.LastAt) {
+		return false, nil
+	}
+	r.counters[seen.Key] = nextAttempt(current, seen.Key, now, window)
+	return true, nil
+}
+
+func (r *memoryAttemptRepo) ReleaseAttempt(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if counter, ok := r.counters[key]; ok && counter.Count > 0 {
+		counter.Count--
+		r.counters[key] = counter
+	}
+	return nil
+}
+
+func (r *memoryAttemptRepo) ClearAttempts(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.counters, key)
+	return nil
+}
+
+func nextAttempt(counter models.AttemptCounter, key string, now time.Time, window time.Duration) models.AttemptCounter {
+	if counter.LastAt.After(now.Add(-window)) {
+		return models.AttemptCounter{Key: key, Count: counter.Count + 1, LastAt: now}
+	}
+	return models.AttemptCounter{Key: key, Count: 1, LastAt: now}
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"time"
+)
+
+// MeteredAIProvider reports the tokens and latency of every call to the usage meter attached
+// to the call's context, if any
+type MeteredAIProvider struct {
+	provider interfaces.AIProvider
+}
+
+func NewMeteredAIProvider(provider interfaces.AIProvider) *MeteredAIProvider {
+	return &MeteredAIProvider{provider: provider}
+}
+
+func (p *MeteredAIProvider) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	start := time.Now()
+	generation, err := p.provider.Generate(ctx, req)
+	if meter := models.AIUsageMeterFromContext(ctx); meter != nil {
+		meter.Add(generation.Usage, time.Since(start), err)
+	}
+	return generation, err
+}
+
+func (p *MeteredAIProvider) Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	start := time.Now()
+	generation, err := p.provider.Stream(ctx, req, onToken)
+	if meter := models.AIUsageMeterFromContext(ctx); meter != nil {
+		meter.Add(generation.Usage, time.Since(start), err)
+	}
+	return generation, err
+}
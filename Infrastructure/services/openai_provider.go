@@ -0,0 +1,175 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIProvider calls any OpenAI-compatible chat completions API
+type OpenAIProvider struct {
+	baseURL      string
+	apiKey       string
+	model        string
+	client       *http.Client
+	streamClient *http.Client
+}
+
+func NewOpenAIProvider(baseURL, apiKey, model string, timeout time.Duration) *OpenAIProvider {
+	return &OpenAIProvider{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		apiKey:       apiKey,
+		model:        model,
+		client:       &http.Client{Timeout: timeout},
+		streamClient: newStreamClient(timeout),
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model         string         `json:"model"`
+	Messages      []chatMessage  `json:"messages"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *streamOptions `json:"stream_options,omitempty"`
+}
+
+// streamOptions asks for a final chunk carrying the token usage
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage chatCompletionUsage `json:"usage"`
+}
+
+// chatCompletionChunk is one server-sent event of a streamed completion
+type chatCompletionChunk struct {
+	Choices []struct {
+		Delta chatMessage `json:"delta"`
+	} `json:"choices"`
+	Usage *chatCompletionUsage `json:"usage"`
+}
+
+// Generate asks the model for the requested kind of suggestion and parses the formatted answer
+func (p *OpenAIProvider) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	if p.apiKey == "" {
+		return models.AIGeneration{}, errors.New("OPENAI_API_KEY not configured")
+	}
+
+	var result chatCompletionResponse
+	completion := p.completionRequest(req, false)
+	if err := postJSON(ctx, p.client, p.baseURL+"/chat/completions", p.headers(), completion, &result); err != nil {
+		return models.AIGeneration{}, err
+	}
+	if len(result.Choices) == 0 {
+		return models.AIGeneration{}, errors.New("AI service returned no choices")
+	}
+
+	answer := result.Choices[0].Message.Content
+	return withUsage(parseGeneration(req.Type, answer), result.Usage.tokenUsage(), completion.prompt(), answer), nil
+}
+
+// Stream requests a streamed completion and relays each content delta
+func (p *OpenAIProvider) Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	if p.apiKey == "" {
+		return models.AIGeneration{}, errors.New("OPENAI_API_KEY not configured")
+	}
+
+	completion := p.completionRequest(req, true)
+	resp, err := openStream(ctx, p.streamClient, p.baseURL+"/chat/completions", p.headers(), completion)
+	if err != nil {
+		return models.AIGeneration{}, err
+	}
+	defer resp.Body.Close()
+
+	var text strings.Builder
+	var usage models.AITokenUsage
+	done := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			done = true
+			break
+		}
+
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return models.AIGeneration{}, fmt.Errorf("failed to parse AI stream: %w", err)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage.tokenUsage()
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		token := chunk.Choices[0].Delta.Content
+		text.WriteString(token)
+		if err := onToken(token); err != nil {
+			return models.AIGeneration{}, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return models.AIGeneration{}, fmt.Errorf("AI service stream failed: %w", err)
+	}
+	if err := streamEnd(done, text.String()); err != nil {
+		return models.AIGeneration{}, err
+	}
+
+	return withUsage(parseGeneration(req.Type, text.String()), usage, completion.prompt(), text.String()), nil
+}
+
+func (u chatCompletionUsage) tokenUsage() models.AITokenUsage {
+	return models.AITokenUsage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens}
+}
+
+// prompt is the text of every message, for estimating usage
+func (r chatCompletionRequest) prompt() string {
+	var prompt strings.Builder
+	for _, message := range r.Messages {
+		prompt.WriteString(message.Content)
+	}
+	return prompt.String()
+}
+
+func (p *OpenAIProvider) headers() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + p.apiKey}
+}
+
+func (p *OpenAIProvider) completionRequest(req models.AIGenerationRequest, stream bool) chatCompletionRequest {
+	template := promptTemplateFor(req.Type)
+	completion := chatCompletionRequest{
+		Model: p.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: template.format},
+			{Role: "user", Content: template.task(req)},
+		},
+		Stream: stream,
+	}
+	if stream {
+		completion.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+	return completion
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"blog-api/Domain/interfaces"
+	"log"
+	"time"
+)
+
+type PublishScheduler struct {
+	publisher interfaces.ScheduledPublisher
+	interval  time.Duration
+	stopChan  chan bool
+}
+
+func NewPublishScheduler(publisher interfaces.ScheduledPublisher) *PublishScheduler {
+	return &PublishScheduler{
+		publisher: publisher,
+		interval:  1 * time.Minute, // Run every minute
+		stopChan:  make(chan bool),
+	}
+}
+
+// Start starts the background scheduler
+func (ps *PublishScheduler) Start() {
+	log.Println("Starting publish scheduler...")
+
+	go func() {
+		ticker := time.NewTicker(ps.interval)
+		defer ticker.Stop()
+
+		// Publish anything that became due while the server was down
+		ps.PublishDue()
+
+		for {
+			select {
+			case <-ticker.C:
+				ps.PublishDue()
+			case <-ps.stopChan:
+				log.Println("Stopping publish scheduler...")
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background scheduler
+func (ps *PublishScheduler) Stop() {
+	ps.stopChan <- true
+}
+
+// PublishDue publishes all due drafts immediately
+func (ps *PublishScheduler) PublishDue() {
+	published, err := ps.publisher.PublishDueBlogs()
+	if err != nil {
+		log.Printf("Error publishing scheduled blogs: %v", err)
+	}
+	if published > 0 {
+		log.Printf("Published %d scheduled blog(s)", published)
+	}
+}
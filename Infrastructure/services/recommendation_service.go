@@ -12,15 +12,18 @@ import (
 type recommendationService struct {
 	recommendationRepo interfaces.RecommendationRepository
 	blogRepo           interfaces.BlogRepository
+	followRepo         interfaces.FollowRepository
 }
 
 func NewRecommendationService(
 	recommendationRepo interfaces.RecommendationRepository,
 	blogRepo interfaces.BlogRepository,
+	followRepo interfaces.FollowRepository,
 ) interfaces.RecommendationService {
 	return &recommendationService{
 		recommendationRepo: recommendationRepo,
 		blogRepo:           blogRepo,
+		followRepo:         followRepo,
 	}
 }
 
@@ -180,7 +183,8 @@ func (r *recommendationService) FindSimilarContent(blogID string, limit int) ([]
 	return result, nil
 }
 
-// UpdateUserInterests updates user's interest profile based on their behavior
+// UpdateUserInterests rebuilds user's interest profile from their behavior and the authors they
+// follow. Topics that no longer carry weight, such as an author they unfollowed, are dropped.
 func (r *recommendationService) UpdateUserInterests(userID string) error {
 	behaviors, err := r.recommendationRepo.GetUserBehaviors(userID, 100)
 	if err != nil {
@@ -207,13 +211,23 @@ func (r *recommendationService) UpdateUserInterests(userID string) error {
 		}
 
 		// Add weight to author
-		authorKey := "author:" + blog.AuthorID
-		interestWeights[authorKey] += adjustedWeight
+		interestWeights[models.AuthorTopic(blog.AuthorID)] += adjustedWeight
 
 		totalWeight += adjustedWeight
 	}
 
+	// Followed authors count as a standing interest
+	following, err := r.followRepo.GetFollowing(userID)
+	if err != nil {
+		return err
+	}
+	for _, authorID := range following {
+		interestWeights[models.AuthorTopic(authorID)] += models.WeightFollow
+		totalWeight += models.WeightFollow
+	}
+
 	// Normalize and create interest records
+	kept := make(map[string]bool)
 	for topic, weight := range interestWeights {
 		if totalWeight > 0 {
 			normalizedWeight := weight / totalWeight
@@ -227,10 +241,25 @@ func (r *recommendationService) UpdateUserInterests(userID string) error {
 					UpdatedAt: time.Now(),
 				}
 				r.recommendationRepo.UpdateUserInterest(interest)
+				kept[topic] = true
 			}
 		}
 	}
 
+	existing, err := r.recommendationRepo.GetUserInterests(userID)
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for _, interest := range existing {
+		if !kept[interest.Topic] {
+			stale = append(stale, interest.Topic)
+		}
+	}
+	if len(stale) > 0 {
+		return r.recommendationRepo.DeleteUserInterests(userID, stale)
+	}
+
 	return nil
 }
 
@@ -397,8 +426,8 @@ func (r *recommendationService) GetPopularContent(limit int) ([]models.Blog, err
 
 	// Sort by popularity score (views + likes*2 + comments*3)
 	sort.Slice(allBlogs, func(i, j int) bool {
-		scoreI := float64(allBlogs[i].ViewCount + allBlogs[i].Likes*2 + len(allBlogs[i].Comments)*3)
-		scoreJ := float64(allBlogs[j].ViewCount + allBlogs[j].Likes*2 + len(allBlogs[j].Comments)*3)
+		scoreI := float64(allBlogs[i].ViewCount + allBlogs[i].Likes*2 + allBlogs[i].CommentCount*3)
+		scoreJ := float64(allBlogs[j].ViewCount + allBlogs[j].Likes*2 + allBlogs[j].CommentCount*3)
 		return scoreI > scoreJ
 	})
 
@@ -497,6 +526,8 @@ func getActionWeight(action string) float64 {
 	}
 }
 
+// calculateTagSimilarity is the Jaccard index of two tag sets. Blogs store canonical tags, but
+// posts written before the vocabulary may still differ in case or surrounding spaces.
 func calculateTagSimilarity(tags1, tags2 []string) float64 {
 	if len(tags1) == 0 && len(tags2) == 0 {
 		return 1.0
@@ -509,10 +540,10 @@ func calculateTagSimilarity(tags1, tags2 []string) float64 {
 	tagSet2 := make(map[string]bool)
 
 	for _, tag := range tags1 {
-		tagSet1[tag] = true
+		tagSet1[strings.ToLower(strings.TrimSpace(tag))] = true
 	}
 	for _, tag := range tags2 {
-		tagSet2[tag] = true
+		tagSet2[strings.ToLower(strings.TrimSpace(tag))] = true
 	}
 
 	intersection := 0
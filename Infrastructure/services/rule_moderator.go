@@ -0,0 +1,211 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Thresholds of the local moderation rules
+const (
+	// Posts with at least this many links need this many words per link to not look like link spam
+	linkSpamMinLinks    = 3
+	linkSpamWordsPerURL = 8
+	// A line repeated this often, or a word making up more than half of at least repeatedWordMinWords
+	// words, reads as filler
+	repeatedLineLimit    = 3
+	repeatedWordMinWords = 20
+	repeatedCharRun      = 20
+	// Texts shorter than this are too generic to flag as duplicates
+	duplicateMinWords = 8
+	duplicateWindow   = 24 * time.Hour
+	maxRecentContent  = 10000
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://\S+|\bwww\.\S+`)
+
+// RuleModerator screens content locally with a blocklist, link spam heuristics and repeated
+// content detection. Blocklisted words reject content outright; the other rules send it for review.
+// Recent content is only remembered in memory, so each server instance detects duplicates on its
+// own and forgets them on restart.
+type RuleModerator struct {
+	blocklist []string
+	maxLinks  int
+	now       func() time.Time
+
+	mu sync.Mutex
+	// recent maps an author and content hash to the last content seen with it
+	recent map[string]recentContent
+}
+
+type recentContent struct {
+	contentID string
+	seenAt    time.Time
+}
+
+// NewRuleModerator builds a moderator rejecting the blocklisted words and phrases, matched as whole
+// words in any case, and holding content with more than maxLinks links; 0 means no link limit
+func NewRuleModerator(blocklist []string, maxLinks int) *RuleModerator {
+	terms := []string{}
+	for _, term := range blocklist {
+		if term = strings.Join(moderationWords(term), " "); term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	return &RuleModerator{
+		blocklist: terms,
+		maxLinks:  maxLinks,
+		now:       time.Now,
+		recent:    make(map[string]recentContent),
+	}
+}
+
+func (m *RuleModerator) Moderate(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error) {
+	verdict := models.ModerationVerdict{Decision: models.ModerationAllow}
+	text := content.Title + "\n" + content.Body
+	words := moderationWords(text)
+
+	if term := m.blocked(words); term != "" {
+		verdict = verdict.Merge(reject(fmt.Sprintf("Contains blocked term %q", term)))
+	}
+	if reason := m.linkSpam(text, len(words)); reason != "" {
+		verdict = verdict.Merge(review(reason))
+	}
+	if reason := repetition(content.Body, words); reason != "" {
+		verdict = verdict.Merge(review(reason))
+	}
+	if m.duplicate(content, words) {
+		verdict = verdict.Merge(review("Repeats content the author posted recently"))
+	}
+
+	return verdict, nil
+}
+
+// blocked returns the first blocklisted term found among words
+func (m *RuleModerator) blocked(words []string) string {
+	padded := " " + strings.Join(words, " ") + " "
+	for _, term := range m.blocklist {
+		if strings.Contains(padded, " "+term+" ") {
+			return term
+		}
+	}
+	return ""
+}
+
+func (m *RuleModerator) linkSpam(text string, wordCount int) string {
+	links := len(linkPattern.FindAllString(text, -1))
+	if m.maxLinks > 0 && links > m.maxLinks {
+		return fmt.Sprintf("Contains %d links, more than the %d allowed", links, m.maxLinks)
+	}
+	if links >= linkSpamMinLinks && wordCount < links*linkSpamWordsPerURL {
+		return "Consists mostly of links"
+	}
+	return ""
+}
+
+// repetition looks for filler: the same line over and over, one word drowning out the rest, or a
+// long run of one character
+func repetition(body string, words []string) string {
+	lines := make(map[string]int)
+	for _, line := range strings.Split(body, "\n") {
+		normalized := moderationWords(line)
+		if len(normalized) < 3 {
+			continue
+		}
+		key := strings.Join(normalized, " ")
+		lines[key]++
+		if lines[key] == repeatedLineLimit {
+			return "Repeats the same line"
+		}
+	}
+
+	if len(words) >= repeatedWordMinWords {
+		counts := make(map[string]int)
+		for _, word := range words {
+			if len([]rune(word)) < 3 {
+				continue
+			}
+			counts[word]++
+			if counts[word]*2 > len(words) {
+				return fmt.Sprintf("Repeats the word %q", word)
+			}
+		}
+	}
+
+	var last rune
+	run := 0
+	for _, r := range body {
+		if r == last && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.So, r)) {
+			run++
+			if run == repeatedCharRun {
+				return "Repeats the same character"
+			}
+			continue
+		}
+		last, run = r, 1
+	}
+
+	return ""
+}
+
+// duplicate reports whether the author posted the same text under another post or comment within
+// duplicateWindow, and remembers this one. New content is moderated before it is saved and has no
+// ID yet, so an entry without an ID is taken to be the first edit's own content rather than another
+// post's; two new posts with the same text still count as duplicates.
+func (m *RuleModerator) duplicate(content models.ModerationContent, words []string) bool {
+	if content.AuthorID == "" || len(words) < duplicateMinWords {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	key := content.AuthorID + ":" + hex.EncodeToString(sum[:])
+	now := m.now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, seen := m.recent[key]
+	isDuplicate := seen && now.Sub(previous.seenAt) < duplicateWindow &&
+		(content.ID == "" || (previous.contentID != "" && previous.contentID != content.ID))
+
+	if len(m.recent) >= maxRecentContent {
+		for k, entry := range m.recent {
+			if now.Sub(entry.seenAt) >= duplicateWindow {
+				delete(m.recent, k)
+			}
+		}
+	}
+	if seen || len(m.recent) < maxRecentContent {
+		contentID := content.ID
+		if contentID == "" {
+			// A copy being created must not take over the original's entry
+			contentID = previous.contentID
+		}
+		m.recent[key] = recentContent{contentID: contentID, seenAt: now}
+	}
+
+	return isDuplicate
+}
+
+// moderationWords lower-cases text and splits it into words of letters and digits
+func moderationWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func review(reason string) models.ModerationVerdict {
+	return models.ModerationVerdict{Decision: models.ModerationReview, Reasons: []string{reason}}
+}
+
+func reject(reason string) models.ModerationVerdict {
+	return models.ModerationVerdict{Decision: models.ModerationReject, Reasons: []string{reason}}
+}
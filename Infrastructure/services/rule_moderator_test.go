@@ -0,0 +1,186 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleModerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  models.ModerationContent
+		decision string
+		reasons  []string
+	}{
+		{
+			name:     "ordinary post",
+			content:  models.ModerationContent{Title: "Go in Practice", Body: "Goroutines are cheap. See https://go.dev for more."},
+			decision: models.ModerationAllow,
+		},
+		{
+			name:     "blocked phrase in any case",
+			content:  models.ModerationContent{Body: "Get CHEAP   Pills here"},
+			decision: models.ModerationReject,
+			reasons:  []string{`Contains blocked term "cheap pills"`},
+		},
+		{
+			name:     "blocked word only as a whole word",
+			content:  models.ModerationContent{Body: "Scasino is not a word, but it should not match"},
+			decision: models.ModerationAllow,
+		},
+		{
+			name:     "more links than allowed",
+			content:  models.ModerationContent{Body: "Part one of a long read about releases https://example.com/1\nPart two of the same long read about tags https://example.com/2\nPart three goes into the changelog format https://example.com/3\nAnd the last part covers the release notes https://example.com/4"},
+			decision: models.ModerationReview,
+			reasons:  []string{"Contains 4 links, more than the 3 allowed"},
+		},
+		{
+			name:     "mostly links",
+			content:  models.ModerationContent{Body: "see https://a.example www.b.example https://c.example"},
+			decision: models.ModerationReview,
+			reasons:  []string{"Consists mostly of links"},
+		},
+		{
+			name:     "same line over and over",
+			content:  models.ModerationContent{Body: "Buy my book now\nbuy my book now!\nBuy my book now"},
+			decision: models.ModerationReview,
+			reasons:  []string{"Repeats the same line"},
+		},
+		{
+			name:     "one word drowning out the rest",
+			content:  models.ModerationContent{Body: strings.Repeat("crypto ", 12) + "is the future of money and you should buy some today"},
+			decision: models.ModerationReview,
+			reasons:  []string{`Repeats the word "crypto"`},
+		},
+		{
+			name:     "long run of one character",
+			content:  models.ModerationContent{Body: "wow" + strings.Repeat("!", 30) + " so good" + strings.Repeat("o", 25)},
+			decision: models.ModerationReview,
+			reasons:  []string{"Repeats the same character"},
+		},
+		{
+			name:     "markdown rules are not repetition",
+			content:  models.ModerationContent{Body: "Intro\n\n" + strings.Repeat("-", 40) + "\n\nOutro"},
+			decision: models.ModerationAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moderator := NewRuleModerator([]string{"casino", " Cheap pills "}, 3)
+			verdict, err := moderator.Moderate(context.Background(), tt.content)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.decision, verdict.Decision)
+			assert.Equal(t, tt.reasons, verdict.Reasons)
+		})
+	}
+}
+
+func TestRuleModerator_DuplicateContent(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	moderator := NewRuleModerator(nil, 0)
+	moderator.now = func() time.Time { return now }
+
+	body := "I wrote a small tool that checks your Go modules for updates"
+	first := models.ModerationContent{Type: models.ModerationContentComment, ID: "comment1", AuthorID: "user123", Body: body}
+
+	verdict, _ := moderator.Moderate(context.Background(), first)
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	// Editing the same comment is not a duplicate
+	verdict, _ = moderator.Moderate(context.Background(), first)
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	// Someone else may say the same thing
+	verdict, _ = moderator.Moderate(context.Background(), models.ModerationContent{AuthorID: "user456", Body: body})
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	// The same author pasting it under another post is
+	verdict, _ = moderator.Moderate(context.Background(), models.ModerationContent{AuthorID: "user123", Body: strings.ToUpper(body) + "!"})
+	assert.Equal(t, models.ModerationReview, verdict.Decision)
+	assert.Equal(t, []string{"Repeats content the author posted recently"}, verdict.Reasons)
+
+	now = now.Add(duplicateWindow)
+	verdict, _ = moderator.Moderate(context.Background(), models.ModerationContent{ID: "comment3", AuthorID: "user123", Body: body})
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	// Short replies are too generic to count
+	moderator.Moderate(context.Background(), models.ModerationContent{AuthorID: "user123", Body: "Thanks, great post!"})
+	verdict, _ = moderator.Moderate(context.Background(), models.ModerationContent{AuthorID: "user123", Body: "Thanks, great post!"})
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+}
+
+func TestRuleModerator_DuplicateContent_EditAfterCreate(t *testing.T) {
+	moderator := NewRuleModerator(nil, 0)
+	post := models.ModerationContent{
+		Type:     models.ModerationContentBlog,
+		AuthorID: "user123",
+		Title:    "Checking Go modules",
+		Body:     "I wrote a small tool that checks your Go modules for updates",
+	}
+
+	// A new post is moderated before it is saved, so it has no ID yet
+	verdict, _ := moderator.Moderate(context.Background(), post)
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	// Editing only its tags leaves the same text under the saved post's ID
+	post.ID = "blog1"
+	verdict, _ = moderator.Moderate(context.Background(), post)
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	verdict, _ = moderator.Moderate(context.Background(), post)
+	assert.Equal(t, models.ModerationAllow, verdict.Decision)
+
+	// Another post with the same text still is a duplicate, new or edited
+	verdict, _ = moderator.Moderate(context.Background(), models.ModerationContent{AuthorID: post.AuthorID, Title: post.Title, Body: post.Body})
+	assert.Equal(t, models.ModerationReview, verdict.Decision)
+
+	verdict, _ = moderator.Moderate(context.Background(), models.ModerationContent{ID: "blog2", AuthorID: post.AuthorID, Title: post.Title, Body: post.Body})
+	assert.Equal(t, models.ModerationReview, verdict.Decision)
+}
+
+func TestChainModerator(t *testing.T) {
+	content := models.ModerationContent{Type: models.ModerationContentBlog, Title: "Spam for sale", Body: "Cheap spam"}
+
+	t.Run("keeps the strictest verdict", func(t *testing.T) {
+		moderator := NewChainModerator(NewRuleModerator(nil, 0), NewAIModerator(NewFakeAIProvider()))
+		verdict, err := moderator.Moderate(context.Background(), content)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModerationVerdict{Decision: models.ModerationReview, Reasons: []string{"AI: Mentions spam"}}, verdict)
+	})
+
+	t.Run("skips a failing stage", func(t *testing.T) {
+		provider := NewFakeAIProvider()
+		provider.Err = errors.New("AI service request failed")
+
+		moderator := NewChainModerator(NewAIModerator(provider), NewRuleModerator([]string{"spam"}, 0))
+		verdict, err := moderator.Moderate(context.Background(), content)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModerationReject, verdict.Decision)
+
+		moderator = NewChainModerator(NewAIModerator(provider))
+		verdict, err = moderator.Moderate(context.Background(), content)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModerationAllow, verdict.Decision)
+	})
+
+	t.Run("stops asking once rejected", func(t *testing.T) {
+		provider := NewFakeAIProvider()
+
+		moderator := NewChainModerator(NewRuleModerator([]string{"spam"}, 0), NewAIModerator(provider))
+		verdict, _ := moderator.Moderate(context.Background(), content)
+
+		assert.Equal(t, models.ModerationReject, verdict.Decision)
+		assert.Empty(t, provider.Requests)
+	})
+}
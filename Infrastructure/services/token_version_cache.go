@@ -0,0 +1,122 @@
+package services
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TokenVersionCache keeps users' token versions in memory for ttl so checking an access token
+// does not hit Mongo on every request. Bumps made through the cache take effect at once; other
+// instances see them once their entry expires.
+type TokenVersionCache struct {
+	userRepo   interfaces.UserRepository
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]tokenVersionEntry
+}
+
+type tokenVersionEntry struct {
+	version   int
+	expiresAt time.Time
+}
+
+func NewTokenVersionCache(userRepo interfaces.UserRepository, ttl time.Duration, maxEntries int) *TokenVersionCache {
+	return &TokenVersionCache{
+		userRepo:   userRepo,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]tokenVersionEntry),
+	}
+}
+
+// Current returns the user's token version, from memory while it is fresh
+func (c *TokenVersionCache) Current(ctx context.Context, userID string) (int, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.version, nil
+	}
+
+	user, err := c.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	c.store(userID, user.TokenVersion)
+	return user.TokenVersion, nil
+}
+
+// Bump increments the user's token version and remembers the new one
+func (c *TokenVersionCache) Bump(ctx context.Context, userID string) error {
+	version, err := c.userRepo.IncrementTokenVersion(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	c.store(userID, version)
+	return nil
+}
+
+// store remembers a version. A version never goes down, so a slow read finishing after a bump
+// cannot put the older one back.
+func (c *TokenVersionCache) store(userID string, version int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if entry, ok := c.entries[userID]; ok && entry.version > version && now.Before(entry.expiresAt) {
+		return
+	}
+	if len(c.entries) >= c.maxEntries {
+		c.evictExpired(now)
+	}
+	if len(c.entries) >= c.maxEntries {
+		// Still full of live entries; start over rather than grow without bound
+		c.entries = make(map[string]tokenVersionEntry)
+	}
+	c.entries[userID] = tokenVersionEntry{version: version, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *TokenVersionCache) evictExpired(now time.Time) {
+	for userID, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, userID)
+		}
+	}
+}
+
+// VersionCheckingTokenService rejects access tokens whose version is older than the user's
+// current one. Everything else is passed to the wrapped token service.
+type VersionCheckingTokenService struct {
+	interfaces.TokenService
+	versions interfaces.TokenVersionStore
+}
+
+func NewVersionCheckingTokenService(tokenService interfaces.TokenService, versions interfaces.TokenVersionStore) *VersionCheckingTokenService {
+	return &VersionCheckingTokenService{TokenService: tokenService, versions: versions}
+}
+
+// VerifyAccessToken verifies the token and then its version. A user that no longer exists has
+// no valid tokens.
+func (s *VersionCheckingTokenService) VerifyAccessToken(tokenStr string) (*models.UserAccessClaims, error) {
+	claims, err := s.TokenService.VerifyAccessToken(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.versions.Current(context.TODO(), claims.UserID)
+	if err != nil || claims.Version < current {
+		return nil, errors.New("token revoked")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTokenVersionCache_Current(t *testing.T) {
+	userRepo := &mocks.UserRepository{}
+	userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", TokenVersion: 2}, nil)
+
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	cache := NewTokenVersionCache(userRepo, 30*time.Second, 10)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		version, err := cache.Current(context.Background(), "user123")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, version)
+	}
+	userRepo.AssertNumberOfCalls(t, "GetUserByID", 1)
+
+	// Once the entry is stale the user is read again
+	now = now.Add(31 * time.Second)
+	_, err := cache.Current(context.Background(), "user123")
+
+	assert.NoError(t, err)
+	userRepo.AssertNumberOfCalls(t, "GetUserByID", 2)
+}
+
+func TestTokenVersionCache_Bump(t *testing.T) {
+	userRepo := &mocks.UserRepository{}
+	userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", TokenVersion: 2}, nil)
+	userRepo.On("IncrementTokenVersion", mock.Anything, "user123").Return(3, nil)
+
+	cache := NewTokenVersionCache(userRepo, 30*time.Second, 10)
+	_, err := cache.Current(context.Background(), "user123")
+	assert.NoError(t, err)
+
+	assert.NoError(t, cache.Bump(context.Background(), "user123"))
+	version, err := cache.Current(context.Background(), "user123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	userRepo.AssertNumberOfCalls(t, "GetUserByID", 1)
+
+	// A read that started before the bump cannot bring the old version back
+	cache.store("user123", 2)
+	version, _ = cache.Current(context.Background(), "user123")
+	assert.Equal(t, 3, version)
+}
+
+func TestTokenVersionCache_StaysBounded(t *testing.T) {
+	userRepo := &mocks.UserRepository{}
+	userRepo.On("GetUserByID", mock.Anything, mock.Anything).Return(models.User{}, nil)
+
+	cache := NewTokenVersionCache(userRepo, time.Minute, 2)
+	for _, userID := range []string{"user1", "user2", "user3"} {
+		_, err := cache.Current(context.Background(), userID)
+		assert.NoError(t, err)
+	}
+
+	assert.LessOrEqual(t, len(cache.entries), 2)
+}
+
+func TestVersionCheckingTokenService_VerifyAccessToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int
+		current     int
+		lookupErr   error
+		expectError string
+	}{
+		{name: "Success - Current version", version: 2, current: 2},
+		{name: "Success - Newer than the cached version", version: 3, current: 2},
+		{name: "Error - Revoked version", version: 1, current: 2, expectError: "token revoked"},
+		{name: "Error - User is gone", version: 2, lookupErr: errors.New("user not found"), expectError: "token revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenService := &mocks.MockTokenService{}
+			tokenService.On("VerifyAccessToken", "access.jwt.token").Return(&models.UserAccessClaims{UserID: "user123", Version: tt.version}, nil)
+			versions := &mocks.TokenVersionStoreMock{}
+			versions.On("Current", mock.Anything, "user123").Return(tt.current, tt.lookupErr)
+
+			claims, err := NewVersionCheckingTokenService(tokenService, versions).VerifyAccessToken("access.jwt.token")
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				assert.Nil(t, claims)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "user123", claims.UserID)
+			}
+		})
+	}
+}
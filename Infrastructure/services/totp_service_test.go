@@ -0,0 +1,93 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 seed from the RFC 6238 test vectors
+var rfc6238Secret = base32NoPadding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPService_VerifyCode(t *testing.T) {
+	otp := NewTOTPService("Blog API")
+	otp.now = func() time.Time { return time.Unix(59, 0) }
+
+	step, ok := otp.VerifyCode(rfc6238Secret, "287082")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	// One step of clock drift either way is tolerated
+	otp.now = func() time.Time { return time.Unix(89, 0) }
+	_, ok = otp.VerifyCode(rfc6238Secret, "287082")
+	assert.True(t, ok)
+
+	otp.now = func() time.Time { return time.Unix(150, 0) }
+	_, ok = otp.VerifyCode(rfc6238Secret, "287082")
+	assert.False(t, ok)
+
+	_, ok = otp.VerifyCode(rfc6238Secret, "abcdef")
+	assert.False(t, ok)
+	_, ok = otp.VerifyCode("not base32!", "287082")
+	assert.False(t, ok)
+}
+
+func TestTOTPService_GenerateSecret(t *testing.T) {
+	otp := NewTOTPService("Blog API")
+
+	secret, err := otp.GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	code := totpCodeAt(t, secret, otp.now())
+	_, ok := otp.VerifyCode(secret, code)
+	assert.True(t, ok)
+}
+
+func TestTOTPService_ProvisioningURI(t *testing.T) {
+	otp := NewTOTPService("Blog API")
+
+	uri, err := url.Parse(otp.ProvisioningURI("JBSWY3DPEHPK3PXP", "john@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Blog API:john@example.com", uri.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	assert.Equal(t, "Blog API", uri.Query().Get("issuer"))
+}
+
+func TestTOTPService_GenerateRecoveryCodes(t *testing.T) {
+	otp := NewTOTPService("Blog API")
+
+	codes, salt, err := otp.GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Len(t, salt, 32)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.False(t, seen[code], "recovery codes must be unique")
+		seen[code] = true
+	}
+}
+
+func TestTOTPService_HashRecoveryCode(t *testing.T) {
+	otp := NewTOTPService("Blog API")
+
+	hash := otp.HashRecoveryCode("salt1", "abcde-fghij")
+	assert.Equal(t, hash, otp.HashRecoveryCode("salt1", " ABCDEFGHIJ"))
+	// The same code of another user, or of another set, does not hash the same
+	assert.NotEqual(t, hash, otp.HashRecoveryCode("salt2", "abcde-fghij"))
+	assert.NotEqual(t, hash, otp.HashRecoveryCode("salt1", "abcde-fghik"))
+}
+
+func totpCodeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(secret))
+	assert.NoError(t, err)
+	return totpCode(key, at.Unix()/30)
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"blog-api/Domain/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EncodeCursor turns a cursor into the opaque token handed to clients as next_cursor/prev_cursor
+func EncodeCursor(cursor *models.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor reverses EncodeCursor
+func DecodeCursor(token string) (*models.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor models.Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+// CursorRequested reports whether a list that also supports offset pages should be cursor paginated.
+// Offset pages stay the default so existing clients keep their response shape, limit included;
+// a client opts in with pagination=cursor for the first page and passes a cursor after that.
+func CursorRequested(c *gin.Context) bool {
+	if c.Query("page") != "" {
+		return false
+	}
+	return c.Query("cursor") != "" || c.Query("pagination") == "cursor"
+}
+
+// ParsePageRequest reads the cursor, limit and with_total query parameters of a keyset-paginated list
+func ParsePageRequest(c *gin.Context, defaultLimit, maxLimit int) (models.PageRequest, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	if err != nil || limit < 1 || limit > maxLimit {
+		limit = defaultLimit
+	}
+
+	page := models.PageRequest{
+		Limit:     limit,
+		WithTotal: c.Query("with_total") == "true",
+	}
+
+	if token := c.Query("cursor"); token != "" {
+		cursor, err := DecodeCursor(token)
+		if err != nil {
+			return models.PageRequest{}, err
+		}
+		page.Cursor = cursor
+	}
+
+	return page, nil
+}
+
+// PageLinks is the pagination block of a keyset-paginated response
+type PageLinks struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
+// NewPageLinks encodes the neighbouring page cursors of a page
+func NewPageLinks(info models.PageInfo) PageLinks {
+	return PageLinks{
+		NextCursor: EncodeCursor(info.Next),
+		PrevCursor: EncodeCursor(info.Prev),
+		Total:      info.Total,
+	}
+}
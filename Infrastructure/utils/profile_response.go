@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"blog-api/Domain/models"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +16,16 @@ func SendSuccess(c *gin.Context, message string, data interface{}) {
 	})
 }
 
+// Standardized success response for a cursor-paginated list
+func SendPage(c *gin.Context, message string, data interface{}, info models.PageInfo) {
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    message,
+		"data":       data,
+		"pagination": NewPageLinks(info),
+	})
+}
+
 // Standardized error response
 func SendError(c *gin.Context, code int, message string) {
 	c.JSON(code, gin.H{
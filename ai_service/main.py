@@ -1,18 +1,23 @@
 from fastapi import FastAPI
+from fastapi.responses import StreamingResponse
 from pydantic import BaseModel
 from dotenv import load_dotenv
+import json
 import os
 import requests
 
 load_dotenv()
 API_KEY = os.getenv("GEMINI_API_KEY")
 GEMINI_ENDPOINT = "https://generativelanguage.googleapis.com/v1beta/models/gemini-2.0-flash:generateContent"
+GEMINI_STREAM_ENDPOINT = "https://generativelanguage.googleapis.com/v1beta/models/gemini-2.0-flash:streamGenerateContent?alt=sse"
 
 app = FastAPI()
 
 class SuggestRequest(BaseModel):
     keywords: list[str]
     tone: str
+    # Full prompt built by the API for the requested suggestion type; used as is when set
+    prompt: str | None = None
 
 def parse_suggestion(text: str) -> dict:
     lines = text.split('\n')
@@ -30,10 +35,9 @@ def parse_suggestion(text: str) -> dict:
             data["headlines"].append(line[2:].strip())
     return data
 
-@app.post("/generate")
-def generate_blog_suggestion(body: SuggestRequest):
-    prompt_text = f"Write a {body.tone} blog post idea using these keywords: {', '.join(body.keywords)}"
-    
+def gemini_request(body: SuggestRequest):
+    prompt_text = body.prompt or f"Write a {body.tone} blog post idea using these keywords: {', '.join(body.keywords)}"
+
     headers = {
         "Content-Type": "application/json",
         "X-goog-api-key": API_KEY
@@ -48,6 +52,11 @@ def generate_blog_suggestion(body: SuggestRequest):
             }
         ]
     }
+    return headers, payload
+
+@app.post("/generate")
+def generate_blog_suggestion(body: SuggestRequest):
+    headers, payload = gemini_request(body)
 
     response = requests.post(GEMINI_ENDPOINT, headers=headers, json=payload)
     data = response.json()
@@ -58,4 +67,53 @@ def generate_blog_suggestion(body: SuggestRequest):
         text = "Failed to generate content. Please try again."
         return {"error": text}
 
-    return parse_suggestion(text)
+    usage = data.get("usageMetadata", {})
+
+    # The raw text lets the API parse the layout of non-idea suggestion types
+    return {
+        **parse_suggestion(text),
+        "text": text,
+        "usage": {
+            "prompt_tokens": usage.get("promptTokenCount", 0),
+            "completion_tokens": usage.get("candidatesTokenCount", 0),
+        },
+    }
+
+def stream_event(data) -> str:
+    return f"data: {json.dumps(data)}\n\n"
+
+@app.post("/generate/stream")
+def stream_blog_suggestion(body: SuggestRequest):
+    """Relays the raw generated text as server-sent events: {"text": ...} for every chunk, then
+    [DONE], or {"error": ...} when Gemini fails mid-way. The API parses the text once it ends."""
+    headers, payload = gemini_request(body)
+
+    def chunks():
+        try:
+            with requests.post(GEMINI_STREAM_ENDPOINT, headers=headers, json=payload, stream=True) as response:
+                if response.status_code != 200:
+                    yield stream_event({"error": f"Gemini returned status {response.status_code}"})
+                    return
+                for line in response.iter_lines(decode_unicode=True):
+                    if not line or not line.startswith("data: "):
+                        continue
+                    try:
+                        data = json.loads(line[6:])
+                    except ValueError:
+                        continue
+                    if "error" in data:
+                        error = data["error"]
+                        message = error.get("message") if isinstance(error, dict) else str(error)
+                        yield stream_event({"error": message or "Gemini stream failed"})
+                        return
+                    try:
+                        text = data["candidates"][0]["content"]["parts"][0]["text"]
+                    except (KeyError, IndexError):
+                        continue
+                    yield stream_event({"text": text})
+        except requests.RequestException as exc:
+            yield stream_event({"error": f"Gemini stream failed: {exc}"})
+            return
+        yield "data: [DONE]\n\n"
+
+    return StreamingResponse(chunks(), media_type="text/event-stream")
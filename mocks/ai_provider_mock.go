@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AIProviderMock struct {
+	mock.Mock
+}
+
+func (m *AIProviderMock) Generate(ctx context.Context, req models.AIGenerationRequest) (models.AIGeneration, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(models.AIGeneration), args.Error(1)
+}
+
+func (m *AIProviderMock) Stream(ctx context.Context, req models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	args := m.Called(ctx, req, onToken)
+	return args.Get(0).(models.AIGeneration), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AIQuotaCounterRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AIQuotaCounterRepositoryMock) ReserveAIRequest(key string, requestLimit, tokenLimit int64, expiresAt time.Time) (bool, error) {
+	args := m.Called(key, requestLimit, tokenLimit, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AIQuotaCounterRepositoryMock) AddAIUsage(key string, requests, tokens int64) error {
+	args := m.Called(key, requests, tokens)
+	return args.Error(0)
+}
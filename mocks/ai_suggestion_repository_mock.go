@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AISuggestionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AISuggestionRepositoryMock) CreateAISuggestion(suggestion models.AISuggestion) (models.AISuggestion, error) {
+	args := m.Called(suggestion)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) GetAISuggestionByID(suggestionID string) (models.AISuggestion, error) {
+	args := m.Called(suggestionID)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) GetAISuggestionsByUserID(userID string, page, limit int) ([]models.AISuggestion, error) {
+	args := m.Called(userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) UpdateAISuggestion(suggestion models.AISuggestion) (models.AISuggestion, error) {
+	args := m.Called(suggestion)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) DeleteAISuggestion(suggestionID string) error {
+	args := m.Called(suggestionID)
+	return args.Error(0)
+}
+
+func (m *AISuggestionRepositoryMock) GetAISuggestionsByStatus(userID string, status string, page, limit int) ([]models.AISuggestion, error) {
+	args := m.Called(userID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) GetAISuggestionsByCursor(userID string, status string, page models.PageRequest) ([]models.AISuggestion, bool, error) {
+	args := m.Called(userID, status, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]models.AISuggestion), args.Bool(1), args.Error(2)
+}
+
+func (m *AISuggestionRepositoryMock) MarkAISuggestionConverted(suggestionID string) (bool, error) {
+	args := m.Called(suggestionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) CountAISuggestions(userID string, status string) (int64, error) {
+	args := m.Called(userID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *AISuggestionRepositoryMock) ConvertSuggestionToDraft(suggestionID string, userID string) (models.Blog, error) {
+	args := m.Called(suggestionID, userID)
+	return args.Get(0).(models.Blog), args.Error(1)
+}
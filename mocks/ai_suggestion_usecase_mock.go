@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AISuggestionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *AISuggestionUseCaseMock) CreateAISuggestion(suggestion models.AISuggestion) (models.AISuggestion, error) {
+	args := m.Called(suggestion)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) GetAISuggestionByID(suggestionID string, userID string) (models.AISuggestion, error) {
+	args := m.Called(suggestionID, userID)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) GetAISuggestionsByUserID(userID string, page, limit int) ([]models.AISuggestion, error) {
+	args := m.Called(userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) UpdateAISuggestion(suggestion models.AISuggestion, userID string) (models.AISuggestion, error) {
+	args := m.Called(suggestion, userID)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) GenerateSuggestion(ctx context.Context, request models.AIGenerationRequest) (models.AIGeneration, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(models.AIGeneration), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) StreamSuggestion(ctx context.Context, request models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	args := m.Called(ctx, request, onToken)
+	return args.Get(0).(models.AIGeneration), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) DeleteAISuggestion(suggestionID string, userID string) error {
+	args := m.Called(suggestionID, userID)
+	return args.Error(0)
+}
+
+func (m *AISuggestionUseCaseMock) GetAISuggestionsByStatus(userID string, status string, page, limit int) ([]models.AISuggestion, error) {
+	args := m.Called(userID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AISuggestion), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) GetAISuggestionsByCursor(userID string, status string, page models.PageRequest) ([]models.AISuggestion, models.PageInfo, error) {
+	args := m.Called(userID, status, page)
+	if args.Get(0) == nil {
+		return nil, models.PageInfo{}, args.Error(2)
+	}
+	return args.Get(0).([]models.AISuggestion), args.Get(1).(models.PageInfo), args.Error(2)
+}
+
+func (m *AISuggestionUseCaseMock) ConvertSuggestionToDraft(ctx context.Context, suggestionID string, userID string, expand bool) (models.Blog, error) {
+	args := m.Called(ctx, suggestionID, userID, expand)
+	return args.Get(0).(models.Blog), args.Error(1)
+}
+
+func (m *AISuggestionUseCaseMock) SaveAISuggestion(userID string, inputTopic string, keywords []string, tone string, suggestions []string) (models.AISuggestion, error) {
+	args := m.Called(userID, inputTopic, keywords, tone, suggestions)
+	return args.Get(0).(models.AISuggestion), args.Error(1)
+}
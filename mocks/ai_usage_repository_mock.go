@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AIUsageRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AIUsageRepositoryMock) RecordAIUsage(record models.AIUsageRecord) (models.AIUsageRecord, error) {
+	args := m.Called(record)
+	return args.Get(0).(models.AIUsageRecord), args.Error(1)
+}
+
+func (m *AIUsageRepositoryMock) SumAIUsage(userID string, since time.Time) (models.AIUsageTotals, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).(models.AIUsageTotals), args.Error(1)
+}
+
+func (m *AIUsageRepositoryMock) GetAIUsageSummaries(from, to time.Time) ([]models.AIUsageSummary, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AIUsageSummary), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AIUsageUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *AIUsageUseCaseMock) GetUsageStatus(userID string, role string) (models.AIUsageStatus, error) {
+	args := m.Called(userID, role)
+	return args.Get(0).(models.AIUsageStatus), args.Error(1)
+}
+
+func (m *AIUsageUseCaseMock) ReserveRequest(userID string, role string) (models.AIQuotaReservation, error) {
+	args := m.Called(userID, role)
+	return args.Get(0).(models.AIQuotaReservation), args.Error(1)
+}
+
+func (m *AIUsageUseCaseMock) SettleRequest(reservation models.AIQuotaReservation, record models.AIUsageRecord) error {
+	args := m.Called(reservation, record)
+	return args.Error(0)
+}
+
+func (m *AIUsageUseCaseMock) ReleaseRequest(reservation models.AIQuotaReservation) error {
+	args := m.Called(reservation)
+	return args.Error(0)
+}
+
+func (m *AIUsageUseCaseMock) GetUsageReport(from, to time.Time) (models.AIUsageReport, error) {
+	args := m.Called(from, to)
+	return args.Get(0).(models.AIUsageReport), args.Error(1)
+}
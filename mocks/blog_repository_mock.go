@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"blog-api/Domain/models"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -23,27 +24,81 @@ func (m *BlogRepositoryMock) GetPaginatedBlogs(page, limit int) ([]models.Blog,
 	return args.Get(0).([]models.Blog), args.Error(1)
 }
 
+func (m *BlogRepositoryMock) GetBlogsByCursor(page models.PageRequest) ([]models.Blog, bool, error) {
+	args := m.Called(page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]models.Blog), args.Bool(1), args.Error(2)
+}
+
+func (m *BlogRepositoryMock) CountPublishedBlogs() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *BlogRepositoryMock) GetBlogsByAuthorsCursor(authorIDs []string, page models.PageRequest) ([]models.Blog, bool, error) {
+	args := m.Called(authorIDs, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]models.Blog), args.Bool(1), args.Error(2)
+}
+
+func (m *BlogRepositoryMock) CountPublishedBlogsByAuthors(authorIDs []string) (int64, error) {
+	args := m.Called(authorIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *BlogRepositoryMock) GetBlogByID(blogID string) (models.Blog, error) {
 	args := m.Called(blogID)
 	return args.Get(0).(models.Blog), args.Error(1)
 }
 
-func (m *BlogRepositoryMock) UpdateBlog(blog models.Blog) (models.Blog, error) {
-	args := m.Called(blog)
+func (m *BlogRepositoryMock) UpdateBlog(blog models.Blog, expectedVersion int) (models.Blog, error) {
+	args := m.Called(blog, expectedVersion)
 	return args.Get(0).(models.Blog), args.Error(1)
 }
 
+func (m *BlogRepositoryMock) UpdateSlug(blogID, slug string) error {
+	args := m.Called(blogID, slug)
+	return args.Error(0)
+}
+
+func (m *BlogRepositoryMock) GetBlogsWithoutSlug() ([]models.Blog, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Blog), args.Error(1)
+}
+
+func (m *BlogRepositoryMock) SetModerationStatus(blogID, status string, publish bool) error {
+	args := m.Called(blogID, status, publish)
+	return args.Error(0)
+}
+
+func (m *BlogRepositoryMock) ReplaceTags(sources []string, target string) (int64, error) {
+	args := m.Called(sources, target)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *BlogRepositoryMock) DeleteBlog(blogID string) error {
 	args := m.Called(blogID)
 	return args.Error(0)
 }
 
-func (m *BlogRepositoryMock) SearchBlogs(query string) ([]models.Blog, error) {
-	args := m.Called(query)
+func (m *BlogRepositoryMock) SearchBlogs(query string, page, limit int) ([]models.BlogSearchResult, error) {
+	args := m.Called(query, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Blog), args.Error(1)
+	return args.Get(0).([]models.BlogSearchResult), args.Error(1)
+}
+
+func (m *BlogRepositoryMock) CountSearchResults(query string) (int64, error) {
+	args := m.Called(query)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *BlogRepositoryMock) FilterBlogs(tags []string, dateRange [2]string, sortBy string) ([]models.Blog, error) {
@@ -69,15 +124,36 @@ func (m *BlogRepositoryMock) UpdateDislikes(blogID string, increment bool) error
 	return args.Error(0)
 }
 
-func (m *BlogRepositoryMock) AddComment(blogID string, comment models.Comment) (models.Comment, error) {
-	args := m.Called(blogID, comment)
-	return args.Get(0).(models.Comment), args.Error(1)
+func (m *BlogRepositoryMock) GetScheduledBlogs(authorID string) ([]models.Blog, error) {
+	args := m.Called(authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Blog), args.Error(1)
+}
+
+func (m *BlogRepositoryMock) PublishDueBlog(now time.Time) (*models.Blog, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Blog), args.Error(1)
 }
 
-func (m *BlogRepositoryMock) GetComments(blogID string) ([]models.Comment, error) {
-	args := m.Called(blogID)
+func (m *BlogRepositoryMock) GetBlogsByAuthor(authorID, status string, page, limit int) ([]models.Blog, error) {
+	args := m.Called(authorID, status, page, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Comment), args.Error(1)
+	return args.Get(0).([]models.Blog), args.Error(1)
+}
+
+func (m *BlogRepositoryMock) CountBlogsByAuthor(authorID, status string) (int64, error) {
+	args := m.Called(authorID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *BlogRepositoryMock) QueryBlogs(query models.BlogQuery) (models.BlogQueryResult, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.BlogQueryResult), args.Error(1)
 }
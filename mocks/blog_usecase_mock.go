@@ -15,12 +15,20 @@ func (m *BlogUseCaseMock) CreateBlog(blog models.Blog) (models.Blog, error) {
 	return args.Get(0).(models.Blog), args.Error(1)
 }
 
-func (m *BlogUseCaseMock) GetPaginatedBlogs(page, limit int) ([]models.Blog, error) {
+func (m *BlogUseCaseMock) GetPaginatedBlogs(page, limit int) ([]models.Blog, int64, error) {
 	args := m.Called(page, limit)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]models.Blog), args.Error(1)
+	return args.Get(0).([]models.Blog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *BlogUseCaseMock) GetBlogsByCursor(page models.PageRequest) ([]models.Blog, models.PageInfo, error) {
+	args := m.Called(page)
+	if args.Get(0) == nil {
+		return nil, models.PageInfo{}, args.Error(2)
+	}
+	return args.Get(0).([]models.Blog), args.Get(1).(models.PageInfo), args.Error(2)
 }
 
 func (m *BlogUseCaseMock) GetBlogByID(blogID string) (models.Blog, error) {
@@ -28,6 +36,16 @@ func (m *BlogUseCaseMock) GetBlogByID(blogID string) (models.Blog, error) {
 	return args.Get(0).(models.Blog), args.Error(1)
 }
 
+func (m *BlogUseCaseMock) GetBlogBySlug(slug string) (models.Blog, error) {
+	args := m.Called(slug)
+	return args.Get(0).(models.Blog), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) BackfillSlugs() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
 func (m *BlogUseCaseMock) UpdateBlog(blog models.Blog) (models.Blog, error) {
 	args := m.Called(blog)
 	return args.Get(0).(models.Blog), args.Error(1)
@@ -38,12 +56,12 @@ func (m *BlogUseCaseMock) DeleteBlog(blogID string) error {
 	return args.Error(0)
 }
 
-func (m *BlogUseCaseMock) SearchBlogs(query string) ([]models.Blog, error) {
-	args := m.Called(query)
+func (m *BlogUseCaseMock) SearchBlogs(query string, page, limit int) ([]models.BlogSearchResult, int64, error) {
+	args := m.Called(query, page, limit)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]models.Blog), args.Error(1)
+	return args.Get(0).([]models.BlogSearchResult), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *BlogUseCaseMock) FilterBlogs(tags []string, dateRange [2]string, sortBy string) ([]models.Blog, error) {
@@ -74,10 +92,97 @@ func (m *BlogUseCaseMock) AddComment(blogID string, comment models.Comment) (mod
 	return args.Get(0).(models.Comment), args.Error(1)
 }
 
-func (m *BlogUseCaseMock) GetComments(blogID string) ([]models.Comment, error) {
+func (m *BlogUseCaseMock) GetComments(blogID string, page, limit int) ([]models.Comment, int64, error) {
+	args := m.Called(blogID, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.Comment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *BlogUseCaseMock) GetCommentsByCursor(blogID string, page models.PageRequest) ([]models.Comment, models.PageInfo, error) {
+	args := m.Called(blogID, page)
+	if args.Get(0) == nil {
+		return nil, models.PageInfo{}, args.Error(2)
+	}
+	return args.Get(0).([]models.Comment), args.Get(1).(models.PageInfo), args.Error(2)
+}
+
+func (m *BlogUseCaseMock) GetCommentByID(commentID string) (models.Comment, error) {
+	args := m.Called(commentID)
+	return args.Get(0).(models.Comment), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) UpdateComment(commentID string, content string) (models.Comment, error) {
+	args := m.Called(commentID, content)
+	return args.Get(0).(models.Comment), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) DeleteComment(commentID string) error {
+	args := m.Called(commentID)
+	return args.Error(0)
+}
+
+func (m *BlogUseCaseMock) ReactToBlog(blogID, userID, reactionType string) (models.Reaction, error) {
+	args := m.Called(blogID, userID, reactionType)
+	return args.Get(0).(models.Reaction), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) RemoveReaction(blogID, userID, reactionType string) error {
+	args := m.Called(blogID, userID, reactionType)
+	return args.Error(0)
+}
+
+func (m *BlogUseCaseMock) GetUserReaction(blogID, userID string) (string, error) {
+	args := m.Called(blogID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) GetRevisions(blogID string) ([]models.BlogRevision, error) {
 	args := m.Called(blogID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Comment), args.Error(1)
+	return args.Get(0).([]models.BlogRevision), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) GetRevision(blogID string, version int) (models.BlogRevision, error) {
+	args := m.Called(blogID, version)
+	return args.Get(0).(models.BlogRevision), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) DiffRevisions(blogID string, fromVersion, toVersion int) (models.RevisionDiff, error) {
+	args := m.Called(blogID, fromVersion, toVersion)
+	return args.Get(0).(models.RevisionDiff), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) RestoreRevision(blogID string, version int) (models.Blog, error) {
+	args := m.Called(blogID, version)
+	return args.Get(0).(models.Blog), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) GetScheduledBlogs(authorID string) ([]models.Blog, error) {
+	args := m.Called(authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Blog), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) PublishDueBlogs() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *BlogUseCaseMock) GetMyBlogs(authorID, status string, page, limit int) ([]models.Blog, int64, error) {
+	args := m.Called(authorID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.Blog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *BlogUseCaseMock) QueryBlogs(query models.BlogQuery) (models.BlogQueryResult, error) {
+	args := m.Called(query)
+	return args.Get(0).(models.BlogQueryResult), args.Error(1)
 }
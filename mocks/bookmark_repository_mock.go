@@ -0,0 +1,90 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BookmarkRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *BookmarkRepositoryMock) CreateCollection(collection models.BookmarkCollection) (models.BookmarkCollection, error) {
+	args := m.Called(collection)
+	return args.Get(0).(models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) GetCollectionByID(collectionID string) (models.BookmarkCollection, error) {
+	args := m.Called(collectionID)
+	return args.Get(0).(models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) GetCollectionByName(userID, name string) (models.BookmarkCollection, error) {
+	args := m.Called(userID, name)
+	return args.Get(0).(models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) GetCollections(userID string) ([]models.BookmarkCollection, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) RenameCollection(collectionID, name string) error {
+	args := m.Called(collectionID, name)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepositoryMock) DeleteCollection(collectionID string) error {
+	args := m.Called(collectionID)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepositoryMock) AddBookmark(bookmark models.Bookmark) (models.Bookmark, bool, error) {
+	args := m.Called(bookmark)
+	return args.Get(0).(models.Bookmark), args.Bool(1), args.Error(2)
+}
+
+func (m *BookmarkRepositoryMock) GetBookmarkByID(bookmarkID string) (models.Bookmark, error) {
+	args := m.Called(bookmarkID)
+	return args.Get(0).(models.Bookmark), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) GetBookmarks(collectionID string, unreadOnly bool, page, limit int) ([]models.Bookmark, error) {
+	args := m.Called(collectionID, unreadOnly, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Bookmark), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) CountBookmarks(collectionID string, unreadOnly bool) (int64, error) {
+	args := m.Called(collectionID, unreadOnly)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) GetBookmarkIDs(collectionID string) ([]string, error) {
+	args := m.Called(collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *BookmarkRepositoryMock) SetPositions(collectionID string, bookmarkIDs []string) error {
+	args := m.Called(collectionID, bookmarkIDs)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepositoryMock) SetRead(bookmarkID string, read bool) error {
+	args := m.Called(bookmarkID, read)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepositoryMock) RemoveBookmark(bookmarkID string) error {
+	args := m.Called(bookmarkID)
+	return args.Error(0)
+}
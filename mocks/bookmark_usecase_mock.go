@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BookmarkUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *BookmarkUseCaseMock) CreateCollection(userID, name string) (models.BookmarkCollection, error) {
+	args := m.Called(userID, name)
+	return args.Get(0).(models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkUseCaseMock) GetCollections(userID string) ([]models.BookmarkCollection, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkUseCaseMock) RenameCollection(userID, collectionID, name string) (models.BookmarkCollection, error) {
+	args := m.Called(userID, collectionID, name)
+	return args.Get(0).(models.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkUseCaseMock) DeleteCollection(userID, collectionID string) error {
+	args := m.Called(userID, collectionID)
+	return args.Error(0)
+}
+
+func (m *BookmarkUseCaseMock) AddBookmark(userID, blogID, collectionID string) (models.Bookmark, error) {
+	args := m.Called(userID, blogID, collectionID)
+	return args.Get(0).(models.Bookmark), args.Error(1)
+}
+
+func (m *BookmarkUseCaseMock) GetBookmarks(userID, collectionID string, unreadOnly bool, page, limit int) ([]models.Bookmark, int64, error) {
+	args := m.Called(userID, collectionID, unreadOnly, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.Bookmark), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *BookmarkUseCaseMock) ReorderBookmarks(userID, collectionID string, bookmarkIDs []string) error {
+	args := m.Called(userID, collectionID, bookmarkIDs)
+	return args.Error(0)
+}
+
+func (m *BookmarkUseCaseMock) MarkRead(userID, bookmarkID string, read bool) (models.Bookmark, error) {
+	args := m.Called(userID, bookmarkID, read)
+	return args.Get(0).(models.Bookmark), args.Error(1)
+}
+
+func (m *BookmarkUseCaseMock) RemoveBookmark(userID, bookmarkID string) error {
+	args := m.Called(userID, bookmarkID)
+	return args.Error(0)
+}
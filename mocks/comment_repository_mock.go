@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CommentRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CommentRepositoryMock) CreateComment(comment models.Comment) (models.Comment, error) {
+	args := m.Called(comment)
+	return args.Get(0).(models.Comment), args.Error(1)
+}
+
+func (m *CommentRepositoryMock) GetCommentByID(commentID string) (models.Comment, error) {
+	args := m.Called(commentID)
+	return args.Get(0).(models.Comment), args.Error(1)
+}
+
+func (m *CommentRepositoryMock) UpdateComment(commentID string, content string) (models.Comment, error) {
+	args := m.Called(commentID, content)
+	return args.Get(0).(models.Comment), args.Error(1)
+}
+
+func (m *CommentRepositoryMock) SoftDeleteComment(commentID string) error {
+	args := m.Called(commentID)
+	return args.Error(0)
+}
+
+func (m *CommentRepositoryMock) SetModerationStatus(commentID, status string) error {
+	args := m.Called(commentID, status)
+	return args.Error(0)
+}
+
+func (m *CommentRepositoryMock) GetRootComments(blogID string, page, limit int) ([]models.Comment, error) {
+	args := m.Called(blogID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Comment), args.Error(1)
+}
+
+func (m *CommentRepositoryMock) GetRootCommentsByCursor(blogID string, page models.PageRequest) ([]models.Comment, bool, error) {
+	args := m.Called(blogID, page)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).([]models.Comment), args.Bool(1), args.Error(2)
+}
+
+func (m *CommentRepositoryMock) CountRootComments(blogID string) (int64, error) {
+	args := m.Called(blogID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *CommentRepositoryMock) GetThreadReplies(rootIDs []string) ([]models.Comment, error) {
+	args := m.Called(rootIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Comment), args.Error(1)
+}
@@ -2,6 +2,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -23,3 +25,18 @@ func (m *MockEmailService) SendPasswordResetEmail(username, email, token string)
 	args := m.Called(username, email, token)
 	return args.Error(0)
 }
+
+func (m *MockEmailService) SendModerationResultEmail(username, email, title, status, note string) error {
+	args := m.Called(username, email, title, status, note)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendWarningEmail(username, email, reason string) error {
+	args := m.Called(username, email, reason)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendSuspensionEmail(username, email, reason string, until time.Time) error {
+	args := m.Called(username, email, reason, until)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type FollowRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *FollowRepositoryMock) Follow(followerID, followeeID string) (bool, error) {
+	args := m.Called(followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *FollowRepositoryMock) Unfollow(followerID, followeeID string) (bool, error) {
+	args := m.Called(followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *FollowRepositoryMock) IsFollowing(followerID, followeeID string) (bool, error) {
+	args := m.Called(followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *FollowRepositoryMock) GetFollowing(followerID string) ([]string, error) {
+	args := m.Called(followerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
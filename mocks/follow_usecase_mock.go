@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FollowUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *FollowUseCaseMock) Follow(ctx context.Context, followerID, authorID string) error {
+	args := m.Called(ctx, followerID, authorID)
+	return args.Error(0)
+}
+
+func (m *FollowUseCaseMock) Unfollow(ctx context.Context, followerID, authorID string) error {
+	args := m.Called(ctx, followerID, authorID)
+	return args.Error(0)
+}
+
+func (m *FollowUseCaseMock) GetAuthorProfile(ctx context.Context, authorID, viewerID string) (models.AuthorProfile, error) {
+	args := m.Called(ctx, authorID, viewerID)
+	return args.Get(0).(models.AuthorProfile), args.Error(1)
+}
+
+func (m *FollowUseCaseMock) GetFeed(userID string, page models.PageRequest) ([]models.Blog, models.PageInfo, error) {
+	args := m.Called(userID, page)
+	return args.Get(0).([]models.Blog), args.Get(1).(models.PageInfo), args.Error(2)
+}
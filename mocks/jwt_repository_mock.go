@@ -29,3 +29,26 @@ func (m *MockTokenRepository) GetToken(tokenID string) (*models.Token, error) {
 	args := m.Called(tokenID)
 	return args.Get(0).(*models.Token), args.Error(1)
 }
+
+func (m *MockTokenRepository) RotateToken(tokenID string) (bool, error) {
+	args := m.Called(tokenID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) DeleteTokenFamily(familyID string) error {
+	args := m.Called(familyID)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) GetActiveTokens(userID string) ([]*models.Token, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Token), args.Error(1)
+}
+
+func (m *MockTokenRepository) DeleteUserTokens(userID, keepFamilyID string) (int64, error) {
+	args := m.Called(userID, keepFamilyID)
+	return args.Get(0).(int64), args.Error(1)
+}
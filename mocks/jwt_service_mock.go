@@ -11,8 +11,8 @@ type MockTokenService struct {
 	mock.Mock
 }
 
-func (m *MockTokenService) GenerateAccessToken(userID, email, role string) (string, error) {
-	args := m.Called(userID, email, role)
+func (m *MockTokenService) GenerateAccessToken(userID, email, role string, version int, mfa bool) (string, error) {
+	args := m.Called(userID, email, role, version, mfa)
 	return args.String(0), args.Error(1)
 }
 
@@ -50,3 +50,13 @@ func (m *MockTokenService) VerifyToken(hashed, token string) bool {
 	args := m.Called(hashed, token)
 	return args.Bool(0)
 }
+
+func (m *MockTokenService) GenerateMFAToken(userID string) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenService) VerifyMFAToken(tokenStr string) (string, error) {
+	args := m.Called(tokenStr)
+	return args.String(0), args.Error(1)
+}
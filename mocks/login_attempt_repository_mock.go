@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginAttemptRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *LoginAttemptRepositoryMock) GetAttempts(key string) (models.AttemptCounter, error) {
+	args := m.Called(key)
+	return args.Get(0).(models.AttemptCounter), args.Error(1)
+}
+
+func (m *LoginAttemptRepositoryMock) ReserveAttempt(seen models.AttemptCounter, now time.Time, window time.Duration) (bool, error) {
+	args := m.Called(seen, now, window)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *LoginAttemptRepositoryMock) ReleaseAttempt(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *LoginAttemptRepositoryMock) ClearAttempts(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
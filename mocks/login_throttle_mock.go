@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginThrottleMock struct {
+	mock.Mock
+}
+
+func (m *LoginThrottleMock) AttemptLogin(email, ip string) error {
+	args := m.Called(email, ip)
+	return args.Error(0)
+}
+
+func (m *LoginThrottleMock) LoginSucceeded(email, ip string) error {
+	args := m.Called(email, ip)
+	return args.Error(0)
+}
+
+func (m *LoginThrottleMock) AllowPasswordReset(email, ip string) error {
+	args := m.Called(email, ip)
+	return args.Error(0)
+}
+
+func (m *LoginThrottleMock) UnlockAccount(email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MFAUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *MFAUseCaseMock) GetStatus(ctx context.Context, userID string) (models.MFAStatus, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(models.MFAStatus), args.Error(1)
+}
+
+func (m *MFAUseCaseMock) Enroll(ctx context.Context, userID, password string, client models.ClientInfo) (models.MFAEnrollment, error) {
+	args := m.Called(ctx, userID, password, client)
+	return args.Get(0).(models.MFAEnrollment), args.Error(1)
+}
+
+func (m *MFAUseCaseMock) Confirm(ctx context.Context, userID, password, code string, client models.ClientInfo) ([]string, error) {
+	args := m.Called(ctx, userID, password, code, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MFAUseCaseMock) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MFAUseCaseMock) Disable(ctx context.Context, userID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MFAUseCaseMock) RequiredFor(role string) bool {
+	args := m.Called(role)
+	return args.Bool(0)
+}
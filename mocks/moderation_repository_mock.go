@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ModerationRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ModerationRepositoryMock) QueueItem(item models.ModerationItem) (models.ModerationItem, error) {
+	args := m.Called(item)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationRepositoryMock) GetItemByID(itemID string) (models.ModerationItem, error) {
+	args := m.Called(itemID)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationRepositoryMock) GetPendingItem(contentType, contentID string) (models.ModerationItem, error) {
+	args := m.Called(contentType, contentID)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationRepositoryMock) GetItems(status string, page, limit int) ([]models.ModerationItem, error) {
+	args := m.Called(status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationRepositoryMock) CountItems(status string) (int64, error) {
+	args := m.Called(status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ModerationRepositoryMock) ResolveItem(itemID, status, reviewerID, note string) (models.ModerationItem, error) {
+	args := m.Called(itemID, status, reviewerID, note)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
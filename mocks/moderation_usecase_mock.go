@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ModerationUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ModerationUseCaseMock) Check(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error) {
+	args := m.Called(ctx, content)
+	return args.Get(0).(models.ModerationVerdict), args.Error(1)
+}
+
+func (m *ModerationUseCaseMock) Hold(content models.ModerationContent, verdict models.ModerationVerdict, publish bool) (models.ModerationItem, error) {
+	args := m.Called(content, verdict, publish)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationUseCaseMock) Flag(contentType, contentID string, reasons []string) (models.ModerationItem, error) {
+	args := m.Called(contentType, contentID, reasons)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationUseCaseMock) GetQueue(status string, page, limit int) ([]models.ModerationItem, int64, error) {
+	args := m.Called(status, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.ModerationItem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ModerationUseCaseMock) GetItem(itemID string) (models.ModerationItem, error) {
+	args := m.Called(itemID)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationUseCaseMock) GetPendingItem(contentType, contentID string) (models.ModerationItem, error) {
+	args := m.Called(contentType, contentID)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
+
+func (m *ModerationUseCaseMock) Review(ctx context.Context, itemID, reviewerID, status, note string) (models.ModerationItem, error) {
+	args := m.Called(ctx, itemID, reviewerID, status, note)
+	return args.Get(0).(models.ModerationItem), args.Error(1)
+}
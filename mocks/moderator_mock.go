@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ModeratorMock struct {
+	mock.Mock
+}
+
+func (m *ModeratorMock) Moderate(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error) {
+	args := m.Called(ctx, content)
+	return args.Get(0).(models.ModerationVerdict), args.Error(1)
+}
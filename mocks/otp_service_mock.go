@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type OTPServiceMock struct {
+	mock.Mock
+}
+
+func (m *OTPServiceMock) GenerateSecret() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *OTPServiceMock) ProvisioningURI(secret, accountName string) string {
+	args := m.Called(secret, accountName)
+	return args.String(0)
+}
+
+func (m *OTPServiceMock) VerifyCode(secret, code string) (int64, bool) {
+	args := m.Called(secret, code)
+	return args.Get(0).(int64), args.Bool(1)
+}
+
+func (m *OTPServiceMock) GenerateRecoveryCodes(n int) ([]string, string, error) {
+	args := m.Called(n)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]string), args.String(1), args.Error(2)
+}
+
+func (m *OTPServiceMock) HashRecoveryCode(salt, code string) string {
+	args := m.Called(salt, code)
+	return args.String(0)
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReactionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ReactionRepositoryMock) SetReaction(userID, blogID, reactionType string) (models.Reaction, error) {
+	args := m.Called(userID, blogID, reactionType)
+	return args.Get(0).(models.Reaction), args.Error(1)
+}
+
+func (m *ReactionRepositoryMock) RemoveReaction(userID, blogID, reactionType string) error {
+	args := m.Called(userID, blogID, reactionType)
+	return args.Error(0)
+}
+
+func (m *ReactionRepositoryMock) GetReaction(userID, blogID string) (*models.Reaction, error) {
+	args := m.Called(userID, blogID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reaction), args.Error(1)
+}
+
+func (m *ReactionRepositoryMock) DeleteBlogReactions(blogID string) error {
+	args := m.Called(blogID)
+	return args.Error(0)
+}
@@ -0,0 +1,104 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RecommendationServiceMock struct {
+	mock.Mock
+}
+
+func (m *RecommendationServiceMock) TrackUserAction(userID, blogID, action string) error {
+	args := m.Called(userID, blogID, action)
+	return args.Error(0)
+}
+
+func (m *RecommendationServiceMock) GetUserBehaviorSummary(userID string) (map[string]interface{}, error) {
+	args := m.Called(userID)
+	summary, _ := args.Get(0).(map[string]interface{})
+	return summary, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) CalculateSimilarity(blog1, blog2 models.Blog) float64 {
+	args := m.Called(blog1, blog2)
+	return args.Get(0).(float64)
+}
+
+func (m *RecommendationServiceMock) FindSimilarContent(blogID string, limit int) ([]models.Blog, error) {
+	args := m.Called(blogID, limit)
+	blogs, _ := args.Get(0).([]models.Blog)
+	return blogs, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) UpdateUserInterests(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *RecommendationServiceMock) GetUserInterestProfile(userID string) ([]models.UserInterest, error) {
+	args := m.Called(userID)
+	interests, _ := args.Get(0).([]models.UserInterest)
+	return interests, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) GenerateUserRecommendations(userID string, limit int) ([]models.UserRecommendation, error) {
+	args := m.Called(userID, limit)
+	recommendations, _ := args.Get(0).([]models.UserRecommendation)
+	return recommendations, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) GetRecommendations(request models.RecommendationRequest) (models.RecommendationResponse, error) {
+	args := m.Called(request)
+	return args.Get(0).(models.RecommendationResponse), args.Error(1)
+}
+
+func (m *RecommendationServiceMock) MarkRecommendationViewed(recommendationID string) error {
+	args := m.Called(recommendationID)
+	return args.Error(0)
+}
+
+func (m *RecommendationServiceMock) GetTrendingContent(limit int) ([]models.Blog, error) {
+	args := m.Called(limit)
+	blogs, _ := args.Get(0).([]models.Blog)
+	return blogs, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) GetPopularContent(limit int) ([]models.Blog, error) {
+	args := m.Called(limit)
+	blogs, _ := args.Get(0).([]models.Blog)
+	return blogs, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) GetNewContent(limit int) ([]models.Blog, error) {
+	args := m.Called(limit)
+	blogs, _ := args.Get(0).([]models.Blog)
+	return blogs, args.Error(1)
+}
+
+func (m *RecommendationServiceMock) ProcessContentSimilarities() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *RecommendationServiceMock) ProcessUserRecommendations() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *RecommendationServiceMock) CleanupOldData() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *RecommendationServiceMock) GetRecommendationAnalytics(userID string) (models.RecommendationStats, error) {
+	args := m.Called(userID)
+	return args.Get(0).(models.RecommendationStats), args.Error(1)
+}
+
+func (m *RecommendationServiceMock) GetSystemRecommendationStats() (map[string]interface{}, error) {
+	args := m.Called()
+	stats, _ := args.Get(0).(map[string]interface{})
+	return stats, args.Error(1)
+}
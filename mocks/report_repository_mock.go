@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ReportRepositoryMock) CreateReport(report models.Report) (models.Report, error) {
+	args := m.Called(report)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) GetReportByID(reportID string) (models.Report, error) {
+	args := m.Called(reportID)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) GetReports(status string, page, limit int) ([]models.Report, error) {
+	args := m.Called(status, page, limit)
+	return args.Get(0).([]models.Report), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) CountReports(status string) (int64, error) {
+	args := m.Called(status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) CountOpenReports(contentType, contentID string) (int64, error) {
+	args := m.Called(contentType, contentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ReportRepositoryMock) ResolveReports(contentType, contentID, resolverID, action, note string) (int64, error) {
+	args := m.Called(contentType, contentID, resolverID, action, note)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ReportUseCaseMock) ReportBlog(blogID, reporterID, reason, details string) (models.Report, error) {
+	args := m.Called(blogID, reporterID, reason, details)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *ReportUseCaseMock) ReportComment(blogID, commentID, reporterID, reason, details string) (models.Report, error) {
+	args := m.Called(blogID, commentID, reporterID, reason, details)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *ReportUseCaseMock) GetReports(status string, page, limit int) ([]models.Report, int64, error) {
+	args := m.Called(status, page, limit)
+	return args.Get(0).([]models.Report), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ReportUseCaseMock) GetReport(reportID string) (models.Report, error) {
+	args := m.Called(reportID)
+	return args.Get(0).(models.Report), args.Error(1)
+}
+
+func (m *ReportUseCaseMock) Resolve(ctx context.Context, reportID, resolverID, action, note string, suspendDays int) (models.Report, error) {
+	args := m.Called(ctx, reportID, resolverID, action, note, suspendDays)
+	return args.Get(0).(models.Report), args.Error(1)
+}
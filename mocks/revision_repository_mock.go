@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RevisionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RevisionRepositoryMock) CreateRevision(revision models.BlogRevision) (models.BlogRevision, error) {
+	args := m.Called(revision)
+	return args.Get(0).(models.BlogRevision), args.Error(1)
+}
+
+func (m *RevisionRepositoryMock) GetRevisions(blogID string) ([]models.BlogRevision, error) {
+	args := m.Called(blogID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BlogRevision), args.Error(1)
+}
+
+func (m *RevisionRepositoryMock) GetRevision(blogID string, version int) (models.BlogRevision, error) {
+	args := m.Called(blogID, version)
+	return args.Get(0).(models.BlogRevision), args.Error(1)
+}
+
+func (m *RevisionRepositoryMock) DeleteRevision(blogID string, version int) error {
+	args := m.Called(blogID, version)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SessionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *SessionUseCaseMock) GetSessions(userID string) ([]models.Session, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Session), args.Error(1)
+}
+
+func (m *SessionUseCaseMock) RevokeSession(userID, sessionID string) error {
+	args := m.Called(userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *SessionUseCaseMock) RevokeOtherSessions(userID, refreshToken string) (int64, error) {
+	args := m.Called(userID, refreshToken)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *SessionUseCaseMock) RevokeAllSessions(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
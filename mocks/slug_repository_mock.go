@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SlugRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *SlugRepositoryMock) ReserveSlug(slug, blogID string) (bool, error) {
+	args := m.Called(slug, blogID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *SlugRepositoryMock) ReleaseSlug(slug, blogID string) error {
+	args := m.Called(slug, blogID)
+	return args.Error(0)
+}
+
+func (m *SlugRepositoryMock) ReleaseBlogSlugs(blogID string) error {
+	args := m.Called(blogID)
+	return args.Error(0)
+}
+
+func (m *SlugRepositoryMock) GetSlug(slug string) (*models.BlogSlug, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BlogSlug), args.Error(1)
+}
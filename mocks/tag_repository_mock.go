@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TagRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TagRepositoryMock) GetTags() ([]models.Tag, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *TagRepositoryMock) FindTags(names []string) ([]models.Tag, error) {
+	args := m.Called(names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *TagRepositoryMock) MergeTags(sources []string, target string) (models.Tag, error) {
+	args := m.Called(sources, target)
+	return args.Get(0).(models.Tag), args.Error(1)
+}
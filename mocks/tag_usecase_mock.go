@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"blog-api/Domain/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TagUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *TagUseCaseMock) GetTags() ([]models.Tag, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *TagUseCaseMock) SuggestTags(ctx context.Context, title, content string) (models.TagSuggestion, error) {
+	args := m.Called(ctx, title, content)
+	return args.Get(0).(models.TagSuggestion), args.Error(1)
+}
+
+func (m *TagUseCaseMock) MergeTags(sources []string, target string) (models.TagMergeResult, error) {
+	args := m.Called(sources, target)
+	return args.Get(0).(models.TagMergeResult), args.Error(1)
+}
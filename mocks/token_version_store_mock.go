@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TokenVersionStoreMock struct {
+	mock.Mock
+}
+
+func (m *TokenVersionStoreMock) Current(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *TokenVersionStoreMock) Bump(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"blog-api/Domain/models"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -61,3 +62,48 @@ func (m *UserRepository) CountUsers() (int64, error) {
 	count, _ := args.Get(0).(int64)
 	return count, args.Error(1)
 }
+
+func (m *UserRepository) AdjustFollowCounts(ctx context.Context, followerID, followeeID string, delta int) error {
+	args := m.Called(ctx, followerID, followeeID, delta)
+	return args.Error(0)
+}
+
+func (m *UserRepository) AddWarning(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *UserRepository) Suspend(ctx context.Context, id string, until time.Time, reason string) error {
+	args := m.Called(ctx, id, until, reason)
+	return args.Error(0)
+}
+
+func (m *UserRepository) IncrementTokenVersion(ctx context.Context, id string) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *UserRepository) SetMFASecret(ctx context.Context, id, secret string) error {
+	args := m.Called(ctx, id, secret)
+	return args.Error(0)
+}
+
+func (m *UserRepository) EnableMFA(ctx context.Context, id, recoverySalt string, recoveryCodeHashes []string) error {
+	args := m.Called(ctx, id, recoverySalt, recoveryCodeHashes)
+	return args.Error(0)
+}
+
+func (m *UserRepository) DisableMFA(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *UserRepository) ClaimMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	args := m.Called(ctx, id, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *UserRepository) UseRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	args := m.Called(ctx, id, codeHash)
+	return args.Bool(0), args.Error(1)
+}
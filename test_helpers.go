@@ -21,7 +21,6 @@ func (th *TestHelper) CreateTestBlog() models.Blog {
 		ViewCount:   0,
 		Likes:       0,
 		Dislikes:    0,
-		Comments:    []models.Comment{},
 		IsPublished: true,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -42,7 +41,6 @@ func (th *TestHelper) CreateTestBlogs(count int) []models.Blog {
 			ViewCount:   i * 10,
 			Likes:       i * 5,
 			Dislikes:    i,
-			Comments:    []models.Comment{},
 			IsPublished: true,
 			CreatedAt:   time.Now().AddDate(0, 0, -i),
 			UpdatedAt:   time.Now().AddDate(0, 0, -i),
@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"strings"
+)
+
+// Prefixes of the flattened suggestion lines clients save
+const (
+	suggestionTitlePrefix    = "Title:"
+	suggestionAudiencePrefix = "Target Audience:"
+	suggestionSummaryPrefix  = "Summary:"
+	suggestionBulletPrefix   = "•"
+)
+
+// suggestionOutline is a saved suggestion read back into its parts
+type suggestionOutline struct {
+	Title     string
+	Audience  string
+	Summary   string
+	Headlines []string
+}
+
+// outlineFromSuggestion strips the line prefixes off a saved suggestion. The first title wins;
+// without one the input topic, then the keywords, name the post.
+func outlineFromSuggestion(suggestion models.AISuggestion) suggestionOutline {
+	var outline suggestionOutline
+
+	lines := suggestion.Suggestions
+	if len(lines) == 0 && suggestion.SuggestedContent != "" {
+		lines = []string{suggestion.SuggestedContent}
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, suggestionTitlePrefix):
+			if outline.Title == "" {
+				outline.Title = strings.TrimSpace(strings.TrimPrefix(line, suggestionTitlePrefix))
+			}
+		case strings.HasPrefix(line, suggestionAudiencePrefix):
+			outline.Audience = strings.TrimSpace(strings.TrimPrefix(line, suggestionAudiencePrefix))
+		case strings.HasPrefix(line, suggestionSummaryPrefix):
+			outline.Summary = strings.TrimSpace(strings.TrimPrefix(line, suggestionSummaryPrefix))
+		case strings.HasPrefix(line, suggestionBulletPrefix):
+			if headline := strings.TrimSpace(strings.TrimPrefix(line, suggestionBulletPrefix)); headline != "" {
+				outline.Headlines = append(outline.Headlines, headline)
+			}
+		}
+	}
+
+	if outline.Title == "" {
+		outline.Title = strings.TrimSpace(suggestion.InputTopic)
+	}
+	if outline.Title == "" {
+		outline.Title = strings.Join(suggestion.Keywords, ", ")
+	}
+
+	return outline
+}
+
+// draftTags turns suggestion keywords into tags: trimmed, lower-cased, without a leading "#"
+// or bullet, and without duplicates
+func draftTags(keywords []string) []string {
+	tags := []string{}
+	seen := make(map[string]bool)
+
+	for _, keyword := range keywords {
+		tag := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(keyword), "#"+suggestionBulletPrefix)))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// outlineContent lays the outline out as a markdown skeleton for the author to fill in
+func outlineContent(outline suggestionOutline) string {
+	var sections []string
+
+	if outline.Summary != "" {
+		sections = append(sections, outline.Summary)
+	}
+	if outline.Audience != "" {
+		sections = append(sections, "_Written for "+outline.Audience+"._")
+	}
+	for _, headline := range outline.Headlines {
+		sections = append(sections, "## "+headline)
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// draftContent renders an expanded draft as markdown: intro, one section per headline, conclusion
+func draftContent(draft models.AIGeneration) string {
+	var sections []string
+
+	if draft.Intro != "" {
+		sections = append(sections, draft.Intro)
+	}
+	for _, section := range draft.Sections {
+		sections = append(sections, "## "+section.Heading+"\n\n"+section.Body)
+	}
+	if draft.Conclusion != "" {
+		sections = append(sections, "## Conclusion\n\n"+draft.Conclusion)
+	}
+
+	return strings.Join(sections, "\n\n")
+}
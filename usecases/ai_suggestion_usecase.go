@@ -3,19 +3,29 @@ package usecases
 import (
 	"blog-api/Domain/interfaces"
 	"blog-api/Domain/models"
+	"context"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 )
 
+// errSuggestionConverted is returned when a suggestion already has a draft
+var errSuggestionConverted = errors.New("suggestion already converted to a draft")
+
 type aiSuggestionUseCase struct {
 	aiSuggestionRepo interfaces.AISuggestionRepository
-	blogRepo         interfaces.BlogRepository
+	blogUC           BlogUseCase
+	userRepo         interfaces.UserRepository
+	aiProvider       interfaces.AIProvider
 }
 
-func NewAISuggestionUseCase(aiSuggestionRepo interfaces.AISuggestionRepository, blogRepo interfaces.BlogRepository) interfaces.AISuggestionUseCase {
+func NewAISuggestionUseCase(aiSuggestionRepo interfaces.AISuggestionRepository, blogUC BlogUseCase, userRepo interfaces.UserRepository, aiProvider interfaces.AIProvider) interfaces.AISuggestionUseCase {
 	return &aiSuggestionUseCase{
 		aiSuggestionRepo: aiSuggestionRepo,
-		blogRepo:         blogRepo,
+		blogUC:           blogUC,
+		userRepo:         userRepo,
+		aiProvider:       aiProvider,
 	}
 }
 
@@ -84,46 +94,117 @@ func (a *aiSuggestionUseCase) GetAISuggestionsByStatus(userID string, status str
 	return a.aiSuggestionRepo.GetAISuggestionsByStatus(userID, status, page, limit)
 }
 
-func (a *aiSuggestionUseCase) ConvertSuggestionToDraft(suggestionID string, userID string) (models.Blog, error) {
-	// Get the suggestion
+// GetAISuggestionsByCursor retrieves a page of the user's suggestions next to the cursor
+func (a *aiSuggestionUseCase) GetAISuggestionsByCursor(userID string, status string, page models.PageRequest) ([]models.AISuggestion, models.PageInfo, error) {
+	suggestions, hasMore, err := a.aiSuggestionRepo.GetAISuggestionsByCursor(userID, status, page)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	info := pageInfo(page, hasMore, len(suggestions), func(i int) models.Cursor {
+		return models.Cursor{CreatedAt: suggestions[i].CreatedAt, ID: suggestions[i].ID}
+	})
+	if page.WithTotal {
+		total, err := a.aiSuggestionRepo.CountAISuggestions(userID, status)
+		if err != nil {
+			return nil, models.PageInfo{}, err
+		}
+		info.Total = &total
+	}
+
+	return suggestions, info, nil
+}
+
+// ConvertSuggestionToDraft turns a saved suggestion into an unpublished blog linked back to it.
+// The suggestion's title becomes the blog title and its keywords the tags. With expand set, the
+// AI provider writes the body from the headlines; otherwise the headlines become an outline.
+// A suggestion converts once: it is claimed before the draft is written and given back if that
+// fails.
+func (a *aiSuggestionUseCase) ConvertSuggestionToDraft(ctx context.Context, suggestionID string, userID string, expand bool) (models.Blog, error) {
 	suggestion, err := a.GetAISuggestionByID(suggestionID, userID)
 	if err != nil {
 		return models.Blog{}, err
 	}
-	
-	// Create a blog draft from the suggestion
-	blog := models.Blog{
-		Title:       suggestion.SuggestedContent, // Use the first suggestion as title
-		Content:     "", // Empty content for draft
-		AuthorID:    userID,
-		AuthorName:  "", // Will be set by controller
-		Tags:        suggestion.Keywords,
-		IsPublished: false, // Draft
-		ViewCount:   0,
-		Likes:       0,
-		Dislikes:    0,
-		Comments:    []models.Comment{},
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	if suggestion.Status == models.AISuggestionStatusConvertedToDraft {
+		return models.Blog{}, errSuggestionConverted
 	}
-	
-	// Create the blog draft
-	createdBlog, err := a.blogRepo.CreateBlog(blog)
+
+	author, err := a.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return models.Blog{}, err
 	}
-	
-	// Update suggestion status to converted
-	suggestion.Status = models.AISuggestionStatusConvertedToDraft
-	suggestion.UpdatedAt = time.Now()
-	_, err = a.aiSuggestionRepo.UpdateAISuggestion(suggestion)
+
+	claimed, err := a.aiSuggestionRepo.MarkAISuggestionConverted(suggestion.ID)
 	if err != nil {
 		return models.Blog{}, err
 	}
-	
+	if !claimed {
+		return models.Blog{}, errSuggestionConverted
+	}
+
+	blog, err := a.writeDraft(ctx, suggestion, userID, author, expand)
+	if err != nil {
+		// Give the suggestion back so the conversion can be tried again
+		if _, restoreErr := a.aiSuggestionRepo.UpdateAISuggestion(suggestion); restoreErr != nil {
+			log.Printf("Failed to restore AI suggestion %s after a failed conversion: %v", suggestion.ID, restoreErr)
+		}
+		return models.Blog{}, err
+	}
+
+	return blog, nil
+}
+
+// writeDraft creates the draft blog for a claimed suggestion
+func (a *aiSuggestionUseCase) writeDraft(ctx context.Context, suggestion models.AISuggestion, userID string, author models.User, expand bool) (models.Blog, error) {
+	authorName := author.Username
+	if authorName == "" {
+		authorName = author.Email
+	}
+
+	outline := outlineFromSuggestion(suggestion)
+	content := outlineContent(outline)
+	if expand {
+		draft, err := a.aiProvider.Generate(ctx, models.AIGenerationRequest{
+			Type:      models.AISuggestionTypeDraft,
+			Keywords:  suggestion.Keywords,
+			Tone:      suggestion.Tone,
+			Title:     outline.Title,
+			Audience:  outline.Audience,
+			Headlines: outline.Headlines,
+		})
+		if err != nil {
+			return models.Blog{}, fmt.Errorf("failed to expand suggestion: %w", err)
+		}
+		content = draftContent(draft)
+	}
+
+	// Going through the blog use case gives the draft its slug
+	createdBlog, err := a.blogUC.CreateBlog(models.Blog{
+		Title:              outline.Title,
+		Content:            content,
+		AuthorID:           userID,
+		AuthorName:         authorName,
+		Tags:               draftTags(suggestion.Keywords),
+		IsPublished:        false,
+		SourceSuggestionID: suggestion.ID,
+	})
+	if err != nil {
+		return models.Blog{}, err
+	}
+
 	return createdBlog, nil
 }
 
+// GenerateSuggestion asks the AI provider for suggestions
+func (a *aiSuggestionUseCase) GenerateSuggestion(ctx context.Context, request models.AIGenerationRequest) (models.AIGeneration, error) {
+	return a.aiProvider.Generate(ctx, request)
+}
+
+// StreamSuggestion asks the AI provider for suggestions, relaying the text as it is generated
+func (a *aiSuggestionUseCase) StreamSuggestion(ctx context.Context, request models.AIGenerationRequest, onToken func(token string) error) (models.AIGeneration, error) {
+	return a.aiProvider.Stream(ctx, request, onToken)
+}
+
 func (a *aiSuggestionUseCase) SaveAISuggestion(userID string, inputTopic string, keywords []string, tone string, suggestions []string) (models.AISuggestion, error) {
 	// Create a new AI suggestion
 	suggestion := models.AISuggestion{
@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAISuggestionUseCase_ConvertSuggestionToDraft(t *testing.T) {
+	suggestion := models.AISuggestion{
+		ID:         "suggestion123",
+		UserID:     "user123",
+		InputTopic: "go concurrency",
+		Keywords:   []string{"Go", " concurrency ", "#go"},
+		Tone:       "casual",
+		Suggestions: []string{
+			"Title: Concurrency in Go",
+			"Target Audience: Backend developers",
+			"• Goroutines",
+			"• Channels",
+		},
+		Status: models.AISuggestionStatusSaved,
+	}
+	draft := models.AIGeneration{
+		Type:  models.AISuggestionTypeDraft,
+		Intro: "Go makes concurrency easy.",
+		Sections: []models.AIDraftSection{
+			{Heading: "Goroutines", Body: "Cheap threads."},
+			{Heading: "Channels", Body: "Typed pipes."},
+		},
+		Conclusion: "Start small.",
+	}
+
+	tests := []struct {
+		name          string
+		userID        string
+		expand        bool
+		setupMocks    func(*mocks.AISuggestionRepositoryMock, *mocks.BlogUseCaseMock, *mocks.UserRepository, *mocks.AIProviderMock)
+		expectError   bool
+		expectedError error
+		createsDraft  bool
+		expectedBlog  models.Blog
+	}{
+		{
+			name:   "Success - Outline without expansion",
+			userID: "user123",
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				repo.On("GetAISuggestionByID", "suggestion123").Return(suggestion, nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Username: "gopher"}, nil)
+				draftBlog := models.Blog{
+					Title:              "Concurrency in Go",
+					Content:            "_Written for Backend developers._\n\n## Goroutines\n\n## Channels",
+					AuthorID:           "user123",
+					AuthorName:         "gopher",
+					Tags:               []string{"go", "concurrency"},
+					SourceSuggestionID: "suggestion123",
+				}
+				createdBlog := draftBlog
+				createdBlog.ID = "blog123"
+				createdBlog.Slug = "concurrency-in-go"
+				repo.On("MarkAISuggestionConverted", "suggestion123").Return(true, nil)
+				blogUC.On("CreateBlog", draftBlog).Return(createdBlog, nil)
+			},
+			expectedBlog: models.Blog{
+				ID:                 "blog123",
+				Title:              "Concurrency in Go",
+				Slug:               "concurrency-in-go",
+				Content:            "_Written for Backend developers._\n\n## Goroutines\n\n## Channels",
+				AuthorID:           "user123",
+				AuthorName:         "gopher",
+				Tags:               []string{"go", "concurrency"},
+				SourceSuggestionID: "suggestion123",
+			},
+		},
+		{
+			name:   "Success - AI expands the headlines",
+			userID: "user123",
+			expand: true,
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				repo.On("GetAISuggestionByID", "suggestion123").Return(suggestion, nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Email: "gopher@example.com"}, nil)
+				repo.On("MarkAISuggestionConverted", "suggestion123").Return(true, nil)
+				provider.On("Generate", mock.Anything, models.AIGenerationRequest{
+					Type:      models.AISuggestionTypeDraft,
+					Keywords:  suggestion.Keywords,
+					Tone:      "casual",
+					Title:     "Concurrency in Go",
+					Audience:  "Backend developers",
+					Headlines: []string{"Goroutines", "Channels"},
+				}).Return(draft, nil)
+				draftBlog := models.Blog{
+					Title:              "Concurrency in Go",
+					Content:            "Go makes concurrency easy.\n\n## Goroutines\n\nCheap threads.\n\n## Channels\n\nTyped pipes.\n\n## Conclusion\n\nStart small.",
+					AuthorID:           "user123",
+					AuthorName:         "gopher@example.com",
+					Tags:               []string{"go", "concurrency"},
+					SourceSuggestionID: "suggestion123",
+				}
+				createdBlog := draftBlog
+				createdBlog.ID = "blog123"
+				blogUC.On("CreateBlog", draftBlog).Return(createdBlog, nil)
+			},
+			expectedBlog: models.Blog{
+				ID:                 "blog123",
+				Title:              "Concurrency in Go",
+				Content:            "Go makes concurrency easy.\n\n## Goroutines\n\nCheap threads.\n\n## Channels\n\nTyped pipes.\n\n## Conclusion\n\nStart small.",
+				AuthorID:           "user123",
+				AuthorName:         "gopher@example.com",
+				Tags:               []string{"go", "concurrency"},
+				SourceSuggestionID: "suggestion123",
+			},
+		},
+		{
+			name:   "Error - AI expansion fails",
+			userID: "user123",
+			expand: true,
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				repo.On("GetAISuggestionByID", "suggestion123").Return(suggestion, nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Username: "gopher"}, nil)
+				repo.On("MarkAISuggestionConverted", "suggestion123").Return(true, nil)
+				provider.On("Generate", mock.Anything, mock.Anything).Return(models.AIGeneration{}, errors.New("AI service request failed"))
+				// The suggestion is given back
+				repo.On("UpdateAISuggestion", suggestion).Return(suggestion, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:   "Error - Draft creation fails",
+			userID: "user123",
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				repo.On("GetAISuggestionByID", "suggestion123").Return(suggestion, nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Username: "gopher"}, nil)
+				repo.On("MarkAISuggestionConverted", "suggestion123").Return(true, nil)
+				blogUC.On("CreateBlog", mock.Anything).Return(models.Blog{}, errors.New("database error"))
+				repo.On("UpdateAISuggestion", suggestion).Return(suggestion, nil)
+			},
+			expectError:  true,
+			createsDraft: true,
+		},
+		{
+			name:   "Error - Already converted",
+			userID: "user123",
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				converted := suggestion
+				converted.Status = models.AISuggestionStatusConvertedToDraft
+				repo.On("GetAISuggestionByID", "suggestion123").Return(converted, nil)
+			},
+			expectError:   true,
+			expectedError: errSuggestionConverted,
+		},
+		{
+			name:   "Error - Converted by a concurrent request",
+			userID: "user123",
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				repo.On("GetAISuggestionByID", "suggestion123").Return(suggestion, nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Username: "gopher"}, nil)
+				repo.On("MarkAISuggestionConverted", "suggestion123").Return(false, nil)
+			},
+			expectError:   true,
+			expectedError: errSuggestionConverted,
+		},
+		{
+			name:   "Error - Someone else's suggestion",
+			userID: "intruder",
+			setupMocks: func(repo *mocks.AISuggestionRepositoryMock, blogUC *mocks.BlogUseCaseMock, userRepo *mocks.UserRepository, provider *mocks.AIProviderMock) {
+				repo.On("GetAISuggestionByID", "suggestion123").Return(suggestion, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.AISuggestionRepositoryMock{}
+			blogUC := &mocks.BlogUseCaseMock{}
+			userRepo := &mocks.UserRepository{}
+			provider := &mocks.AIProviderMock{}
+			tt.setupMocks(repo, blogUC, userRepo, provider)
+
+			useCase := NewAISuggestionUseCase(repo, blogUC, userRepo, provider)
+			result, err := useCase.ConvertSuggestionToDraft(context.Background(), "suggestion123", tt.userID, tt.expand)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.expectedError != nil {
+					assert.ErrorIs(t, err, tt.expectedError)
+				}
+				if !tt.createsDraft {
+					blogUC.AssertNotCalled(t, "CreateBlog", mock.Anything)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBlog, result)
+			}
+
+			repo.AssertExpectations(t)
+			blogUC.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			provider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAISuggestionUseCase_GenerateSuggestion(t *testing.T) {
+	request := models.AIGenerationRequest{Type: models.AISuggestionTypeIdeas, Keywords: []string{"go"}, Tone: "casual"}
+	generation := models.AIGeneration{Type: models.AISuggestionTypeIdeas, Title: "A casual guide to go"}
+
+	provider := &mocks.AIProviderMock{}
+	provider.On("Generate", mock.Anything, request).Return(generation, nil)
+	provider.On("Stream", mock.Anything, request, mock.Anything).Return(generation, nil)
+
+	useCase := NewAISuggestionUseCase(&mocks.AISuggestionRepositoryMock{}, &mocks.BlogUseCaseMock{}, &mocks.UserRepository{}, provider)
+
+	result, err := useCase.GenerateSuggestion(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, generation, result)
+
+	result, err = useCase.StreamSuggestion(context.Background(), request, func(token string) error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, generation, result)
+
+	provider.AssertExpectations(t)
+}
+
+func TestOutlineFromSuggestion(t *testing.T) {
+	tests := []struct {
+		name       string
+		suggestion models.AISuggestion
+		expected   suggestionOutline
+	}{
+		{
+			name: "First title wins",
+			suggestion: models.AISuggestion{Suggestions: []string{
+				"Title: Go in Practice",
+				"Title: Shipping Go",
+			}},
+			expected: suggestionOutline{Title: "Go in Practice"},
+		},
+		{
+			name: "Summary and improvements",
+			suggestion: models.AISuggestion{InputTopic: "My post", Suggestions: []string{
+				"Summary: Go is simple.",
+				"•   Shorten the intro",
+			}},
+			expected: suggestionOutline{Title: "My post", Summary: "Go is simple.", Headlines: []string{"Shorten the intro"}},
+		},
+		{
+			name:       "Suggested content of older suggestions",
+			suggestion: models.AISuggestion{SuggestedContent: "Title: Legacy", Keywords: []string{"go"}},
+			expected:   suggestionOutline{Title: "Legacy"},
+		},
+		{
+			name:       "Keywords when nothing else names the post",
+			suggestion: models.AISuggestion{Keywords: []string{"go", "mongo"}},
+			expected:   suggestionOutline{Title: "go, mongo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, outlineFromSuggestion(tt.suggestion))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"errors"
+	"log"
+	"time"
+)
+
+// defaultAIQuotaRole is the role whose quota applies to roles without one of their own
+const defaultAIQuotaRole = "user"
+
+type aiUsageUseCase struct {
+	aiUsageRepo   interfaces.AIUsageRepository
+	quotaCounters interfaces.AIQuotaCounterRepository
+	quotas        map[string]models.AIQuota
+	now           func() time.Time
+}
+
+// NewAIUsageUseCase enforces quotas keyed by role
+func NewAIUsageUseCase(aiUsageRepo interfaces.AIUsageRepository, quotaCounters interfaces.AIQuotaCounterRepository, quotas map[string]models.AIQuota) interfaces.AIUsageUseCase {
+	return &aiUsageUseCase{
+		aiUsageRepo:   aiUsageRepo,
+		quotaCounters: quotaCounters,
+		quotas:        quotas,
+		now:           time.Now,
+	}
+}
+
+func (u *aiUsageUseCase) quotaFor(role string) models.AIQuota {
+	quota, ok := u.quotas[role]
+	if !ok {
+		quota = u.quotas[defaultAIQuotaRole]
+	}
+	return quota
+}
+
+// quotaWindows returns the start of the current UTC day and month
+func (u *aiUsageUseCase) quotaWindows() (dayStart, monthStart time.Time) {
+	now := u.now().UTC()
+	dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, monthStart
+}
+
+// GetUsageStatus measures today's and this month's usage against the role's quota
+func (u *aiUsageUseCase) GetUsageStatus(userID string, role string) (models.AIUsageStatus, error) {
+	quota := u.quotaFor(role)
+	dayStart, monthStart := u.quotaWindows()
+
+	daily, err := u.aiUsageRepo.SumAIUsage(userID, dayStart)
+	if err != nil {
+		return models.AIUsageStatus{}, err
+	}
+	monthly, err := u.aiUsageRepo.SumAIUsage(userID, monthStart)
+	if err != nil {
+		return models.AIUsageStatus{}, err
+	}
+
+	status := models.AIUsageStatus{
+		Role: role,
+		Daily: models.AIUsageWindow{
+			Requests:     daily.Requests,
+			RequestLimit: quota.DailyRequests,
+			Tokens:       daily.Tokens,
+			TokenLimit:   quota.DailyTokens,
+			ResetAt:      dayStart.AddDate(0, 0, 1),
+		},
+		Monthly: models.AIUsageWindow{
+			Requests:     monthly.Requests,
+			RequestLimit: quota.MonthlyRequests,
+			Tokens:       monthly.Tokens,
+			TokenLimit:   quota.MonthlyTokens,
+			ResetAt:      monthStart.AddDate(0, 1, 0),
+		},
+	}
+
+	// An exhausted month outlasts an exhausted day
+	switch {
+	case status.Monthly.Exhausted():
+		status.Exceeded = true
+		status.ResetAt = &status.Monthly.ResetAt
+	case status.Daily.Exhausted():
+		status.Exceeded = true
+		status.ResetAt = &status.Daily.ResetAt
+	}
+
+	return status, nil
+}
+
+// ReserveRequest counts a request against the user's daily and monthly counters before it
+// reaches the model, so concurrent requests cannot all pass on the same reading. Token limits
+// are checked against the tokens settled so far; what a request will use is only known after.
+func (u *aiUsageUseCase) ReserveRequest(userID string, role string) (models.AIQuotaReservation, error) {
+	if userID == "" {
+		return models.AIQuotaReservation{}, errors.New("user ID is required")
+	}
+
+	quota := u.quotaFor(role)
+	dayStart, monthStart := u.quotaWindows()
+
+	// The month goes first: an exhausted month outlasts an exhausted day
+	windows := []struct {
+		key          string
+		requestLimit int64
+		tokenLimit   int64
+		resetAt      time.Time
+	}{
+		{userID + ":month:" + monthStart.Format("2006-01"), quota.MonthlyRequests, quota.MonthlyTokens, monthStart.AddDate(0, 1, 0)},
+		{userID + ":day:" + dayStart.Format("2006-01-02"), quota.DailyRequests, quota.DailyTokens, dayStart.AddDate(0, 0, 1)},
+	}
+
+	reservation := models.AIQuotaReservation{UserID: userID}
+	for _, window := range windows {
+		reserved, err := u.quotaCounters.ReserveAIRequest(window.key, window.requestLimit, window.tokenLimit, window.resetAt)
+		if err == nil && !reserved {
+			err = &models.AIQuotaExceededError{ResetAt: window.resetAt}
+		}
+		if err != nil {
+			if releaseErr := u.ReleaseRequest(reservation); releaseErr != nil {
+				log.Printf("Failed to release AI quota for user %s: %v", userID, releaseErr)
+			}
+			return models.AIQuotaReservation{}, err
+		}
+		reservation.Keys = append(reservation.Keys, window.key)
+	}
+
+	return reservation, nil
+}
+
+// SettleRequest adds a metered request to the ledger and its tokens to the counters it reserved
+func (u *aiUsageUseCase) SettleRequest(reservation models.AIQuotaReservation, record models.AIUsageRecord) error {
+	if record.UserID == "" {
+		return errors.New("user ID is required")
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = u.now()
+	}
+
+	if _, err := u.aiUsageRepo.RecordAIUsage(record); err != nil {
+		return err
+	}
+
+	if record.TotalTokens == 0 {
+		return nil
+	}
+	for _, key := range reservation.Keys {
+		if err := u.quotaCounters.AddAIUsage(key, 0, int64(record.TotalTokens)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReleaseRequest gives back a reservation whose request did not call the model, or whose call failed
+func (u *aiUsageUseCase) ReleaseRequest(reservation models.AIQuotaReservation) error {
+	for _, key := range reservation.Keys {
+		if err := u.quotaCounters.AddAIUsage(key, -1, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetUsageReport summarises usage per user over [from, to)
+func (u *aiUsageUseCase) GetUsageReport(from, to time.Time) (models.AIUsageReport, error) {
+	if !from.Before(to) {
+		return models.AIUsageReport{}, errors.New("from must be before to")
+	}
+
+	summaries, err := u.aiUsageRepo.GetAIUsageSummaries(from, to)
+	if err != nil {
+		return models.AIUsageReport{}, err
+	}
+
+	report := models.AIUsageReport{From: from, To: to, Users: summaries}
+	for _, summary := range summaries {
+		report.Requests += summary.Requests
+		report.TotalTokens += summary.TotalTokens
+	}
+
+	return report, nil
+}
@@ -0,0 +1,270 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAIUsageUseCase_GetUsageStatus(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	dayStart := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	quotas := map[string]models.AIQuota{
+		"user":       {DailyRequests: 10, MonthlyRequests: 100, MonthlyTokens: 5000},
+		"superadmin": {},
+	}
+
+	tests := []struct {
+		name           string
+		role           string
+		daily          models.AIUsageTotals
+		monthly        models.AIUsageTotals
+		expectExceeded bool
+		expectResetAt  time.Time
+	}{
+		{
+			name:    "Under quota",
+			role:    "user",
+			daily:   models.AIUsageTotals{Requests: 3, Tokens: 900},
+			monthly: models.AIUsageTotals{Requests: 40, Tokens: 4000},
+		},
+		{
+			name:           "Daily requests used up",
+			role:           "user",
+			daily:          models.AIUsageTotals{Requests: 10, Tokens: 900},
+			monthly:        models.AIUsageTotals{Requests: 40, Tokens: 4000},
+			expectExceeded: true,
+			expectResetAt:  time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Monthly tokens used up outlast the day",
+			role:           "user",
+			daily:          models.AIUsageTotals{Requests: 10, Tokens: 900},
+			monthly:        models.AIUsageTotals{Requests: 40, Tokens: 5200},
+			expectExceeded: true,
+			expectResetAt:  time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Unknown role gets the user quota",
+			role:           "editor",
+			daily:          models.AIUsageTotals{Requests: 12},
+			monthly:        models.AIUsageTotals{Requests: 12},
+			expectExceeded: true,
+			expectResetAt:  time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Zero limits are unlimited",
+			role:    "superadmin",
+			daily:   models.AIUsageTotals{Requests: 10000, Tokens: 1e9},
+			monthly: models.AIUsageTotals{Requests: 10000, Tokens: 1e9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.AIUsageRepositoryMock{}
+			mockRepo.On("SumAIUsage", "user123", dayStart).Return(tt.daily, nil)
+			mockRepo.On("SumAIUsage", "user123", monthStart).Return(tt.monthly, nil)
+
+			useCase := NewAIUsageUseCase(mockRepo, &mocks.AIQuotaCounterRepositoryMock{}, quotas).(*aiUsageUseCase)
+			useCase.now = func() time.Time { return now }
+
+			status, err := useCase.GetUsageStatus("user123", tt.role)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.role, status.Role)
+			assert.Equal(t, tt.daily.Requests, status.Daily.Requests)
+			assert.Equal(t, tt.monthly.Tokens, status.Monthly.Tokens)
+			assert.Equal(t, tt.expectExceeded, status.Exceeded)
+			if tt.expectExceeded {
+				assert.Equal(t, tt.expectResetAt, *status.ResetAt)
+			} else {
+				assert.Nil(t, status.ResetAt)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAIUsageUseCase_GetUsageStatus_RepositoryError(t *testing.T) {
+	mockRepo := &mocks.AIUsageRepositoryMock{}
+	mockRepo.On("SumAIUsage", "user123", mock.Anything).Return(models.AIUsageTotals{}, errors.New("database error"))
+
+	useCase := NewAIUsageUseCase(mockRepo, &mocks.AIQuotaCounterRepositoryMock{}, map[string]models.AIQuota{})
+	_, err := useCase.GetUsageStatus("user123", "user")
+
+	assert.Error(t, err)
+}
+
+func TestAIUsageUseCase_ReserveRequest(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	dayKey, monthKey := "user123:day:2026-03-14", "user123:month:2026-03"
+	dayReset := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	monthReset := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	quotas := map[string]models.AIQuota{
+		"user": {DailyRequests: 10, MonthlyRequests: 100, MonthlyTokens: 5000},
+	}
+
+	tests := []struct {
+		name          string
+		monthReserved bool
+		dayReserved   bool
+		expectResetAt time.Time
+		expectRelease bool
+	}{
+		{
+			name:          "Under quota",
+			monthReserved: true,
+			dayReserved:   true,
+		},
+		{
+			name:          "Day used up gives back the month",
+			monthReserved: true,
+			expectResetAt: dayReset,
+			expectRelease: true,
+		},
+		{
+			name:          "Month used up",
+			expectResetAt: monthReset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCounters := &mocks.AIQuotaCounterRepositoryMock{}
+			mockCounters.On("ReserveAIRequest", monthKey, int64(100), int64(5000), monthReset).Return(tt.monthReserved, nil)
+			if tt.monthReserved {
+				mockCounters.On("ReserveAIRequest", dayKey, int64(10), int64(0), dayReset).Return(tt.dayReserved, nil)
+			}
+			if tt.expectRelease {
+				mockCounters.On("AddAIUsage", monthKey, int64(-1), int64(0)).Return(nil)
+			}
+
+			useCase := NewAIUsageUseCase(&mocks.AIUsageRepositoryMock{}, mockCounters, quotas).(*aiUsageUseCase)
+			useCase.now = func() time.Time { return now }
+
+			reservation, err := useCase.ReserveRequest("user123", "editor")
+
+			if tt.expectResetAt.IsZero() {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{monthKey, dayKey}, reservation.Keys)
+			} else {
+				var exceeded *models.AIQuotaExceededError
+				assert.ErrorAs(t, err, &exceeded)
+				assert.Equal(t, tt.expectResetAt, exceeded.ResetAt)
+				assert.Empty(t, reservation.Keys)
+			}
+			mockCounters.AssertExpectations(t)
+		})
+	}
+}
+
+// memoryQuotaCounters applies each reservation under a lock, as the conditional update does
+type memoryQuotaCounters struct {
+	mu       sync.Mutex
+	requests map[string]int64
+	tokens   map[string]int64
+}
+
+func (m *memoryQuotaCounters) ReserveAIRequest(key string, requestLimit, tokenLimit int64, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if (requestLimit > 0 && m.requests[key] >= requestLimit) || (tokenLimit > 0 && m.tokens[key] >= tokenLimit) {
+		return false, nil
+	}
+	m.requests[key]++
+	return true, nil
+}
+
+func (m *memoryQuotaCounters) AddAIUsage(key string, requests, tokens int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[key] += requests
+	m.tokens[key] += tokens
+	return nil
+}
+
+func TestAIUsageUseCase_ReserveRequest_Concurrent(t *testing.T) {
+	counters := &memoryQuotaCounters{requests: map[string]int64{}, tokens: map[string]int64{}}
+	quotas := map[string]models.AIQuota{"user": {DailyRequests: 5}}
+	useCase := NewAIUsageUseCase(&mocks.AIUsageRepositoryMock{}, counters, quotas)
+
+	var wg sync.WaitGroup
+	var allowed atomic.Int64
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := useCase.ReserveRequest("user123", "user"); err == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(5), allowed.Load())
+}
+
+func TestAIUsageUseCase_SettleRequest(t *testing.T) {
+	reservation := models.AIQuotaReservation{UserID: "user123", Keys: []string{"user123:month:2026-03", "user123:day:2026-03-14"}}
+
+	mockRepo := &mocks.AIUsageRepositoryMock{}
+	mockRepo.On("RecordAIUsage", mock.MatchedBy(func(record models.AIUsageRecord) bool {
+		return record.UserID == "user123" && record.TotalTokens == 120 && !record.CreatedAt.IsZero()
+	})).Return(models.AIUsageRecord{ID: "usage123"}, nil)
+	mockCounters := &mocks.AIQuotaCounterRepositoryMock{}
+	mockCounters.On("AddAIUsage", "user123:month:2026-03", int64(0), int64(120)).Return(nil)
+	mockCounters.On("AddAIUsage", "user123:day:2026-03-14", int64(0), int64(120)).Return(nil)
+
+	useCase := NewAIUsageUseCase(mockRepo, mockCounters, nil)
+
+	assert.NoError(t, useCase.SettleRequest(reservation, models.AIUsageRecord{UserID: "user123", TotalTokens: 120}))
+	assert.Error(t, useCase.SettleRequest(reservation, models.AIUsageRecord{TotalTokens: 120}))
+	mockRepo.AssertExpectations(t)
+	mockCounters.AssertExpectations(t)
+}
+
+func TestAIUsageUseCase_ReleaseRequest(t *testing.T) {
+	reservation := models.AIQuotaReservation{UserID: "user123", Keys: []string{"user123:month:2026-03", "user123:day:2026-03-14"}}
+
+	mockCounters := &mocks.AIQuotaCounterRepositoryMock{}
+	mockCounters.On("AddAIUsage", "user123:month:2026-03", int64(-1), int64(0)).Return(nil)
+	mockCounters.On("AddAIUsage", "user123:day:2026-03-14", int64(-1), int64(0)).Return(nil)
+
+	useCase := NewAIUsageUseCase(&mocks.AIUsageRepositoryMock{}, mockCounters, nil)
+
+	assert.NoError(t, useCase.ReleaseRequest(reservation))
+	mockCounters.AssertExpectations(t)
+}
+
+func TestAIUsageUseCase_GetUsageReport(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo := &mocks.AIUsageRepositoryMock{}
+	mockRepo.On("GetAIUsageSummaries", from, to).Return([]models.AIUsageSummary{
+		{UserID: "heavy", Requests: 30, TotalTokens: 9000},
+		{UserID: "light", Requests: 2, TotalTokens: 400},
+	}, nil)
+
+	useCase := NewAIUsageUseCase(mockRepo, &mocks.AIQuotaCounterRepositoryMock{}, nil)
+	report, err := useCase.GetUsageReport(from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(32), report.Requests)
+	assert.Equal(t, int64(9400), report.TotalTokens)
+	assert.Len(t, report.Users, 2)
+
+	// Empty range
+	_, err = useCase.GetUsageReport(to, from)
+	assert.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -3,63 +3,388 @@ package usecases
 import (
 	"blog-api/Domain/interfaces"
 	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type BlogUseCase interface {
 	CreateBlog(blog models.Blog) (models.Blog, error)
-	GetPaginatedBlogs(page, limit int) ([]models.Blog, error)
+	GetPaginatedBlogs(page, limit int) ([]models.Blog, int64, error)
+	GetBlogsByCursor(page models.PageRequest) ([]models.Blog, models.PageInfo, error)
 	GetBlogByID(blogID string) (models.Blog, error)
+	// GetBlogBySlug also resolves slugs a blog used before it was renamed; compare blog.Slug to detect those
+	GetBlogBySlug(slug string) (models.Blog, error)
+	// BackfillSlugs gives a slug to blogs stored without one and returns how many it assigned
+	BackfillSlugs() (int, error)
 	UpdateBlog(blog models.Blog) (models.Blog, error)
 	DeleteBlog(blogID string) error
-	SearchBlogs(query string) ([]models.Blog, error)
+	SearchBlogs(query string, page, limit int) ([]models.BlogSearchResult, int64, error)
 	FilterBlogs(tags []string, dateRange [2]string, sortBy string) ([]models.Blog, error)
+	QueryBlogs(query models.BlogQuery) (models.BlogQueryResult, error)
 
 	// popularity tracking methods
 	IncrementViewCount(blogID string) error
 	UpdateLikes(blogID string, increment bool) error
 	UpdateDislikes(blogID string, increment bool) error
+
+	// threaded comments
 	AddComment(blogID string, comment models.Comment) (models.Comment, error)
-	GetComments(blogID string) ([]models.Comment, error)
+	GetComments(blogID string, page, limit int) ([]models.Comment, int64, error)
+	GetCommentsByCursor(blogID string, page models.PageRequest) ([]models.Comment, models.PageInfo, error)
+	GetCommentByID(commentID string) (models.Comment, error)
+	UpdateComment(commentID string, content string) (models.Comment, error)
+	DeleteComment(commentID string) error
+
+	// author dashboard
+	GetMyBlogs(authorID, status string, page, limit int) ([]models.Blog, int64, error)
+
+	// scheduled publishing
+	GetScheduledBlogs(authorID string) ([]models.Blog, error)
+	PublishDueBlogs() (int, error)
+
+	// revision history
+	GetRevisions(blogID string) ([]models.BlogRevision, error)
+	GetRevision(blogID string, version int) (models.BlogRevision, error)
+	DiffRevisions(blogID string, fromVersion, toVersion int) (models.RevisionDiff, error)
+	RestoreRevision(blogID string, version int) (models.Blog, error)
+
+	// per-user reactions
+	ReactToBlog(blogID, userID, reactionType string) (models.Reaction, error)
+	RemoveReaction(blogID, userID, reactionType string) error
+	GetUserReaction(blogID, userID string) (string, error)
 }
 
 type blogUseCase struct {
-	blogRepo interfaces.BlogRepository
+	blogRepo     interfaces.BlogRepository
+	reactionRepo interfaces.ReactionRepository
+	commentRepo  interfaces.CommentRepository
+	revisionRepo interfaces.RevisionRepository
+	slugRepo     interfaces.SlugRepository
+	tagRepo      interfaces.TagRepository
+	moderationUC interfaces.ModerationUseCase
 }
 
-func NewBlogUseCase(blogRepo interfaces.BlogRepository) BlogUseCase {
+func NewBlogUseCase(blogRepo interfaces.BlogRepository, reactionRepo interfaces.ReactionRepository, commentRepo interfaces.CommentRepository, revisionRepo interfaces.RevisionRepository, slugRepo interfaces.SlugRepository, tagRepo interfaces.TagRepository, moderationUC interfaces.ModerationUseCase) BlogUseCase {
 	return &blogUseCase{
-		blogRepo: blogRepo,
+		blogRepo:     blogRepo,
+		reactionRepo: reactionRepo,
+		commentRepo:  commentRepo,
+		revisionRepo: revisionRepo,
+		slugRepo:     slugRepo,
+		tagRepo:      tagRepo,
+		moderationUC: moderationUC,
 	}
 }
 
+// editedWhileHeld keeps content that was edited while waiting for review in the queue
+var editedWhileHeld = models.ModerationVerdict{
+	Decision: models.ModerationReview,
+	Reasons:  []string{"Edited while held for moderation"},
+}
+
+// CreateBlog stores tags in their canonical form, so "Go" and "golang" become "go".
+// A post the moderator holds back is saved unpublished and queued for review.
 func (b *blogUseCase) CreateBlog(blog models.Blog) (models.Blog, error) {
-	return b.blogRepo.CreateBlog(blog)
+	clearScheduleIfPublished(&blog)
+
+	tags, err := canonicalTags(b.tagRepo, blog.Tags)
+	if err != nil {
+		return models.Blog{}, err
+	}
+	blog.Tags = tags
+
+	verdict, err := b.moderationUC.Check(context.TODO(), blogModerationContent(blog))
+	if err != nil {
+		return models.Blog{}, err
+	}
+	publish := blog.IsPublished
+	blog.ModerationStatus = verdict.Status()
+	if models.IsModerationHeld(blog.ModerationStatus) {
+		blog.IsPublished = false
+	}
+
+	createdBlog, err := b.blogRepo.CreateBlog(blog)
+	if err != nil {
+		return models.Blog{}, err
+	}
+
+	// The slug can only be reserved once the blog has an ID; a blog left without one is removed
+	slug, err := b.assignSlug(createdBlog)
+	if err != nil {
+		if deleteErr := b.blogRepo.DeleteBlog(createdBlog.ID); deleteErr != nil {
+			log.Printf("Failed to remove blog %s left without a slug: %v", createdBlog.ID, deleteErr)
+		}
+		return models.Blog{}, err
+	}
+	createdBlog.Slug = slug
+
+	if models.IsModerationHeld(createdBlog.ModerationStatus) {
+		if _, err := b.moderationUC.Hold(blogModerationContent(createdBlog), verdict, publish); err != nil {
+			return models.Blog{}, err
+		}
+	}
+
+	return createdBlog, nil
 }
 
-func (b *blogUseCase) GetPaginatedBlogs(page, limit int) ([]models.Blog, error) {
-	return b.blogRepo.GetPaginatedBlogs(page, limit)
+// assignSlug reserves a slug for a stored blog and saves it on the blog, giving the slug back if
+// that fails
+func (b *blogUseCase) assignSlug(blog models.Blog) (string, error) {
+	slug, err := b.reserveSlug(blog.Title, blog.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := b.blogRepo.UpdateSlug(blog.ID, slug); err != nil {
+		if releaseErr := b.slugRepo.ReleaseSlug(slug, blog.ID); releaseErr != nil {
+			log.Printf("Failed to release slug %s of blog %s: %v", slug, blog.ID, releaseErr)
+		}
+		return "", err
+	}
+	return slug, nil
+}
+
+// BackfillSlugs gives a slug to every blog stored before blogs had one
+func (b *blogUseCase) BackfillSlugs() (int, error) {
+	blogs, err := b.blogRepo.GetBlogsWithoutSlug()
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for _, blog := range blogs {
+		if _, err := b.assignSlug(blog); err != nil {
+			return assigned, err
+		}
+		assigned++
+	}
+
+	return assigned, nil
+}
+
+func (b *blogUseCase) GetPaginatedBlogs(page, limit int) ([]models.Blog, int64, error) {
+	blogs, err := b.blogRepo.GetPaginatedBlogs(page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := b.blogRepo.CountPublishedBlogs()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blogs, total, nil
+}
+
+// GetBlogsByCursor returns the published blogs next to the cursor, newest first
+func (b *blogUseCase) GetBlogsByCursor(page models.PageRequest) ([]models.Blog, models.PageInfo, error) {
+	blogs, hasMore, err := b.blogRepo.GetBlogsByCursor(page)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	info := pageInfo(page, hasMore, len(blogs), func(i int) models.Cursor {
+		return models.Cursor{CreatedAt: blogs[i].CreatedAt, ID: blogs[i].ID}
+	})
+	if page.WithTotal {
+		total, err := b.blogRepo.CountPublishedBlogs()
+		if err != nil {
+			return nil, models.PageInfo{}, err
+		}
+		info.Total = &total
+	}
+
+	return blogs, info, nil
 }
 
 func (b *blogUseCase) GetBlogByID(blogID string) (models.Blog, error) {
 	return b.blogRepo.GetBlogByID(blogID)
 }
 
+func (b *blogUseCase) GetBlogBySlug(slug string) (models.Blog, error) {
+	record, err := b.slugRepo.GetSlug(slug)
+	if err != nil {
+		return models.Blog{}, err
+	}
+	if record == nil {
+		return models.Blog{}, models.ErrBlogNotFound
+	}
+
+	return b.blogRepo.GetBlogByID(record.BlogID)
+}
+
+// UpdateBlog archives the current version before overwriting it whenever title, content or tags change.
+// Tags are compared and stored in their canonical form. Changed posts are moderated again, and a
+// post held for review stays unpublished until an admin approves it.
 func (b *blogUseCase) UpdateBlog(blog models.Blog) (models.Blog, error) {
-	return b.blogRepo.UpdateBlog(blog)
+	current, err := b.blogRepo.GetBlogByID(blog.ID)
+	if err != nil {
+		return models.Blog{}, err
+	}
+
+	clearScheduleIfPublished(&blog)
+
+	tags, err := canonicalTags(b.tagRepo, blog.Tags)
+	if err != nil {
+		return models.Blog{}, err
+	}
+	blog.Tags = tags
+
+	changed := blogContentChanged(current, blog)
+	publish := blog.IsPublished
+
+	// A pending post is requeued even when unchanged, so the queue knows whether to publish it
+	blog.ModerationStatus = current.ModerationStatus
+	var held *models.ModerationVerdict
+	if changed || current.ModerationStatus == models.ModerationStatusPending {
+		verdict := models.ModerationVerdict{Decision: models.ModerationAllow}
+		if changed {
+			verdict, err = b.moderationUC.Check(context.TODO(), blogModerationContent(blog))
+			if err != nil {
+				return models.Blog{}, err
+			}
+		}
+		if models.IsModerationHeld(current.ModerationStatus) {
+			verdict = verdict.Merge(editedWhileHeld)
+		}
+		if status := verdict.Status(); status != "" {
+			blog.ModerationStatus = status
+			held = &verdict
+		}
+	}
+	if models.IsModerationHeld(blog.ModerationStatus) {
+		blog.IsPublished = false
+	}
+
+	// Renaming moves the blog to a new slug; the old one stays reserved and redirects
+	blog.Slug = current.Slug
+	reservedAt := time.Now()
+	if current.Slug == "" || slugify(blog.Title) != slugify(current.Title) {
+		slug, err := b.reserveSlug(blog.Title, blog.ID)
+		if err != nil {
+			return models.Blog{}, err
+		}
+		blog.Slug = slug
+	}
+
+	currentVersion := blogVersion(current)
+	blog.Version = currentVersion
+
+	if changed {
+		if _, err := b.revisionRepo.CreateRevision(revisionFromBlog(current)); err != nil {
+			b.releaseNewSlug(blog, current.Slug, reservedAt)
+			return models.Blog{}, err
+		}
+		blog.Version = currentVersion + 1
+	}
+
+	updatedBlog, err := b.blogRepo.UpdateBlog(blog, currentVersion)
+	if err != nil {
+		// The archived version would otherwise block every later edit
+		if changed {
+			if deleteErr := b.revisionRepo.DeleteRevision(current.ID, currentVersion); deleteErr != nil {
+				log.Printf("Failed to delete revision %d of blog %s after a failed update: %v", currentVersion, current.ID, deleteErr)
+			}
+		}
+		b.releaseNewSlug(blog, current.Slug, reservedAt)
+		return models.Blog{}, err
+	}
+
+	if held != nil {
+		if _, err := b.moderationUC.Hold(blogModerationContent(updatedBlog), *held, publish); err != nil {
+			return models.Blog{}, err
+		}
+	}
+
+	return updatedBlog, nil
 }
 
+// DeleteBlog removes the blog, frees its current and earlier slugs and drops the reactions recorded on it
 func (b *blogUseCase) DeleteBlog(blogID string) error {
-	return b.blogRepo.DeleteBlog(blogID)
+	if err := b.blogRepo.DeleteBlog(blogID); err != nil {
+		return err
+	}
+	if err := b.slugRepo.ReleaseBlogSlugs(blogID); err != nil {
+		return err
+	}
+	return b.reactionRepo.DeleteBlogReactions(blogID)
 }
 
-func (b *blogUseCase) SearchBlogs(query string) ([]models.Blog, error) {
-	return b.blogRepo.SearchBlogs(query)
+// SearchBlogs runs a ranked full-text search and attaches highlighted snippets to each hit
+func (b *blogUseCase) SearchBlogs(query string, page, limit int) ([]models.BlogSearchResult, int64, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		// Nothing searchable left once operators are stripped
+		return []models.BlogSearchResult{}, 0, nil
+	}
+	textQuery := strings.Join(terms, " ")
+
+	results, err := b.blogRepo.SearchBlogs(textQuery, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := b.blogRepo.CountSearchResults(textQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Highlights = highlightSnippets(results[i].Blog.Content, terms)
+	}
+
+	return results, total, nil
 }
 
+// FilterBlogs matches tags in their canonical form, so filtering by an alias finds the blogs
 func (b *blogUseCase) FilterBlogs(tags []string, dateRange [2]string, sortBy string) ([]models.Blog, error) {
+	tags, err := canonicalTags(b.tagRepo, tags)
+	if err != nil {
+		return nil, err
+	}
+
 	return b.blogRepo.FilterBlogs(tags, dateRange, sortBy)
 }
 
+// QueryBlogs fills in defaults, strips search operators and resolves tag aliases before running
+// a faceted query
+func (b *blogUseCase) QueryBlogs(query models.BlogQuery) (models.BlogQueryResult, error) {
+	query.Text = strings.Join(searchTerms(query.Text), " ")
+
+	tags, err := canonicalTags(b.tagRepo, query.Tags)
+	if err != nil {
+		return models.BlogQueryResult{}, err
+	}
+	query.Tags = tags
+
+	if query.TagMatch != models.TagMatchAll {
+		query.TagMatch = models.TagMatchAny
+	}
+
+	// Relevance is the natural order for text queries and meaningless without one
+	if query.SortBy == "" && query.Text != "" {
+		query.SortBy = models.SortByRelevance
+	}
+	if query.SortBy == "" || (query.SortBy == models.SortByRelevance && query.Text == "") {
+		query.SortBy = "created_at"
+	}
+	if query.SortOrder == 0 {
+		query.SortOrder = -1
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 {
+		query.Limit = 10
+	}
+
+	return b.blogRepo.QueryBlogs(query)
+}
+
 func (b *blogUseCase) IncrementViewCount(blogID string) error {
 	return b.blogRepo.IncrementViewCount(blogID)
 }
@@ -72,10 +397,455 @@ func (b *blogUseCase) UpdateDislikes(blogID string, increment bool) error {
 	return b.blogRepo.UpdateDislikes(blogID, increment)
 }
 
+// AddComment threads the comment under its parent. A comment the moderator holds back is saved
+// hidden and queued for review.
 func (b *blogUseCase) AddComment(blogID string, comment models.Comment) (models.Comment, error) {
-	return b.blogRepo.AddComment(blogID, comment)
+	if _, err := b.blogRepo.GetBlogByID(blogID); err != nil {
+		return models.Comment{}, models.ErrBlogNotFound
+	}
+
+	comment.BlogID = blogID
+	comment.Depth = 0
+	comment.RootID = ""
+
+	// Replies inherit the thread of their parent
+	if comment.ParentID != "" {
+		parent, err := b.commentRepo.GetCommentByID(comment.ParentID)
+		if err != nil || parent.BlogID != blogID || models.IsModerationHeld(parent.ModerationStatus) {
+			return models.Comment{}, errors.New("parent comment not found")
+		}
+		if parent.IsDeleted {
+			return models.Comment{}, errors.New("cannot reply to a deleted comment")
+		}
+		if parent.Depth >= models.MaxCommentDepth {
+			return models.Comment{}, errors.New("maximum reply depth reached")
+		}
+
+		comment.Depth = parent.Depth + 1
+		comment.RootID = parent.RootID
+		if comment.RootID == "" {
+			comment.RootID = parent.ID
+		}
+	}
+
+	verdict, err := b.moderationUC.Check(context.TODO(), commentModerationContent(comment))
+	if err != nil {
+		return models.Comment{}, err
+	}
+	comment.ModerationStatus = verdict.Status()
+
+	createdComment, err := b.commentRepo.CreateComment(comment)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	if models.IsModerationHeld(createdComment.ModerationStatus) {
+		if _, err := b.moderationUC.Hold(commentModerationContent(createdComment), verdict, false); err != nil {
+			return models.Comment{}, err
+		}
+	}
+
+	return createdComment, nil
+}
+
+// GetComments returns a page of top-level comments with their reply threads nested underneath
+func (b *blogUseCase) GetComments(blogID string, page, limit int) ([]models.Comment, int64, error) {
+	roots, err := b.commentRepo.GetRootComments(blogID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := b.commentRepo.CountRootComments(blogID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	threads, err := b.attachReplies(roots)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return threads, total, nil
+}
+
+// GetCommentsByCursor returns the top-level comments next to the cursor, oldest first, with their threads
+func (b *blogUseCase) GetCommentsByCursor(blogID string, page models.PageRequest) ([]models.Comment, models.PageInfo, error) {
+	roots, hasMore, err := b.commentRepo.GetRootCommentsByCursor(blogID, page)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	info := pageInfo(page, hasMore, len(roots), func(i int) models.Cursor {
+		return models.Cursor{CreatedAt: roots[i].CreatedAt, ID: roots[i].ID}
+	})
+	if page.WithTotal {
+		total, err := b.commentRepo.CountRootComments(blogID)
+		if err != nil {
+			return nil, models.PageInfo{}, err
+		}
+		info.Total = &total
+	}
+
+	threads, err := b.attachReplies(roots)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	return threads, info, nil
+}
+
+// attachReplies loads the reply threads of the given top-level comments and nests them
+func (b *blogUseCase) attachReplies(roots []models.Comment) ([]models.Comment, error) {
+	rootIDs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.ID)
+	}
+
+	replies, err := b.commentRepo.GetThreadReplies(rootIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCommentThreads(roots, replies), nil
+}
+
+func (b *blogUseCase) GetCommentByID(commentID string) (models.Comment, error) {
+	return b.commentRepo.GetCommentByID(commentID)
+}
+
+// UpdateComment moderates changed content again; an edit to a held comment keeps it in the queue
+func (b *blogUseCase) UpdateComment(commentID string, content string) (models.Comment, error) {
+	current, err := b.commentRepo.GetCommentByID(commentID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+	if current.Content == content {
+		return b.commentRepo.UpdateComment(commentID, content)
+	}
+
+	edited := current
+	edited.Content = content
+	verdict, err := b.moderationUC.Check(context.TODO(), commentModerationContent(edited))
+	if err != nil {
+		return models.Comment{}, err
+	}
+	if models.IsModerationHeld(current.ModerationStatus) {
+		verdict = verdict.Merge(editedWhileHeld)
+	}
+
+	updatedComment, err := b.commentRepo.UpdateComment(commentID, content)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	status := verdict.Status()
+	if status == "" {
+		return updatedComment, nil
+	}
+	if status != current.ModerationStatus {
+		if err := b.commentRepo.SetModerationStatus(commentID, status); err != nil {
+			return models.Comment{}, err
+		}
+		updatedComment.ModerationStatus = status
+	}
+	if _, err := b.moderationUC.Hold(commentModerationContent(updatedComment), verdict, false); err != nil {
+		return models.Comment{}, err
+	}
+
+	return updatedComment, nil
+}
+
+func (b *blogUseCase) DeleteComment(commentID string) error {
+	return b.commentRepo.SoftDeleteComment(commentID)
+}
+
+// GetMyBlogs lists the author's own posts, drafts included, with the total for the status filter
+func (b *blogUseCase) GetMyBlogs(authorID, status string, page, limit int) ([]models.Blog, int64, error) {
+	if status == "" {
+		status = models.BlogStatusAll
+	}
+	switch status {
+	case models.BlogStatusAll, models.BlogStatusDraft, models.BlogStatusScheduled, models.BlogStatusPublished:
+	default:
+		return nil, 0, errors.New("invalid status filter")
+	}
+
+	blogs, err := b.blogRepo.GetBlogsByAuthor(authorID, status, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := b.blogRepo.CountBlogsByAuthor(authorID, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blogs, total, nil
+}
+
+// GetScheduledBlogs lists the author's drafts that are waiting to be published
+func (b *blogUseCase) GetScheduledBlogs(authorID string) ([]models.Blog, error) {
+	return b.blogRepo.GetScheduledBlogs(authorID)
+}
+
+// PublishDueBlogs publishes every draft whose PublishAt has passed.
+// Each draft is claimed atomically by the repository, so concurrent schedulers never publish the same post twice.
+func (b *blogUseCase) PublishDueBlogs() (int, error) {
+	published := 0
+	for {
+		blog, err := b.blogRepo.PublishDueBlog(time.Now())
+		if err != nil {
+			return published, err
+		}
+		if blog == nil {
+			return published, nil
+		}
+		published++
+	}
+}
+
+// GetRevisions lists every version of a blog, newest (current) first
+func (b *blogUseCase) GetRevisions(blogID string) ([]models.BlogRevision, error) {
+	blog, err := b.blogRepo.GetBlogByID(blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := b.revisionRepo.GetRevisions(blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := revisionFromBlog(blog)
+	current.IsCurrent = true
+	return append([]models.BlogRevision{current}, archived...), nil
+}
+
+// GetRevision returns a single version; the current version is served from the blog itself
+func (b *blogUseCase) GetRevision(blogID string, version int) (models.BlogRevision, error) {
+	blog, err := b.blogRepo.GetBlogByID(blogID)
+	if err != nil {
+		return models.BlogRevision{}, err
+	}
+
+	if version == blogVersion(blog) {
+		current := revisionFromBlog(blog)
+		current.IsCurrent = true
+		return current, nil
+	}
+	if version < 1 || version > blogVersion(blog) {
+		return models.BlogRevision{}, errors.New("revision not found")
+	}
+
+	return b.revisionRepo.GetRevision(blogID, version)
+}
+
+func (b *blogUseCase) DiffRevisions(blogID string, fromVersion, toVersion int) (models.RevisionDiff, error) {
+	from, err := b.GetRevision(blogID, fromVersion)
+	if err != nil {
+		return models.RevisionDiff{}, err
+	}
+
+	to, err := b.GetRevision(blogID, toVersion)
+	if err != nil {
+		return models.RevisionDiff{}, err
+	}
+
+	diff := models.RevisionDiff{
+		BlogID:      blogID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		FromTitle:   from.Title,
+		ToTitle:     to.Title,
+		Lines:       diffLines(splitLines(from.Content), splitLines(to.Content)),
+	}
+	for _, line := range diff.Lines {
+		switch line.Op {
+		case models.DiffInsert:
+			diff.Added++
+		case models.DiffDelete:
+			diff.Removed++
+		}
+	}
+
+	return diff, nil
 }
 
-func (b *blogUseCase) GetComments(blogID string) ([]models.Comment, error) {
-	return b.blogRepo.GetComments(blogID)
+// RestoreRevision makes an old version current again. The replaced version is archived like any other edit.
+func (b *blogUseCase) RestoreRevision(blogID string, version int) (models.Blog, error) {
+	revision, err := b.GetRevision(blogID, version)
+	if err != nil {
+		return models.Blog{}, err
+	}
+
+	blog, err := b.blogRepo.GetBlogByID(blogID)
+	if err != nil {
+		return models.Blog{}, err
+	}
+
+	blog.Title = revision.Title
+	blog.Content = revision.Content
+	blog.Tags = revision.Tags
+
+	return b.UpdateBlog(blog)
+}
+
+func (b *blogUseCase) ReactToBlog(blogID, userID, reactionType string) (models.Reaction, error) {
+	if !isValidReaction(reactionType) {
+		return models.Reaction{}, models.ErrInvalidReaction
+	}
+	return b.reactionRepo.SetReaction(userID, blogID, reactionType)
+}
+
+func (b *blogUseCase) RemoveReaction(blogID, userID, reactionType string) error {
+	if !isValidReaction(reactionType) {
+		return models.ErrInvalidReaction
+	}
+	return b.reactionRepo.RemoveReaction(userID, blogID, reactionType)
+}
+
+func (b *blogUseCase) GetUserReaction(blogID, userID string) (string, error) {
+	reaction, err := b.reactionRepo.GetReaction(userID, blogID)
+	if err != nil {
+		return "", err
+	}
+	if reaction == nil {
+		return models.ReactionNone, nil
+	}
+	return reaction.Type, nil
+}
+
+func isValidReaction(reactionType string) bool {
+	return reactionType == models.ReactionLike || reactionType == models.ReactionDislike
+}
+
+// buildCommentThreads nests replies under their parents, keeping creation order
+func buildCommentThreads(roots, replies []models.Comment) []models.Comment {
+	children := make(map[string][]models.Comment)
+	for _, reply := range replies {
+		children[reply.ParentID] = append(children[reply.ParentID], reply)
+	}
+
+	var attach func(comment models.Comment) models.Comment
+	attach = func(comment models.Comment) models.Comment {
+		for _, child := range children[comment.ID] {
+			comment.Replies = append(comment.Replies, attach(child))
+		}
+		return comment
+	}
+
+	threads := make([]models.Comment, 0, len(roots))
+	for _, root := range roots {
+		threads = append(threads, attach(root))
+	}
+	return threads
+}
+
+// blogVersion treats blogs created before versioning as version 1
+func blogVersion(blog models.Blog) int {
+	if blog.Version < 1 {
+		return 1
+	}
+	return blog.Version
+}
+
+func blogContentChanged(current, updated models.Blog) bool {
+	if current.Title != updated.Title || current.Content != updated.Content {
+		return true
+	}
+	if len(current.Tags) != len(updated.Tags) {
+		return true
+	}
+	for i := range current.Tags {
+		if current.Tags[i] != updated.Tags[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func blogModerationContent(blog models.Blog) models.ModerationContent {
+	return models.ModerationContent{
+		Type:     models.ModerationContentBlog,
+		ID:       blog.ID,
+		AuthorID: blog.AuthorID,
+		Title:    blog.Title,
+		Body:     blog.Content,
+	}
+}
+
+func commentModerationContent(comment models.Comment) models.ModerationContent {
+	return models.ModerationContent{
+		Type:     models.ModerationContentComment,
+		ID:       comment.ID,
+		BlogID:   comment.BlogID,
+		AuthorID: comment.AuthorID,
+		Body:     comment.Content,
+	}
+}
+
+func revisionFromBlog(blog models.Blog) models.BlogRevision {
+	return models.BlogRevision{
+		BlogID:    blog.ID,
+		Version:   blogVersion(blog),
+		Title:     blog.Title,
+		Content:   blog.Content,
+		Tags:      blog.Tags,
+		CreatedAt: blog.UpdatedAt,
+	}
+}
+
+// clearScheduleIfPublished drops the schedule of a post that is already live
+func clearScheduleIfPublished(blog *models.Blog) {
+	if blog.IsPublished {
+		blog.PublishAt = nil
+	}
+}
+
+// reserveSlug claims the slug for the title, adding -2, -3, ... on collisions
+// and finally the blog ID, which is unique by construction
+// releaseNewSlug gives back the slug an update that did not go through reserved. A slug the blog
+// used before is reserved from earlier and keeps redirecting, so only one claimed since
+// reservedAt is released.
+func (b *blogUseCase) releaseNewSlug(blog models.Blog, currentSlug string, reservedAt time.Time) {
+	if blog.Slug == currentSlug {
+		return
+	}
+
+	record, err := b.slugRepo.GetSlug(blog.Slug)
+	if err != nil || record == nil || record.BlogID != blog.ID || record.CreatedAt.Before(reservedAt) {
+		return
+	}
+	if err := b.slugRepo.ReleaseSlug(blog.Slug, blog.ID); err != nil {
+		log.Printf("Failed to release slug %s of blog %s after a failed update: %v", blog.Slug, blog.ID, err)
+	}
+}
+
+func (b *blogUseCase) reserveSlug(title, blogID string) (string, error) {
+	base := slugify(title)
+
+	for i := 1; i <= maxSlugSuffix; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = base + "-" + strconv.Itoa(i)
+		}
+
+		ok, err := b.slugRepo.ReserveSlug(candidate, blogID)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return candidate, nil
+		}
+	}
+
+	candidate := base + "-" + blogID
+	ok, err := b.slugRepo.ReserveSlug(candidate, blogID)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", errors.New("could not reserve a unique slug")
+	}
+	return candidate, nil
 }
@@ -4,6 +4,7 @@ import (
 	"blog-api/Domain/models"
 	"blog-api/mocks"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
@@ -66,7 +67,7 @@ func TestBlogUseCase_CreateBlog(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			result, err := useCase.CreateBlog(tt.blog)
 
 			if tt.expectError {
@@ -103,6 +104,7 @@ func TestBlogUseCase_GetPaginatedBlogs(t *testing.T) {
 					{ID: "blog2", Title: "Blog 2", Content: "Content 2"},
 				}
 				mockRepo.On("GetPaginatedBlogs", 1, 10).Return(blogs, nil)
+				mockRepo.On("CountPublishedBlogs").Return(int64(12), nil)
 			},
 			expectError: false,
 			expectedLen: 2,
@@ -124,8 +126,8 @@ func TestBlogUseCase_GetPaginatedBlogs(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
-			result, err := useCase.GetPaginatedBlogs(tt.page, tt.limit)
+			useCase := newTestBlogUseCase(mockRepo)
+			result, total, err := useCase.GetPaginatedBlogs(tt.page, tt.limit)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -133,6 +135,7 @@ func TestBlogUseCase_GetPaginatedBlogs(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Len(t, result, tt.expectedLen)
+				assert.Equal(t, int64(12), total)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -140,6 +143,101 @@ func TestBlogUseCase_GetPaginatedBlogs(t *testing.T) {
 	}
 }
 
+func TestBlogUseCase_GetBlogsByCursor(t *testing.T) {
+	t1 := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	blogs := []models.Blog{
+		{ID: "blog1", CreatedAt: t1},
+		{ID: "blog2", CreatedAt: t2},
+	}
+	cursor := &models.Cursor{CreatedAt: t1, ID: "blog0"}
+	backCursor := &models.Cursor{CreatedAt: t2, ID: "blog3", Backward: true}
+
+	tests := []struct {
+		name       string
+		page       models.PageRequest
+		hasMore    bool
+		expectNext *models.Cursor
+		expectPrev *models.Cursor
+		expectErr  bool
+	}{
+		{
+			name:       "First page with more to come",
+			page:       models.PageRequest{Limit: 2},
+			hasMore:    true,
+			expectNext: &models.Cursor{CreatedAt: t2, ID: "blog2"},
+		},
+		{
+			name:       "Last page reached forwards",
+			page:       models.PageRequest{Cursor: cursor, Limit: 2},
+			expectPrev: &models.Cursor{CreatedAt: t1, ID: "blog1", Backward: true},
+		},
+		{
+			name:       "Backward page in the middle",
+			page:       models.PageRequest{Cursor: backCursor, Limit: 2},
+			hasMore:    true,
+			expectNext: &models.Cursor{CreatedAt: t2, ID: "blog2"},
+			expectPrev: &models.Cursor{CreatedAt: t1, ID: "blog1", Backward: true},
+		},
+		{
+			name:       "Backward page reaching the start",
+			page:       models.PageRequest{Cursor: backCursor, Limit: 2},
+			expectNext: &models.Cursor{CreatedAt: t2, ID: "blog2"},
+		},
+		{
+			name:      "Error - Repository error",
+			page:      models.PageRequest{Limit: 2},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			if tt.expectErr {
+				mockRepo.On("GetBlogsByCursor", tt.page).Return(nil, false, errors.New("database error"))
+			} else {
+				mockRepo.On("GetBlogsByCursor", tt.page).Return(blogs, tt.hasMore, nil)
+			}
+
+			useCase := newTestBlogUseCase(mockRepo)
+			result, info, err := useCase.GetBlogsByCursor(tt.page)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, 2)
+				assert.Equal(t, tt.expectNext, info.Next)
+				assert.Equal(t, tt.expectPrev, info.Prev)
+				assert.Nil(t, info.Total)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_GetBlogsByCursor_WithTotal(t *testing.T) {
+	mockRepo := &mocks.BlogRepositoryMock{}
+	page := models.PageRequest{Limit: 10, WithTotal: true}
+	mockRepo.On("GetBlogsByCursor", page).Return([]models.Blog{}, false, nil)
+	mockRepo.On("CountPublishedBlogs").Return(int64(0), nil)
+
+	useCase := newTestBlogUseCase(mockRepo)
+	result, info, err := useCase.GetBlogsByCursor(page)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Nil(t, info.Next)
+	assert.Nil(t, info.Prev)
+	if assert.NotNil(t, info.Total) {
+		assert.Equal(t, int64(0), *info.Total)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
 func TestBlogUseCase_GetBlogByID(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -175,7 +273,7 @@ func TestBlogUseCase_GetBlogByID(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			result, err := useCase.GetBlogByID(tt.blogID)
 
 			if tt.expectError {
@@ -212,7 +310,8 @@ func TestBlogUseCase_UpdateBlog(t *testing.T) {
 					Content:   "Updated Content",
 					UpdatedAt: time.Now(),
 				}
-				mockRepo.On("UpdateBlog", mock.AnythingOfType("models.Blog")).Return(updatedBlog, nil)
+				mockRepo.On("GetBlogByID", "blog123").Return(updatedBlog, nil)
+				mockRepo.On("UpdateBlog", mock.AnythingOfType("models.Blog"), 1).Return(updatedBlog, nil)
 			},
 			expectError: false,
 		},
@@ -224,7 +323,8 @@ func TestBlogUseCase_UpdateBlog(t *testing.T) {
 				Content: "Updated Content",
 			},
 			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				mockRepo.On("UpdateBlog", mock.AnythingOfType("models.Blog")).Return(models.Blog{}, errors.New("update failed"))
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", Title: "Updated Blog", Content: "Updated Content"}, nil)
+				mockRepo.On("UpdateBlog", mock.AnythingOfType("models.Blog"), 1).Return(models.Blog{}, errors.New("update failed"))
 			},
 			expectError: true,
 		},
@@ -235,7 +335,7 @@ func TestBlogUseCase_UpdateBlog(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			result, err := useCase.UpdateBlog(tt.blog)
 
 			if tt.expectError {
@@ -256,21 +356,23 @@ func TestBlogUseCase_DeleteBlog(t *testing.T) {
 	tests := []struct {
 		name        string
 		blogID      string
-		setupMock   func(*mocks.BlogRepositoryMock)
+		setupMock   func(*mocks.BlogRepositoryMock, *mocks.SlugRepositoryMock, *mocks.ReactionRepositoryMock)
 		expectError bool
 	}{
 		{
-			name:   "Success - Delete blog",
+			name:   "Success - Delete blog, its slugs and its reactions",
 			blogID: "blog123",
-			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockSlugRepo *mocks.SlugRepositoryMock, mockReactionRepo *mocks.ReactionRepositoryMock) {
 				mockRepo.On("DeleteBlog", "blog123").Return(nil)
+				mockSlugRepo.On("ReleaseBlogSlugs", "blog123").Return(nil)
+				mockReactionRepo.On("DeleteBlogReactions", "blog123").Return(nil)
 			},
 			expectError: false,
 		},
 		{
 			name:   "Error - Repository error",
 			blogID: "nonexistent",
-			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockSlugRepo *mocks.SlugRepositoryMock, mockReactionRepo *mocks.ReactionRepositoryMock) {
 				mockRepo.On("DeleteBlog", "nonexistent").Return(errors.New("delete failed"))
 			},
 			expectError: true,
@@ -280,18 +382,24 @@ func TestBlogUseCase_DeleteBlog(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
-			tt.setupMock(mockRepo)
+			mockSlugRepo := &mocks.SlugRepositoryMock{}
+			mockReactionRepo := &mocks.ReactionRepositoryMock{}
+			tt.setupMock(mockRepo, mockSlugRepo, mockReactionRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := NewBlogUseCase(mockRepo, mockReactionRepo, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, mockSlugRepo, newTestTagRepo(), newTestModeration())
 			err := useCase.DeleteBlog(tt.blogID)
 
 			if tt.expectError {
 				assert.Error(t, err)
+				mockSlugRepo.AssertNotCalled(t, "ReleaseBlogSlugs", mock.Anything)
+				mockReactionRepo.AssertNotCalled(t, "DeleteBlogReactions", mock.Anything)
 			} else {
 				assert.NoError(t, err)
 			}
 
 			mockRepo.AssertExpectations(t)
+			mockSlugRepo.AssertExpectations(t)
+			mockReactionRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -308,20 +416,38 @@ func TestBlogUseCase_SearchBlogs(t *testing.T) {
 			name:  "Success - Search blogs",
 			query: "test",
 			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				blogs := []models.Blog{
-					{ID: "blog1", Title: "Test Blog 1"},
-					{ID: "blog2", Title: "Test Blog 2"},
+				results := []models.BlogSearchResult{
+					{Blog: models.Blog{ID: "blog1", Title: "Test Blog 1", Content: "A test post"}, Score: 2.5},
+					{Blog: models.Blog{ID: "blog2", Title: "Test Blog 2"}, Score: 1.1},
 				}
-				mockRepo.On("SearchBlogs", "test").Return(blogs, nil)
+				mockRepo.On("SearchBlogs", "test", 1, 10).Return(results, nil)
+				mockRepo.On("CountSearchResults", "test").Return(int64(2), nil)
 			},
 			expectError: false,
 			expectedLen: 2,
 		},
+		{
+			name:  "Operators are stripped from the query",
+			query: `"go -python" -rust`,
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+				mockRepo.On("SearchBlogs", "go python rust", 1, 10).Return([]models.BlogSearchResult{}, nil)
+				mockRepo.On("CountSearchResults", "go python rust").Return(int64(0), nil)
+			},
+			expectError: false,
+			expectedLen: 0,
+		},
+		{
+			name:        "Nothing searchable",
+			query:       `"" --`,
+			setupMock:   func(mockRepo *mocks.BlogRepositoryMock) {},
+			expectError: false,
+			expectedLen: 0,
+		},
 		{
 			name:  "Error - Repository error",
 			query: "test",
 			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				mockRepo.On("SearchBlogs", "test").Return(nil, errors.New("search failed"))
+				mockRepo.On("SearchBlogs", "test", 1, 10).Return(nil, errors.New("search failed"))
 			},
 			expectError: true,
 			expectedLen: 0,
@@ -333,8 +459,8 @@ func TestBlogUseCase_SearchBlogs(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
-			result, err := useCase.SearchBlogs(tt.query)
+			useCase := newTestBlogUseCase(mockRepo)
+			result, _, err := useCase.SearchBlogs(tt.query, 1, 10)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -349,6 +475,84 @@ func TestBlogUseCase_SearchBlogs(t *testing.T) {
 	}
 }
 
+func TestBlogUseCase_QueryBlogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    models.BlogQuery
+		expected func(models.BlogQuery) bool
+	}{
+		{
+			name:  "Defaults to newest first",
+			query: models.BlogQuery{},
+			expected: func(q models.BlogQuery) bool {
+				return q.SortBy == "created_at" && q.SortOrder == -1 && q.TagMatch == models.TagMatchAny && q.Page == 1 && q.Limit == 10
+			},
+		},
+		{
+			name:  "Text queries sort by relevance and lose operators",
+			query: models.BlogQuery{Text: `"golang" -tips`, Page: 2, Limit: 5},
+			expected: func(q models.BlogQuery) bool {
+				return q.Text == "golang tips" && q.SortBy == models.SortByRelevance && q.Page == 2 && q.Limit == 5
+			},
+		},
+		{
+			name:  "Relevance without text falls back to date",
+			query: models.BlogQuery{SortBy: models.SortByRelevance, SortOrder: 1, TagMatch: models.TagMatchAll},
+			expected: func(q models.BlogQuery) bool {
+				return q.SortBy == "created_at" && q.SortOrder == 1 && q.TagMatch == models.TagMatchAll
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			mockRepo.On("QueryBlogs", mock.MatchedBy(tt.expected)).Return(models.BlogQueryResult{Total: 1}, nil)
+
+			useCase := newTestBlogUseCase(mockRepo)
+			result, err := useCase.QueryBlogs(tt.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), result.Total)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHighlightSnippets(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected []string
+	}{
+		{
+			name:     "Marks every match case-insensitively",
+			text:     "Go is fun. I like go.",
+			terms:    []string{"go"},
+			expected: []string{"<mark>Go</mark> is fun. I like <mark>go</mark>."},
+		},
+		{
+			name:     "Escapes HTML and regex metacharacters",
+			text:     "Use <b>a.b</b> or a+b",
+			terms:    []string{"a+b"},
+			expected: []string{"Use &lt;b&gt;a.b&lt;/b&gt; or <mark>a+b</mark>"},
+		},
+		{
+			name:     "Falls back to the start of the content",
+			text:     "Nothing to see here",
+			terms:    []string{"missing"},
+			expected: []string{"Nothing to see here"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, highlightSnippets(tt.text, tt.terms))
+		})
+	}
+}
+
 func TestBlogUseCase_FilterBlogs(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -392,7 +596,7 @@ func TestBlogUseCase_FilterBlogs(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			result, err := useCase.FilterBlogs(tt.tags, tt.dateRange, tt.sortBy)
 
 			if tt.expectError {
@@ -438,7 +642,7 @@ func TestBlogUseCase_IncrementViewCount(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			err := useCase.IncrementViewCount(tt.blogID)
 
 			if tt.expectError {
@@ -494,7 +698,7 @@ func TestBlogUseCase_UpdateLikes(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			err := useCase.UpdateLikes(tt.blogID, tt.increment)
 
 			if tt.expectError {
@@ -550,7 +754,7 @@ func TestBlogUseCase_UpdateDislikes(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
 			tt.setupMock(mockRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := newTestBlogUseCase(mockRepo)
 			err := useCase.UpdateDislikes(tt.blogID, tt.increment)
 
 			if tt.expectError {
@@ -569,19 +773,23 @@ func TestBlogUseCase_AddComment(t *testing.T) {
 		name        string
 		blogID      string
 		comment     models.Comment
-		setupMock   func(*mocks.BlogRepositoryMock)
+		setupMock   func(*mocks.BlogRepositoryMock, *mocks.CommentRepositoryMock)
 		expectError bool
+		expectDepth int
 	}{
 		{
-			name:   "Success - Add comment",
+			name:   "Success - Add top-level comment",
 			blogID: "blog123",
 			comment: models.Comment{
 				AuthorID:   "user123",
 				AuthorName: "test@example.com",
 				Content:    "Great blog post!",
 			},
-			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				expectedComment := models.Comment{
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockCommentRepo *mocks.CommentRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+				mockCommentRepo.On("CreateComment", mock.MatchedBy(func(c models.Comment) bool {
+					return c.BlogID == "blog123" && c.Depth == 0 && c.RootID == ""
+				})).Return(models.Comment{
 					ID:         "comment123",
 					BlogID:     "blog123",
 					AuthorID:   "user123",
@@ -589,10 +797,60 @@ func TestBlogUseCase_AddComment(t *testing.T) {
 					Content:    "Great blog post!",
 					CreatedAt:  time.Now(),
 					UpdatedAt:  time.Now(),
-				}
-				mockRepo.On("AddComment", "blog123", mock.AnythingOfType("models.Comment")).Return(expectedComment, nil)
+				}, nil)
 			},
 			expectError: false,
+			expectDepth: 0,
+		},
+		{
+			name:   "Success - Reply inherits thread root",
+			blogID: "blog123",
+			comment: models.Comment{
+				ParentID: "comment2",
+				AuthorID: "user123",
+				Content:  "I agree",
+			},
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockCommentRepo *mocks.CommentRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+				mockCommentRepo.On("GetCommentByID", "comment2").Return(models.Comment{
+					ID: "comment2", BlogID: "blog123", RootID: "comment1", Depth: 1,
+				}, nil)
+				mockCommentRepo.On("CreateComment", mock.MatchedBy(func(c models.Comment) bool {
+					return c.ParentID == "comment2" && c.RootID == "comment1" && c.Depth == 2
+				})).Return(models.Comment{
+					ID: "comment3", BlogID: "blog123", ParentID: "comment2", RootID: "comment1", Depth: 2, Content: "I agree",
+				}, nil)
+			},
+			expectError: false,
+			expectDepth: 2,
+		},
+		{
+			name:   "Error - Reply too deep",
+			blogID: "blog123",
+			comment: models.Comment{
+				ParentID: "deep",
+				Content:  "Too deep",
+			},
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockCommentRepo *mocks.CommentRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+				mockCommentRepo.On("GetCommentByID", "deep").Return(models.Comment{
+					ID: "deep", BlogID: "blog123", RootID: "comment1", Depth: models.MaxCommentDepth,
+				}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:   "Error - Parent on another blog",
+			blogID: "blog123",
+			comment: models.Comment{
+				ParentID: "other",
+				Content:  "Wrong thread",
+			},
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockCommentRepo *mocks.CommentRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+				mockCommentRepo.On("GetCommentByID", "other").Return(models.Comment{ID: "other", BlogID: "blog456"}, nil)
+			},
+			expectError: true,
 		},
 		{
 			name:   "Error - Repository error",
@@ -602,8 +860,9 @@ func TestBlogUseCase_AddComment(t *testing.T) {
 				AuthorName: "test@example.com",
 				Content:    "Great blog post!",
 			},
-			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				mockRepo.On("AddComment", "blog123", mock.AnythingOfType("models.Comment")).Return(models.Comment{}, errors.New("add comment failed"))
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockCommentRepo *mocks.CommentRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+				mockCommentRepo.On("CreateComment", mock.AnythingOfType("models.Comment")).Return(models.Comment{}, errors.New("add comment failed"))
 			},
 			expectError: true,
 		},
@@ -612,9 +871,10 @@ func TestBlogUseCase_AddComment(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := &mocks.BlogRepositoryMock{}
-			tt.setupMock(mockRepo)
+			mockCommentRepo := &mocks.CommentRepositoryMock{}
+			tt.setupMock(mockRepo, mockCommentRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
+			useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, mockCommentRepo, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
 			result, err := useCase.AddComment(tt.blogID, tt.comment)
 
 			if tt.expectError {
@@ -625,9 +885,11 @@ func TestBlogUseCase_AddComment(t *testing.T) {
 				assert.NotEmpty(t, result.ID)
 				assert.Equal(t, tt.blogID, result.BlogID)
 				assert.Equal(t, tt.comment.Content, result.Content)
+				assert.Equal(t, tt.expectDepth, result.Depth)
 			}
 
 			mockRepo.AssertExpectations(t)
+			mockCommentRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -636,19 +898,25 @@ func TestBlogUseCase_GetComments(t *testing.T) {
 	tests := []struct {
 		name        string
 		blogID      string
-		setupMock   func(*mocks.BlogRepositoryMock)
+		setupMock   func(*mocks.CommentRepositoryMock)
 		expectError bool
 		expectedLen int
 	}{
 		{
-			name:   "Success - Get comments",
+			name:   "Success - Get threaded comments",
 			blogID: "blog123",
-			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				comments := []models.Comment{
+			setupMock: func(mockRepo *mocks.CommentRepositoryMock) {
+				roots := []models.Comment{
 					{ID: "comment1", BlogID: "blog123", Content: "Comment 1"},
 					{ID: "comment2", BlogID: "blog123", Content: "Comment 2"},
 				}
-				mockRepo.On("GetComments", "blog123").Return(comments, nil)
+				replies := []models.Comment{
+					{ID: "reply1", BlogID: "blog123", ParentID: "comment1", RootID: "comment1", Depth: 1},
+					{ID: "reply2", BlogID: "blog123", ParentID: "reply1", RootID: "comment1", Depth: 2},
+				}
+				mockRepo.On("GetRootComments", "blog123", 1, 20).Return(roots, nil)
+				mockRepo.On("CountRootComments", "blog123").Return(int64(2), nil)
+				mockRepo.On("GetThreadReplies", []string{"comment1", "comment2"}).Return(replies, nil)
 			},
 			expectError: false,
 			expectedLen: 2,
@@ -656,8 +924,8 @@ func TestBlogUseCase_GetComments(t *testing.T) {
 		{
 			name:   "Error - Repository error",
 			blogID: "blog123",
-			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
-				mockRepo.On("GetComments", "blog123").Return(nil, errors.New("get comments failed"))
+			setupMock: func(mockRepo *mocks.CommentRepositoryMock) {
+				mockRepo.On("GetRootComments", "blog123", 1, 20).Return(nil, errors.New("get comments failed"))
 			},
 			expectError: true,
 			expectedLen: 0,
@@ -666,11 +934,11 @@ func TestBlogUseCase_GetComments(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := &mocks.BlogRepositoryMock{}
-			tt.setupMock(mockRepo)
+			mockCommentRepo := &mocks.CommentRepositoryMock{}
+			tt.setupMock(mockCommentRepo)
 
-			useCase := NewBlogUseCase(mockRepo)
-			result, err := useCase.GetComments(tt.blogID)
+			useCase := NewBlogUseCase(&mocks.BlogRepositoryMock{}, &mocks.ReactionRepositoryMock{}, mockCommentRepo, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+			result, total, err := useCase.GetComments(tt.blogID, 1, 20)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -678,9 +946,935 @@ func TestBlogUseCase_GetComments(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Len(t, result, tt.expectedLen)
+				assert.Equal(t, int64(2), total)
+				assert.Len(t, result[0].Replies, 1)
+				assert.Equal(t, "reply2", result[0].Replies[0].Replies[0].ID)
+			}
+
+			mockCommentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_GetCommentsByCursor(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	roots := []models.Comment{
+		{ID: "comment1", BlogID: "blog123", CreatedAt: createdAt},
+		{ID: "comment2", BlogID: "blog123", CreatedAt: createdAt},
+	}
+	replies := []models.Comment{
+		{ID: "reply1", BlogID: "blog123", ParentID: "comment2", RootID: "comment2", Depth: 1},
+	}
+	page := models.PageRequest{Limit: 2, WithTotal: true}
+
+	mockCommentRepo := &mocks.CommentRepositoryMock{}
+	mockCommentRepo.On("GetRootCommentsByCursor", "blog123", page).Return(roots, true, nil)
+	mockCommentRepo.On("CountRootComments", "blog123").Return(int64(5), nil)
+	mockCommentRepo.On("GetThreadReplies", []string{"comment1", "comment2"}).Return(replies, nil)
+
+	useCase := NewBlogUseCase(&mocks.BlogRepositoryMock{}, &mocks.ReactionRepositoryMock{}, mockCommentRepo, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+	result, info, err := useCase.GetCommentsByCursor("blog123", page)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Len(t, result[1].Replies, 1)
+	// Comments with the same timestamp are told apart by ID
+	assert.Equal(t, &models.Cursor{CreatedAt: createdAt, ID: "comment2"}, info.Next)
+	assert.Nil(t, info.Prev)
+	assert.Equal(t, int64(5), *info.Total)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_ReactToBlog(t *testing.T) {
+	tests := []struct {
+		name         string
+		reactionType string
+		setupMock    func(*mocks.ReactionRepositoryMock)
+		expectError  bool
+	}{
+		{
+			name:         "Success - Like blog",
+			reactionType: models.ReactionLike,
+			setupMock: func(mockRepo *mocks.ReactionRepositoryMock) {
+				mockRepo.On("SetReaction", "user123", "blog123", models.ReactionLike).Return(models.Reaction{
+					UserID: "user123",
+					BlogID: "blog123",
+					Type:   models.ReactionLike,
+				}, nil)
+			},
+			expectError: false,
+		},
+		{
+			name:         "Error - Invalid reaction type",
+			reactionType: "love",
+			setupMock:    func(mockRepo *mocks.ReactionRepositoryMock) {},
+			expectError:  true,
+		},
+		{
+			name:         "Error - Repository error",
+			reactionType: models.ReactionDislike,
+			setupMock: func(mockRepo *mocks.ReactionRepositoryMock) {
+				mockRepo.On("SetReaction", "user123", "blog123", models.ReactionDislike).Return(models.Reaction{}, models.ErrBlogNotFound)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReactionRepo := &mocks.ReactionRepositoryMock{}
+			tt.setupMock(mockReactionRepo)
+
+			useCase := NewBlogUseCase(&mocks.BlogRepositoryMock{}, mockReactionRepo, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+			result, err := useCase.ReactToBlog("blog123", "user123", tt.reactionType)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.reactionType, result.Type)
+			}
+
+			mockReactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_GetUserReaction(t *testing.T) {
+	tests := []struct {
+		name             string
+		setupMock        func(*mocks.ReactionRepositoryMock)
+		expectedReaction string
+		expectError      bool
+	}{
+		{
+			name: "Success - Existing reaction",
+			setupMock: func(mockRepo *mocks.ReactionRepositoryMock) {
+				mockRepo.On("GetReaction", "user123", "blog123").Return(&models.Reaction{Type: models.ReactionDislike}, nil)
+			},
+			expectedReaction: models.ReactionDislike,
+			expectError:      false,
+		},
+		{
+			name: "Success - No reaction",
+			setupMock: func(mockRepo *mocks.ReactionRepositoryMock) {
+				mockRepo.On("GetReaction", "user123", "blog123").Return(nil, nil)
+			},
+			expectedReaction: models.ReactionNone,
+			expectError:      false,
+		},
+		{
+			name: "Error - Repository error",
+			setupMock: func(mockRepo *mocks.ReactionRepositoryMock) {
+				mockRepo.On("GetReaction", "user123", "blog123").Return(nil, errors.New("database error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReactionRepo := &mocks.ReactionRepositoryMock{}
+			tt.setupMock(mockReactionRepo)
+
+			useCase := NewBlogUseCase(&mocks.BlogRepositoryMock{}, mockReactionRepo, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+			reaction, err := useCase.GetUserReaction("blog123", "user123")
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReaction, reaction)
+			}
+
+			mockReactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_UpdateBlog_Revisions(t *testing.T) {
+	current := models.Blog{
+		ID:        "blog123",
+		Title:     "Original",
+		Content:   "line one\nline two",
+		Tags:      []string{"go"},
+		Version:   2,
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name            string
+		blog            models.Blog
+		setupMock       func(*mocks.BlogRepositoryMock, *mocks.RevisionRepositoryMock)
+		expectedVersion int
+		expectError     bool
+	}{
+		{
+			name: "Content change archives previous version",
+			blog: models.Blog{ID: "blog123", Title: "Original", Content: "line one\nline 2", Tags: []string{"go"}},
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockRevisionRepo *mocks.RevisionRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+				mockRevisionRepo.On("CreateRevision", mock.MatchedBy(func(r models.BlogRevision) bool {
+					return r.BlogID == "blog123" && r.Version == 2 && r.Content == current.Content
+				})).Return(models.BlogRevision{}, nil)
+				mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool { return b.Version == 3 }), 2).
+					Return(models.Blog{ID: "blog123", Version: 3}, nil)
+			},
+			expectedVersion: 3,
+		},
+		{
+			name: "Unchanged content keeps version",
+			blog: models.Blog{ID: "blog123", Title: "Original", Content: "line one\nline two", Tags: []string{"go"}},
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockRevisionRepo *mocks.RevisionRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+				mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool { return b.Version == 2 }), 2).
+					Return(models.Blog{ID: "blog123", Version: 2}, nil)
+			},
+			expectedVersion: 2,
+		},
+		{
+			name: "Concurrent edit is rejected",
+			blog: models.Blog{ID: "blog123", Title: "Changed", Content: "line one\nline two", Tags: []string{"go"}},
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockRevisionRepo *mocks.RevisionRepositoryMock) {
+				mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+				mockRevisionRepo.On("CreateRevision", mock.AnythingOfType("models.BlogRevision")).
+					Return(models.BlogRevision{}, errors.New("blog was modified concurrently, please retry"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+			tt.setupMock(mockRepo, mockRevisionRepo)
+
+			useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+			result, err := useCase.UpdateBlog(tt.blog)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				mockRepo.AssertNotCalled(t, "UpdateBlog", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedVersion, result.Version)
 			}
 
 			mockRepo.AssertExpectations(t)
+			mockRevisionRepo.AssertExpectations(t)
 		})
 	}
 }
+
+// memoryRevisions keys revisions on blog and version, as the revisions collection does
+type memoryRevisions struct {
+	revisions map[string]models.BlogRevision
+}
+
+func (m *memoryRevisions) CreateRevision(revision models.BlogRevision) (models.BlogRevision, error) {
+	key := revision.BlogID + ":" + strconv.Itoa(revision.Version)
+	if _, exists := m.revisions[key]; exists {
+		return models.BlogRevision{}, models.ErrBlogModified
+	}
+	m.revisions[key] = revision
+	return revision, nil
+}
+
+func (m *memoryRevisions) GetRevisions(blogID string) ([]models.BlogRevision, error) {
+	return nil, nil
+}
+
+func (m *memoryRevisions) GetRevision(blogID string, version int) (models.BlogRevision, error) {
+	return m.revisions[blogID+":"+strconv.Itoa(version)], nil
+}
+
+func (m *memoryRevisions) DeleteRevision(blogID string, version int) error {
+	delete(m.revisions, blogID+":"+strconv.Itoa(version))
+	return nil
+}
+
+func TestBlogUseCase_UpdateBlog_FailedUpdateKeepsBlogEditable(t *testing.T) {
+	current := models.Blog{ID: "blog123", Title: "Original", Content: "old", Version: 2}
+	revisions := &memoryRevisions{revisions: map[string]models.BlogRevision{}}
+
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+	mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool { return b.Content == "first" }), 2).
+		Return(models.Blog{}, errors.New("network error"))
+	mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool { return b.Content == "second" }), 2).
+		Return(models.Blog{ID: "blog123", Content: "second", Version: 3}, nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, revisions, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+
+	// The failed update takes its revision back
+	_, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "Original", Content: "first"})
+	assert.Error(t, err)
+	assert.Empty(t, revisions.revisions)
+
+	// So the next edit of the same version goes through
+	result, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "Original", Content: "second"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Version)
+	assert.Equal(t, "old", revisions.revisions["blog123:2"].Content)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_UpdateBlog_LostRace(t *testing.T) {
+	current := models.Blog{ID: "blog123", Title: "Original", Content: "old", Version: 2}
+
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+	mockRepo.On("UpdateBlog", mock.AnythingOfType("models.Blog"), 2).Return(models.Blog{}, models.ErrBlogModified)
+	mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+	mockRevisionRepo.On("CreateRevision", mock.AnythingOfType("models.BlogRevision")).Return(models.BlogRevision{}, nil)
+	mockRevisionRepo.On("DeleteRevision", "blog123", 2).Return(nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+	_, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "Original", Content: "new"})
+
+	assert.ErrorIs(t, err, models.ErrBlogModified)
+	mockRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_GetRevisions(t *testing.T) {
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+
+	mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", Title: "Current", Version: 2}, nil)
+	mockRevisionRepo.On("GetRevisions", "blog123").Return([]models.BlogRevision{
+		{BlogID: "blog123", Version: 1, Title: "First"},
+	}, nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+	revisions, err := useCase.GetRevisions("blog123")
+
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Version)
+	assert.True(t, revisions[0].IsCurrent)
+	assert.Equal(t, 1, revisions[1].Version)
+	assert.False(t, revisions[1].IsCurrent)
+}
+
+func TestBlogUseCase_DiffRevisions(t *testing.T) {
+	tests := []struct {
+		name            string
+		from            int
+		to              int
+		expectedAdded   int
+		expectedRemoved int
+		expectError     bool
+	}{
+		{
+			name:            "Old revision against current",
+			from:            1,
+			to:              2,
+			expectedAdded:   2,
+			expectedRemoved: 1,
+		},
+		{
+			name:            "Current against old revision",
+			from:            2,
+			to:              1,
+			expectedAdded:   1,
+			expectedRemoved: 2,
+		},
+		{
+			name:        "Unknown version",
+			from:        1,
+			to:          5,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+
+			mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{
+				ID:      "blog123",
+				Content: "intro\nnew middle\nextra\noutro",
+				Version: 2,
+			}, nil)
+			mockRevisionRepo.On("GetRevision", "blog123", 1).Return(models.BlogRevision{
+				BlogID:  "blog123",
+				Version: 1,
+				Content: "intro\nold middle\noutro",
+			}, nil)
+
+			useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+			diff, err := useCase.DiffRevisions("blog123", tt.from, tt.to)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.from, diff.FromVersion)
+			assert.Equal(t, tt.to, diff.ToVersion)
+			assert.Equal(t, tt.expectedAdded, diff.Added)
+			assert.Equal(t, tt.expectedRemoved, diff.Removed)
+			assert.Equal(t, models.DiffLine{Op: models.DiffEqual, Text: "intro"}, diff.Lines[0])
+		})
+	}
+}
+
+func TestBlogUseCase_RestoreRevision(t *testing.T) {
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+
+	current := models.Blog{ID: "blog123", AuthorID: "user1", Title: "Current", Content: "new", Version: 2}
+	mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+	mockRevisionRepo.On("GetRevision", "blog123", 1).Return(models.BlogRevision{
+		BlogID: "blog123", Version: 1, Title: "First", Content: "old", Tags: []string{"go"},
+	}, nil)
+	mockRevisionRepo.On("CreateRevision", mock.MatchedBy(func(r models.BlogRevision) bool {
+		return r.Version == 2 && r.Title == "Current"
+	})).Return(models.BlogRevision{}, nil)
+	mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool {
+		return b.Version == 3 && b.Title == "First" && b.Content == "old" && b.AuthorID == "user1"
+	}), mock.Anything).Return(models.Blog{ID: "blog123", Title: "First", Content: "old", Version: 3}, nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+	blog, err := useCase.RestoreRevision("blog123", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, blog.Version)
+	assert.Equal(t, "First", blog.Title)
+	mockRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_CreateBlog_Schedule(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		blog          models.Blog
+		expectPending bool
+	}{
+		{
+			name:          "Draft keeps its schedule",
+			blog:          models.Blog{Title: "Draft", PublishAt: &publishAt},
+			expectPending: true,
+		},
+		{
+			name:          "Published post drops its schedule",
+			blog:          models.Blog{Title: "Live", IsPublished: true, PublishAt: &publishAt},
+			expectPending: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			mockRepo.On("CreateBlog", mock.MatchedBy(func(b models.Blog) bool {
+				return (b.PublishAt != nil) == tt.expectPending
+			})).Return(models.Blog{ID: "blog123"}, nil)
+
+			useCase := newTestBlogUseCase(mockRepo)
+			_, err := useCase.CreateBlog(tt.blog)
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_CanonicalTags(t *testing.T) {
+	vocabulary := []models.Tag{{Name: "go", Aliases: []string{"golang", "go-lang"}}}
+
+	t.Run("CreateBlog stores canonical tags", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockTagRepo := &mocks.TagRepositoryMock{}
+		mockTagRepo.On("FindTags", []string{"golang", "go", "web dev"}).Return(vocabulary, nil)
+		mockRepo.On("CreateBlog", models.Blog{Title: "Hello", Tags: []string{"go", "web dev"}}).Return(models.Blog{ID: "blog123", Title: "Hello", Tags: []string{"go", "web dev"}}, nil)
+		mockRepo.On("UpdateSlug", "blog123", "hello").Return(nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), mockTagRepo, newTestModeration())
+		blog, err := useCase.CreateBlog(models.Blog{Title: "Hello", Tags: []string{"Golang", "Go", "#Web  Dev"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go", "web dev"}, blog.Tags)
+		mockRepo.AssertExpectations(t)
+		mockTagRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateBlog with only alias spellings is no new revision", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockTagRepo := &mocks.TagRepositoryMock{}
+		current := models.Blog{ID: "blog123", Title: "Hello", Slug: "hello", Content: "body", Tags: []string{"go"}, Version: 2}
+		mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+		mockTagRepo.On("FindTags", []string{"go-lang"}).Return(vocabulary, nil)
+		mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool {
+			return assert.ObjectsAreEqual([]string{"go"}, b.Tags) && b.Version == 2
+		}), mock.Anything).Return(current, nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), mockTagRepo, newTestModeration())
+		_, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "Hello", Content: "body", Tags: []string{"Go-Lang"}})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockTagRepo.AssertExpectations(t)
+	})
+}
+
+func TestBlogUseCase_Moderation(t *testing.T) {
+	spam := models.ModerationVerdict{Decision: models.ModerationReview, Reasons: []string{"Consists mostly of links"}}
+
+	t.Run("CreateBlog holds back a flagged post", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		moderationUC := &mocks.ModerationUseCaseMock{}
+		moderationUC.On("Check", mock.Anything, models.ModerationContent{Type: models.ModerationContentBlog, AuthorID: "user123", Title: "Deals", Body: "links"}).Return(spam, nil)
+		mockRepo.On("CreateBlog", mock.MatchedBy(func(b models.Blog) bool {
+			return !b.IsPublished && b.ModerationStatus == models.ModerationStatusPending
+		})).Return(models.Blog{ID: "blog123", Title: "Deals", Content: "links", AuthorID: "user123", ModerationStatus: models.ModerationStatusPending}, nil)
+		mockRepo.On("UpdateSlug", "blog123", "deals").Return(nil)
+		moderationUC.On("Hold", models.ModerationContent{Type: models.ModerationContentBlog, ID: "blog123", AuthorID: "user123", Title: "Deals", Body: "links"}, spam, true).Return(models.ModerationItem{ID: "item123"}, nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), moderationUC)
+		blog, err := useCase.CreateBlog(models.Blog{Title: "Deals", Content: "links", AuthorID: "user123", IsPublished: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModerationStatusPending, blog.ModerationStatus)
+		mockRepo.AssertExpectations(t)
+		moderationUC.AssertExpectations(t)
+	})
+
+	t.Run("UpdateBlog keeps a pending post unpublished and requeues it", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		moderationUC := &mocks.ModerationUseCaseMock{}
+		current := models.Blog{ID: "blog123", Title: "Deals", Slug: "deals", Content: "links", AuthorID: "user123", Tags: []string{}, Version: 1, ModerationStatus: models.ModerationStatusPending}
+		mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+		mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool {
+			return !b.IsPublished && b.ModerationStatus == models.ModerationStatusPending
+		}), mock.Anything).Return(current, nil)
+		moderationUC.On("Hold", mock.Anything, editedWhileHeld, true).Return(models.ModerationItem{ID: "item123"}, nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), moderationUC)
+		_, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "Deals", Content: "links", AuthorID: "user123", Tags: []string{}, IsPublished: true})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		moderationUC.AssertExpectations(t)
+		moderationUC.AssertNotCalled(t, "Check", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdateBlog leaves an approved post alone when the edit passes", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+		current := models.Blog{ID: "blog123", Title: "Hello", Slug: "hello", Content: "old", Tags: []string{}, Version: 1, IsPublished: true, ModerationStatus: models.ModerationStatusApproved}
+		mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+		mockRevisionRepo.On("CreateRevision", mock.Anything).Return(models.BlogRevision{}, nil)
+		mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool {
+			return b.IsPublished && b.ModerationStatus == models.ModerationStatusApproved
+		}), mock.Anything).Return(current, nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+		_, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "Hello", Content: "new", Tags: []string{}, IsPublished: true})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("AddComment hides a flagged comment", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockCommentRepo := &mocks.CommentRepositoryMock{}
+		moderationUC := &mocks.ModerationUseCaseMock{}
+		mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+		moderationUC.On("Check", mock.Anything, mock.Anything).Return(spam, nil)
+		mockCommentRepo.On("CreateComment", mock.MatchedBy(func(c models.Comment) bool {
+			return c.ModerationStatus == models.ModerationStatusPending
+		})).Return(models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "links", ModerationStatus: models.ModerationStatusPending}, nil)
+		moderationUC.On("Hold", models.ModerationContent{Type: models.ModerationContentComment, ID: "comment123", BlogID: "blog123", AuthorID: "user123", Body: "links"}, spam, false).Return(models.ModerationItem{ID: "item123"}, nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, mockCommentRepo, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), moderationUC)
+		comment, err := useCase.AddComment("blog123", models.Comment{AuthorID: "user123", Content: "links"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModerationStatusPending, comment.ModerationStatus)
+		mockCommentRepo.AssertExpectations(t)
+		moderationUC.AssertExpectations(t)
+	})
+
+	t.Run("AddComment cannot reply to a held comment", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockCommentRepo := &mocks.CommentRepositoryMock{}
+		mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123"}, nil)
+		mockCommentRepo.On("GetCommentByID", "held").Return(models.Comment{ID: "held", BlogID: "blog123", ModerationStatus: models.ModerationStatusRejected}, nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, mockCommentRepo, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+		_, err := useCase.AddComment("blog123", models.Comment{ParentID: "held", Content: "me too"})
+
+		assert.Error(t, err)
+		mockCommentRepo.AssertNotCalled(t, "CreateComment", mock.Anything)
+	})
+
+	t.Run("UpdateComment sends an edited rejected comment back for review", func(t *testing.T) {
+		mockCommentRepo := &mocks.CommentRepositoryMock{}
+		moderationUC := newTestModeration()
+		current := models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "spam", ModerationStatus: models.ModerationStatusRejected}
+		mockCommentRepo.On("GetCommentByID", "comment123").Return(current, nil)
+		updated := current
+		updated.Content = "fixed"
+		mockCommentRepo.On("UpdateComment", "comment123", "fixed").Return(updated, nil)
+		mockCommentRepo.On("SetModerationStatus", "comment123", models.ModerationStatusPending).Return(nil)
+		moderationUC.On("Hold", mock.Anything, editedWhileHeld, false).Return(models.ModerationItem{ID: "item123"}, nil)
+
+		useCase := NewBlogUseCase(&mocks.BlogRepositoryMock{}, &mocks.ReactionRepositoryMock{}, mockCommentRepo, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), moderationUC)
+		comment, err := useCase.UpdateComment("comment123", "fixed")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ModerationStatusPending, comment.ModerationStatus)
+		mockCommentRepo.AssertExpectations(t)
+		moderationUC.AssertExpectations(t)
+	})
+}
+
+func TestBlogUseCase_PublishDueBlogs(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(*mocks.BlogRepositoryMock)
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name: "Publishes until nothing is due",
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+				mockRepo.On("PublishDueBlog", mock.AnythingOfType("time.Time")).Return(&models.Blog{ID: "blog1"}, nil).Once()
+				mockRepo.On("PublishDueBlog", mock.AnythingOfType("time.Time")).Return(&models.Blog{ID: "blog2"}, nil).Once()
+				mockRepo.On("PublishDueBlog", mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "Nothing due",
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+				mockRepo.On("PublishDueBlog", mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Repository error",
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+				mockRepo.On("PublishDueBlog", mock.AnythingOfType("time.Time")).Return(&models.Blog{ID: "blog1"}, nil).Once()
+				mockRepo.On("PublishDueBlog", mock.AnythingOfType("time.Time")).Return(nil, errors.New("database error")).Once()
+			},
+			expectedCount: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			tt.setupMock(mockRepo)
+
+			useCase := newTestBlogUseCase(mockRepo)
+			published, err := useCase.PublishDueBlogs()
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCount, published)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_GetMyBlogs(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		setupMock     func(*mocks.BlogRepositoryMock)
+		expectedTotal int64
+		expectError   bool
+	}{
+		{
+			name:   "Defaults to all posts",
+			status: "",
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+				mockRepo.On("GetBlogsByAuthor", "user123", models.BlogStatusAll, 1, 10).Return([]models.Blog{{ID: "blog1"}, {ID: "blog2"}}, nil)
+				mockRepo.On("CountBlogsByAuthor", "user123", models.BlogStatusAll).Return(int64(2), nil)
+			},
+			expectedTotal: 2,
+		},
+		{
+			name:   "Drafts only",
+			status: models.BlogStatusDraft,
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock) {
+				mockRepo.On("GetBlogsByAuthor", "user123", models.BlogStatusDraft, 1, 10).Return([]models.Blog{{ID: "blog1"}}, nil)
+				mockRepo.On("CountBlogsByAuthor", "user123", models.BlogStatusDraft).Return(int64(1), nil)
+			},
+			expectedTotal: 1,
+		},
+		{
+			name:        "Invalid status",
+			status:      "archived",
+			setupMock:   func(mockRepo *mocks.BlogRepositoryMock) {},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			tt.setupMock(mockRepo)
+
+			useCase := newTestBlogUseCase(mockRepo)
+			_, total, err := useCase.GetMyBlogs("user123", tt.status, 1, 10)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTotal, total)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Go 1.23 -- what's new?  ", "go-1-23-what-s-new"},
+		{"Crème brûlée à la française", "creme-brulee-a-la-francaise"},
+		{"Straße nach Øresund", "strasse-nach-oresund"},
+		{"Привет, мир", "privet-mir"},
+		{"Объявление", "obyavlenie"},
+		{"Мой йогурт", "moy-yogurt"},
+		{"Αθήνα", "athina"},
+		{"你好", "post"},
+		{"", "post"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slugify(tt.title))
+		})
+	}
+}
+
+func TestBlogUseCase_CreateBlog_SlugCollision(t *testing.T) {
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockSlugRepo := &mocks.SlugRepositoryMock{}
+
+	mockRepo.On("CreateBlog", mock.AnythingOfType("models.Blog")).Return(models.Blog{ID: "blog123", Title: "Hello World"}, nil)
+	mockSlugRepo.On("ReserveSlug", "hello-world", "blog123").Return(false, nil)
+	mockSlugRepo.On("ReserveSlug", "hello-world-2", "blog123").Return(false, nil)
+	mockSlugRepo.On("ReserveSlug", "hello-world-3", "blog123").Return(true, nil)
+	mockRepo.On("UpdateSlug", "blog123", "hello-world-3").Return(nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, mockSlugRepo, newTestTagRepo(), newTestModeration())
+	blog, err := useCase.CreateBlog(models.Blog{Title: "Hello World"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world-3", blog.Slug)
+	mockRepo.AssertExpectations(t)
+	mockSlugRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_CreateBlog_SlugFailureRemovesBlog(t *testing.T) {
+	t.Run("reservation fails", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockSlugRepo := &mocks.SlugRepositoryMock{}
+
+		mockRepo.On("CreateBlog", mock.AnythingOfType("models.Blog")).Return(models.Blog{ID: "blog123", Title: "Hello World"}, nil)
+		mockSlugRepo.On("ReserveSlug", "hello-world", "blog123").Return(false, errors.New("database error"))
+		mockRepo.On("DeleteBlog", "blog123").Return(nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, mockSlugRepo, newTestTagRepo(), newTestModeration())
+		_, err := useCase.CreateBlog(models.Blog{Title: "Hello World"})
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+		mockSlugRepo.AssertExpectations(t)
+	})
+
+	t.Run("saving the slug fails", func(t *testing.T) {
+		mockRepo := &mocks.BlogRepositoryMock{}
+		mockSlugRepo := &mocks.SlugRepositoryMock{}
+
+		mockRepo.On("CreateBlog", mock.AnythingOfType("models.Blog")).Return(models.Blog{ID: "blog123", Title: "Hello World"}, nil)
+		mockSlugRepo.On("ReserveSlug", "hello-world", "blog123").Return(true, nil)
+		mockRepo.On("UpdateSlug", "blog123", "hello-world").Return(errors.New("database error"))
+		mockSlugRepo.On("ReleaseSlug", "hello-world", "blog123").Return(nil)
+		mockRepo.On("DeleteBlog", "blog123").Return(nil)
+
+		useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, mockSlugRepo, newTestTagRepo(), newTestModeration())
+		_, err := useCase.CreateBlog(models.Blog{Title: "Hello World"})
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+		mockSlugRepo.AssertExpectations(t)
+	})
+}
+
+func TestBlogUseCase_BackfillSlugs(t *testing.T) {
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockSlugRepo := &mocks.SlugRepositoryMock{}
+
+	mockRepo.On("GetBlogsWithoutSlug").Return([]models.Blog{
+		{ID: "blog1", Title: "Hello World"},
+		{ID: "blog2", Title: "Hello World"},
+	}, nil)
+	mockSlugRepo.On("ReserveSlug", "hello-world", "blog1").Return(true, nil)
+	mockSlugRepo.On("ReserveSlug", "hello-world", "blog2").Return(false, nil)
+	mockSlugRepo.On("ReserveSlug", "hello-world-2", "blog2").Return(true, nil)
+	mockRepo.On("UpdateSlug", "blog1", "hello-world").Return(nil)
+	mockRepo.On("UpdateSlug", "blog2", "hello-world-2").Return(nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, mockSlugRepo, newTestTagRepo(), newTestModeration())
+	assigned, err := useCase.BackfillSlugs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, assigned)
+	mockRepo.AssertExpectations(t)
+	mockSlugRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_UpdateBlog_RenameReservesNewSlug(t *testing.T) {
+	mockRepo := &mocks.BlogRepositoryMock{}
+	mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+	mockSlugRepo := &mocks.SlugRepositoryMock{}
+
+	current := models.Blog{ID: "blog123", Title: "Old Title", Slug: "old-title", Version: 1}
+	mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+	mockSlugRepo.On("ReserveSlug", "new-title", "blog123").Return(true, nil)
+	mockRevisionRepo.On("CreateRevision", mock.AnythingOfType("models.BlogRevision")).Return(models.BlogRevision{}, nil)
+	mockRepo.On("UpdateBlog", mock.MatchedBy(func(b models.Blog) bool { return b.Slug == "new-title" }), mock.Anything).
+		Return(models.Blog{ID: "blog123", Title: "New Title", Slug: "new-title"}, nil)
+
+	useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, mockSlugRepo, newTestTagRepo(), newTestModeration())
+	blog, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "New Title", Slug: "old-title"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new-title", blog.Slug)
+	mockRepo.AssertExpectations(t)
+	mockSlugRepo.AssertExpectations(t)
+}
+
+func TestBlogUseCase_UpdateBlog_FailedRenameReleasesNewSlug(t *testing.T) {
+	current := models.Blog{ID: "blog123", Title: "Old Title", Slug: "old-title", Version: 1}
+
+	tests := []struct {
+		name        string
+		reservedAt  time.Time
+		wantRelease bool
+	}{
+		{name: "Newly reserved slug is released", reservedAt: time.Now().Add(time.Minute), wantRelease: true},
+		{name: "Slug the blog used before keeps redirecting", reservedAt: time.Now().Add(-time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			mockRevisionRepo := &mocks.RevisionRepositoryMock{}
+			mockSlugRepo := &mocks.SlugRepositoryMock{}
+
+			mockRepo.On("GetBlogByID", "blog123").Return(current, nil)
+			mockSlugRepo.On("ReserveSlug", "new-title", "blog123").Return(true, nil)
+			mockRevisionRepo.On("CreateRevision", mock.AnythingOfType("models.BlogRevision")).Return(models.BlogRevision{}, nil)
+			mockRepo.On("UpdateBlog", mock.AnythingOfType("models.Blog"), 1).Return(models.Blog{}, errors.New("network error"))
+			mockRevisionRepo.On("DeleteRevision", "blog123", 1).Return(nil)
+			mockSlugRepo.On("GetSlug", "new-title").Return(&models.BlogSlug{Slug: "new-title", BlogID: "blog123", CreatedAt: tt.reservedAt}, nil)
+			if tt.wantRelease {
+				mockSlugRepo.On("ReleaseSlug", "new-title", "blog123").Return(nil)
+			}
+
+			useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, mockRevisionRepo, mockSlugRepo, newTestTagRepo(), newTestModeration())
+			_, err := useCase.UpdateBlog(models.Blog{ID: "blog123", Title: "New Title"})
+
+			assert.Error(t, err)
+			if !tt.wantRelease {
+				mockSlugRepo.AssertNotCalled(t, "ReleaseSlug", mock.Anything, mock.Anything)
+			}
+			mockSlugRepo.AssertExpectations(t)
+			mockRevisionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlogUseCase_GetBlogBySlug(t *testing.T) {
+	tests := []struct {
+		name        string
+		slug        string
+		setupMock   func(*mocks.BlogRepositoryMock, *mocks.SlugRepositoryMock)
+		expectError bool
+	}{
+		{
+			name: "Current slug",
+			slug: "hello-world",
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockSlugRepo *mocks.SlugRepositoryMock) {
+				mockSlugRepo.On("GetSlug", "hello-world").Return(&models.BlogSlug{Slug: "hello-world", BlogID: "blog123"}, nil)
+				mockRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", Slug: "hello-world"}, nil)
+			},
+		},
+		{
+			name: "Unknown slug",
+			slug: "missing",
+			setupMock: func(mockRepo *mocks.BlogRepositoryMock, mockSlugRepo *mocks.SlugRepositoryMock) {
+				mockSlugRepo.On("GetSlug", "missing").Return(nil, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.BlogRepositoryMock{}
+			mockSlugRepo := &mocks.SlugRepositoryMock{}
+			tt.setupMock(mockRepo, mockSlugRepo)
+
+			useCase := NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, mockSlugRepo, newTestTagRepo(), newTestModeration())
+			blog, err := useCase.GetBlogBySlug(tt.slug)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "blog123", blog.ID)
+			}
+			mockRepo.AssertExpectations(t)
+			mockSlugRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// newTestBlogUseCase builds a use case where only the blog repository is exercised
+func newTestBlogUseCase(mockRepo *mocks.BlogRepositoryMock) BlogUseCase {
+	mockRepo.On("UpdateSlug", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return NewBlogUseCase(mockRepo, &mocks.ReactionRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.RevisionRepositoryMock{}, newTestSlugRepo(), newTestTagRepo(), newTestModeration())
+}
+
+// newTestTagRepo has an empty vocabulary, so tags are only normalized
+func newTestTagRepo() *mocks.TagRepositoryMock {
+	tagRepo := &mocks.TagRepositoryMock{}
+	tagRepo.On("FindTags", mock.Anything).Return([]models.Tag{}, nil).Maybe()
+	return tagRepo
+}
+
+// newTestModeration lets all content through
+func newTestModeration() *mocks.ModerationUseCaseMock {
+	moderationUC := &mocks.ModerationUseCaseMock{}
+	moderationUC.On("Check", mock.Anything, mock.Anything).Return(models.ModerationVerdict{Decision: models.ModerationAllow}, nil).Maybe()
+	return moderationUC
+}
+
+// newTestSlugRepo accepts every slug reservation
+func newTestSlugRepo() *mocks.SlugRepositoryMock {
+	slugRepo := &mocks.SlugRepositoryMock{}
+	slugRepo.On("ReserveSlug", mock.Anything, mock.Anything).Return(true, nil).Maybe()
+	slugRepo.On("GetSlug", mock.Anything).Return(nil, nil).Maybe()
+	return slugRepo
+}
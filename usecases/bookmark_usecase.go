@@ -0,0 +1,239 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCollectionName caps the length of a bookmark collection's name
+const maxCollectionName = 50
+
+type bookmarkUseCase struct {
+	bookmarkRepo      interfaces.BookmarkRepository
+	blogRepo          interfaces.BlogRepository
+	recommendationSvc interfaces.RecommendationService
+}
+
+func NewBookmarkUseCase(bookmarkRepo interfaces.BookmarkRepository, blogRepo interfaces.BlogRepository, recommendationSvc interfaces.RecommendationService) interfaces.BookmarkUseCase {
+	return &bookmarkUseCase{
+		bookmarkRepo:      bookmarkRepo,
+		blogRepo:          blogRepo,
+		recommendationSvc: recommendationSvc,
+	}
+}
+
+func (b *bookmarkUseCase) CreateCollection(userID, name string) (models.BookmarkCollection, error) {
+	name, err := collectionName(name)
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	return b.bookmarkRepo.CreateCollection(models.BookmarkCollection{UserID: userID, Name: name})
+}
+
+func (b *bookmarkUseCase) GetCollections(userID string) ([]models.BookmarkCollection, error) {
+	return b.bookmarkRepo.GetCollections(userID)
+}
+
+func (b *bookmarkUseCase) RenameCollection(userID, collectionID, name string) (models.BookmarkCollection, error) {
+	name, err := collectionName(name)
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	collection, err := b.ownCollection(userID, collectionID)
+	if err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	if err := b.bookmarkRepo.RenameCollection(collection.ID, name); err != nil {
+		return models.BookmarkCollection{}, err
+	}
+
+	return b.bookmarkRepo.GetCollectionByID(collection.ID)
+}
+
+func (b *bookmarkUseCase) DeleteCollection(userID, collectionID string) error {
+	collection, err := b.ownCollection(userID, collectionID)
+	if err != nil {
+		return err
+	}
+
+	return b.bookmarkRepo.DeleteCollection(collection.ID)
+}
+
+// AddBookmark saves the post and tracks the bookmark for recommendations. Tracking is best
+// effort: the bookmark stands if it fails, and a post already in the collection is not tracked
+// twice.
+func (b *bookmarkUseCase) AddBookmark(userID, blogID, collectionID string) (models.Bookmark, error) {
+	blog, err := b.blogRepo.GetBlogByID(blogID)
+	if err != nil || !blog.IsPublished || models.IsModerationHeld(blog.ModerationStatus) {
+		return models.Bookmark{}, models.ErrBlogNotFound
+	}
+
+	var collection models.BookmarkCollection
+	if collectionID == "" {
+		collection, err = b.defaultCollection(userID)
+	} else {
+		collection, err = b.ownCollection(userID, collectionID)
+	}
+	if err != nil {
+		return models.Bookmark{}, err
+	}
+
+	bookmark, added, err := b.bookmarkRepo.AddBookmark(models.Bookmark{
+		UserID:       userID,
+		CollectionID: collection.ID,
+		BlogID:       blog.ID,
+	})
+	if err != nil {
+		return models.Bookmark{}, err
+	}
+	if !added {
+		return models.Bookmark{}, errors.New("post is already in this collection")
+	}
+
+	if err := b.recommendationSvc.TrackUserAction(userID, blog.ID, models.ActionBookmark); err != nil {
+		log.Printf("Failed to track bookmark of blog %s by user %s: %v", blog.ID, userID, err)
+	} else {
+		go func() {
+			if err := b.recommendationSvc.UpdateUserInterests(userID); err != nil {
+				log.Printf("Failed to update interests of user %s: %v", userID, err)
+			}
+		}()
+	}
+
+	bookmark.Blog = &blog
+	return bookmark, nil
+}
+
+// defaultCollection returns the user's default collection, creating it the first time. Of two
+// requests creating it at once, the one that loses picks up the other's.
+func (b *bookmarkUseCase) defaultCollection(userID string) (models.BookmarkCollection, error) {
+	collection, err := b.bookmarkRepo.GetCollectionByName(userID, models.DefaultBookmarkCollection)
+	if err == nil {
+		return collection, nil
+	}
+
+	collection, err = b.bookmarkRepo.CreateCollection(models.BookmarkCollection{UserID: userID, Name: models.DefaultBookmarkCollection})
+	if err != nil {
+		return b.bookmarkRepo.GetCollectionByName(userID, models.DefaultBookmarkCollection)
+	}
+
+	return collection, nil
+}
+
+// GetBookmarks lists a page of the collection with the posts filled in. Posts deleted or taken
+// down since they were bookmarked are listed without one, so they can still be removed.
+func (b *bookmarkUseCase) GetBookmarks(userID, collectionID string, unreadOnly bool, page, limit int) ([]models.Bookmark, int64, error) {
+	collection, err := b.ownCollection(userID, collectionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bookmarks, err := b.bookmarkRepo.GetBookmarks(collection.ID, unreadOnly, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := b.bookmarkRepo.CountBookmarks(collection.ID, unreadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range bookmarks {
+		blog, err := b.blogRepo.GetBlogByID(bookmarks[i].BlogID)
+		if err != nil || !blog.IsPublished || models.IsModerationHeld(blog.ModerationStatus) {
+			continue
+		}
+		bookmarks[i].Blog = &blog
+	}
+
+	return bookmarks, total, nil
+}
+
+// ReorderBookmarks renumbers the collection. The IDs must be exactly the collection's bookmarks,
+// so a client working from a stale list is told to reload rather than losing track of some.
+func (b *bookmarkUseCase) ReorderBookmarks(userID, collectionID string, bookmarkIDs []string) error {
+	collection, err := b.ownCollection(userID, collectionID)
+	if err != nil {
+		return err
+	}
+
+	current, err := b.bookmarkRepo.GetBookmarkIDs(collection.ID)
+	if err != nil {
+		return err
+	}
+
+	remaining := make(map[string]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range bookmarkIDs {
+		if !remaining[id] {
+			return errors.New("bookmark_ids must list every bookmark of the collection exactly once")
+		}
+		delete(remaining, id)
+	}
+	if len(remaining) > 0 {
+		return errors.New("bookmark_ids must list every bookmark of the collection exactly once")
+	}
+
+	return b.bookmarkRepo.SetPositions(collection.ID, bookmarkIDs)
+}
+
+func (b *bookmarkUseCase) MarkRead(userID, bookmarkID string, read bool) (models.Bookmark, error) {
+	bookmark, err := b.ownBookmark(userID, bookmarkID)
+	if err != nil {
+		return models.Bookmark{}, err
+	}
+
+	if err := b.bookmarkRepo.SetRead(bookmark.ID, read); err != nil {
+		return models.Bookmark{}, err
+	}
+
+	return b.bookmarkRepo.GetBookmarkByID(bookmark.ID)
+}
+
+func (b *bookmarkUseCase) RemoveBookmark(userID, bookmarkID string) error {
+	bookmark, err := b.ownBookmark(userID, bookmarkID)
+	if err != nil {
+		return err
+	}
+
+	return b.bookmarkRepo.RemoveBookmark(bookmark.ID)
+}
+
+// ownCollection fetches one of the user's collections. Other users' collections are reported as
+// missing so their IDs cannot be probed.
+func (b *bookmarkUseCase) ownCollection(userID, collectionID string) (models.BookmarkCollection, error) {
+	collection, err := b.bookmarkRepo.GetCollectionByID(collectionID)
+	if err != nil || collection.UserID != userID {
+		return models.BookmarkCollection{}, errors.New("collection not found")
+	}
+	return collection, nil
+}
+
+func (b *bookmarkUseCase) ownBookmark(userID, bookmarkID string) (models.Bookmark, error) {
+	bookmark, err := b.bookmarkRepo.GetBookmarkByID(bookmarkID)
+	if err != nil || bookmark.UserID != userID {
+		return models.Bookmark{}, errors.New("bookmark not found")
+	}
+	return bookmark, nil
+}
+
+func collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("collection name is required")
+	}
+	if utf8.RuneCountInString(name) > maxCollectionName {
+		return "", fmt.Errorf("collection name must be at most %d characters", maxCollectionName)
+	}
+	return name, nil
+}
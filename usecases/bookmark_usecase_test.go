@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkUseCase_AddBookmark(t *testing.T) {
+	live := models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}
+	readingList := models.BookmarkCollection{ID: "collection123", UserID: "reader123", Name: models.DefaultBookmarkCollection}
+	newBookmark := models.Bookmark{UserID: "reader123", CollectionID: "collection123", BlogID: "blog123"}
+	saved := models.Bookmark{ID: "bookmark123", UserID: "reader123", CollectionID: "collection123", BlogID: "blog123", Position: 4}
+
+	tests := []struct {
+		name          string
+		collectionID  string
+		setupMocks    func(*mocks.BookmarkRepositoryMock, *mocks.BlogRepositoryMock, *mocks.RecommendationServiceMock)
+		expectError   string
+		expectTracked bool
+	}{
+		{
+			name:         "Success - Saved to the chosen collection and tracked",
+			collectionID: "collection123",
+			setupMocks: func(repo *mocks.BookmarkRepositoryMock, blogRepo *mocks.BlogRepositoryMock, recommendationSvc *mocks.RecommendationServiceMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("GetCollectionByID", "collection123").Return(readingList, nil)
+				repo.On("AddBookmark", newBookmark).Return(saved, true, nil)
+				recommendationSvc.On("TrackUserAction", "reader123", "blog123", models.ActionBookmark).Return(nil)
+				recommendationSvc.On("UpdateUserInterests", "reader123").Return(nil).Maybe()
+			},
+			expectTracked: true,
+		},
+		{
+			name: "Success - Without a collection the default one is created",
+			setupMocks: func(repo *mocks.BookmarkRepositoryMock, blogRepo *mocks.BlogRepositoryMock, recommendationSvc *mocks.RecommendationServiceMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("GetCollectionByName", "reader123", models.DefaultBookmarkCollection).Return(models.BookmarkCollection{}, errors.New("mongo: no documents in result"))
+				repo.On("CreateCollection", models.BookmarkCollection{UserID: "reader123", Name: models.DefaultBookmarkCollection}).Return(readingList, nil)
+				repo.On("AddBookmark", newBookmark).Return(saved, true, nil)
+				recommendationSvc.On("TrackUserAction", "reader123", "blog123", models.ActionBookmark).Return(nil)
+				recommendationSvc.On("UpdateUserInterests", "reader123").Return(nil).Maybe()
+			},
+			expectTracked: true,
+		},
+		{
+			name:         "Success - Failing to track does not lose the bookmark",
+			collectionID: "collection123",
+			setupMocks: func(repo *mocks.BookmarkRepositoryMock, blogRepo *mocks.BlogRepositoryMock, recommendationSvc *mocks.RecommendationServiceMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("GetCollectionByID", "collection123").Return(readingList, nil)
+				repo.On("AddBookmark", newBookmark).Return(saved, true, nil)
+				recommendationSvc.On("TrackUserAction", "reader123", "blog123", models.ActionBookmark).Return(errors.New("db down"))
+			},
+			expectTracked: true,
+		},
+		{
+			name:         "Error - Already in the collection is not tracked again",
+			collectionID: "collection123",
+			setupMocks: func(repo *mocks.BookmarkRepositoryMock, blogRepo *mocks.BlogRepositoryMock, recommendationSvc *mocks.RecommendationServiceMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("GetCollectionByID", "collection123").Return(readingList, nil)
+				repo.On("AddBookmark", newBookmark).Return(models.Bookmark{}, false, nil)
+			},
+			expectError: "post is already in this collection",
+		},
+		{
+			name:         "Error - Someone else's collection",
+			collectionID: "collection456",
+			setupMocks: func(repo *mocks.BookmarkRepositoryMock, blogRepo *mocks.BlogRepositoryMock, recommendationSvc *mocks.RecommendationServiceMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("GetCollectionByID", "collection456").Return(models.BookmarkCollection{ID: "collection456", UserID: "other123"}, nil)
+			},
+			expectError: "collection not found",
+		},
+		{
+			name:         "Error - Drafts cannot be bookmarked",
+			collectionID: "collection123",
+			setupMocks: func(repo *mocks.BookmarkRepositoryMock, blogRepo *mocks.BlogRepositoryMock, recommendationSvc *mocks.RecommendationServiceMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123"}, nil)
+			},
+			expectError: "blog not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.BookmarkRepositoryMock{}
+			blogRepo := &mocks.BlogRepositoryMock{}
+			recommendationSvc := &mocks.RecommendationServiceMock{}
+			tt.setupMocks(repo, blogRepo, recommendationSvc)
+
+			useCase := NewBookmarkUseCase(repo, blogRepo, recommendationSvc)
+			bookmark, err := useCase.AddBookmark("reader123", "blog123", tt.collectionID)
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "bookmark123", bookmark.ID)
+				assert.Equal(t, &live, bookmark.Blog)
+			}
+			if !tt.expectTracked {
+				recommendationSvc.AssertNotCalled(t, "TrackUserAction", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			repo.AssertExpectations(t)
+			blogRepo.AssertExpectations(t)
+			recommendationSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookmarkUseCase_GetBookmarks(t *testing.T) {
+	repo := &mocks.BookmarkRepositoryMock{}
+	blogRepo := &mocks.BlogRepositoryMock{}
+	live := models.Blog{ID: "blog1", Title: "Still up", IsPublished: true}
+	repo.On("GetCollectionByID", "collection123").Return(models.BookmarkCollection{ID: "collection123", UserID: "reader123"}, nil)
+	repo.On("GetBookmarks", "collection123", true, 1, 10).Return([]models.Bookmark{
+		{ID: "bookmark1", BlogID: "blog1", Position: 0},
+		{ID: "bookmark2", BlogID: "blog2", Position: 1},
+	}, nil)
+	repo.On("CountBookmarks", "collection123", true).Return(int64(2), nil)
+	blogRepo.On("GetBlogByID", "blog1").Return(live, nil)
+	blogRepo.On("GetBlogByID", "blog2").Return(models.Blog{}, errors.New("mongo: no documents in result"))
+
+	useCase := NewBookmarkUseCase(repo, blogRepo, &mocks.RecommendationServiceMock{})
+	bookmarks, total, err := useCase.GetBookmarks("reader123", "collection123", true, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, bookmarks, 2)
+	assert.Equal(t, &live, bookmarks[0].Blog)
+	// A deleted post stays listed without its blog, so it can still be removed
+	assert.Nil(t, bookmarks[1].Blog)
+}
+
+func TestBookmarkUseCase_ReorderBookmarks(t *testing.T) {
+	tests := []struct {
+		name        string
+		bookmarkIDs []string
+		expectError bool
+	}{
+		{name: "Success - Every bookmark in a new order", bookmarkIDs: []string{"b3", "b1", "b2"}},
+		{name: "Error - A bookmark is missing", bookmarkIDs: []string{"b3", "b1"}, expectError: true},
+		{name: "Error - A bookmark is listed twice", bookmarkIDs: []string{"b3", "b1", "b1"}, expectError: true},
+		{name: "Error - A bookmark of another collection", bookmarkIDs: []string{"b3", "b1", "b2", "b9"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.BookmarkRepositoryMock{}
+			repo.On("GetCollectionByID", "collection123").Return(models.BookmarkCollection{ID: "collection123", UserID: "reader123"}, nil)
+			repo.On("GetBookmarkIDs", "collection123").Return([]string{"b1", "b2", "b3"}, nil)
+			if !tt.expectError {
+				repo.On("SetPositions", "collection123", tt.bookmarkIDs).Return(nil)
+			}
+
+			useCase := NewBookmarkUseCase(repo, &mocks.BlogRepositoryMock{}, &mocks.RecommendationServiceMock{})
+			err := useCase.ReorderBookmarks("reader123", "collection123", tt.bookmarkIDs)
+
+			if tt.expectError {
+				assert.EqualError(t, err, "bookmark_ids must list every bookmark of the collection exactly once")
+				repo.AssertNotCalled(t, "SetPositions", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookmarkUseCase_MarkRead(t *testing.T) {
+	repo := &mocks.BookmarkRepositoryMock{}
+	repo.On("GetBookmarkByID", "bookmark123").Return(models.Bookmark{ID: "bookmark123", UserID: "reader123"}, nil).Once()
+	repo.On("SetRead", "bookmark123", true).Return(nil)
+	repo.On("GetBookmarkByID", "bookmark123").Return(models.Bookmark{ID: "bookmark123", UserID: "reader123", IsRead: true}, nil).Once()
+
+	useCase := NewBookmarkUseCase(repo, &mocks.BlogRepositoryMock{}, &mocks.RecommendationServiceMock{})
+	bookmark, err := useCase.MarkRead("reader123", "bookmark123", true)
+
+	assert.NoError(t, err)
+	assert.True(t, bookmark.IsRead)
+
+	// Other users' bookmarks look missing
+	repo.On("GetBookmarkByID", "bookmark456").Return(models.Bookmark{ID: "bookmark456", UserID: "other123"}, nil)
+	_, err = useCase.MarkRead("reader123", "bookmark456", true)
+
+	assert.EqualError(t, err, "bookmark not found")
+	repo.AssertNumberOfCalls(t, "SetRead", 1)
+}
+
+func TestBookmarkUseCase_CreateCollection_InvalidName(t *testing.T) {
+	repo := &mocks.BookmarkRepositoryMock{}
+	useCase := NewBookmarkUseCase(repo, &mocks.BlogRepositoryMock{}, &mocks.RecommendationServiceMock{})
+
+	_, err := useCase.CreateCollection("reader123", "   ")
+
+	assert.EqualError(t, err, "collection name is required")
+	repo.AssertNotCalled(t, "CreateCollection", mock.Anything)
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"log"
+)
+
+type followUseCase struct {
+	followRepo        interfaces.FollowRepository
+	userRepo          interfaces.UserRepository
+	blogRepo          interfaces.BlogRepository
+	recommendationSvc interfaces.RecommendationService
+}
+
+func NewFollowUseCase(followRepo interfaces.FollowRepository, userRepo interfaces.UserRepository, blogRepo interfaces.BlogRepository, recommendationSvc interfaces.RecommendationService) interfaces.FollowUseCase {
+	return &followUseCase{
+		followRepo:        followRepo,
+		userRepo:          userRepo,
+		blogRepo:          blogRepo,
+		recommendationSvc: recommendationSvc,
+	}
+}
+
+// Follow records the follow and bumps both users' counts, but only the first time, so a repeated
+// follow changes nothing
+func (f *followUseCase) Follow(ctx context.Context, followerID, authorID string) error {
+	if followerID == authorID {
+		return errors.New("you cannot follow yourself")
+	}
+	if _, err := f.userRepo.GetUserByID(ctx, authorID); err != nil {
+		return errors.New("user not found")
+	}
+
+	followed, err := f.followRepo.Follow(followerID, authorID)
+	if err != nil {
+		return err
+	}
+	if !followed {
+		return nil
+	}
+
+	if err := f.userRepo.AdjustFollowCounts(ctx, followerID, authorID, 1); err != nil {
+		return err
+	}
+
+	f.refreshInterests(followerID)
+	return nil
+}
+
+// Unfollow removes the follow and lowers both users' counts if there was one
+func (f *followUseCase) Unfollow(ctx context.Context, followerID, authorID string) error {
+	unfollowed, err := f.followRepo.Unfollow(followerID, authorID)
+	if err != nil {
+		return err
+	}
+	if !unfollowed {
+		return nil
+	}
+
+	if err := f.userRepo.AdjustFollowCounts(ctx, followerID, authorID, -1); err != nil {
+		return err
+	}
+
+	f.refreshInterests(followerID)
+	return nil
+}
+
+// refreshInterests rebuilds the follower's interest profile in the background, as tracking an
+// action does, so recommendations pick up the change
+func (f *followUseCase) refreshInterests(userID string) {
+	go func() {
+		if err := f.recommendationSvc.UpdateUserInterests(userID); err != nil {
+			log.Printf("Failed to update interests of user %s: %v", userID, err)
+		}
+	}()
+}
+
+func (f *followUseCase) GetAuthorProfile(ctx context.Context, authorID, viewerID string) (models.AuthorProfile, error) {
+	author, err := f.userRepo.GetUserByID(ctx, authorID)
+	if err != nil {
+		return models.AuthorProfile{}, errors.New("user not found")
+	}
+
+	profile := models.AuthorProfile{
+		ID:             author.ID,
+		Username:       author.Username,
+		Bio:            author.Bio,
+		Picture:        author.Picture,
+		FollowersCount: author.FollowersCount,
+		FollowingCount: author.FollowingCount,
+	}
+
+	if viewerID != "" && viewerID != authorID {
+		profile.IsFollowing, err = f.followRepo.IsFollowing(viewerID, authorID)
+		if err != nil {
+			return models.AuthorProfile{}, err
+		}
+	}
+
+	return profile, nil
+}
+
+// GetFeed returns the published posts of the authors the user follows next to the cursor, newest
+// first. Following nobody gives an empty feed.
+func (f *followUseCase) GetFeed(userID string, page models.PageRequest) ([]models.Blog, models.PageInfo, error) {
+	following, err := f.followRepo.GetFollowing(userID)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	if len(following) == 0 {
+		info := models.PageInfo{}
+		if page.WithTotal {
+			var total int64
+			info.Total = &total
+		}
+		return []models.Blog{}, info, nil
+	}
+
+	blogs, hasMore, err := f.blogRepo.GetBlogsByAuthorsCursor(following, page)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	info := pageInfo(page, hasMore, len(blogs), func(i int) models.Cursor {
+		return models.Cursor{CreatedAt: blogs[i].CreatedAt, ID: blogs[i].ID}
+	})
+	if page.WithTotal {
+		total, err := f.blogRepo.CountPublishedBlogsByAuthors(following)
+		if err != nil {
+			return nil, models.PageInfo{}, err
+		}
+		info.Total = &total
+	}
+
+	return blogs, info, nil
+}
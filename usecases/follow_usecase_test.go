@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFollowUseCase_Follow(t *testing.T) {
+	tests := []struct {
+		name        string
+		followerID  string
+		setupMocks  func(*mocks.FollowRepositoryMock, *mocks.UserRepository, *mocks.RecommendationServiceMock)
+		expectError string
+	}{
+		{
+			name:       "Success - First follow bumps the counts",
+			followerID: "reader123",
+			setupMocks: func(repo *mocks.FollowRepositoryMock, userRepo *mocks.UserRepository, recommendationSvc *mocks.RecommendationServiceMock) {
+				userRepo.On("GetUserByID", mock.Anything, "author123").Return(models.User{ID: "author123"}, nil)
+				repo.On("Follow", "reader123", "author123").Return(true, nil)
+				userRepo.On("AdjustFollowCounts", mock.Anything, "reader123", "author123", 1).Return(nil)
+				recommendationSvc.On("UpdateUserInterests", "reader123").Return(nil).Maybe()
+			},
+		},
+		{
+			name:       "Success - Following again changes nothing",
+			followerID: "reader123",
+			setupMocks: func(repo *mocks.FollowRepositoryMock, userRepo *mocks.UserRepository, recommendationSvc *mocks.RecommendationServiceMock) {
+				userRepo.On("GetUserByID", mock.Anything, "author123").Return(models.User{ID: "author123"}, nil)
+				repo.On("Follow", "reader123", "author123").Return(false, nil)
+			},
+		},
+		{
+			name:       "Error - Following yourself",
+			followerID: "author123",
+			setupMocks: func(repo *mocks.FollowRepositoryMock, userRepo *mocks.UserRepository, recommendationSvc *mocks.RecommendationServiceMock) {
+			},
+			expectError: "you cannot follow yourself",
+		},
+		{
+			name:       "Error - Unknown author",
+			followerID: "reader123",
+			setupMocks: func(repo *mocks.FollowRepositoryMock, userRepo *mocks.UserRepository, recommendationSvc *mocks.RecommendationServiceMock) {
+				userRepo.On("GetUserByID", mock.Anything, "author123").Return(models.User{}, errors.New("mongo: no documents in result"))
+			},
+			expectError: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.FollowRepositoryMock{}
+			userRepo := &mocks.UserRepository{}
+			recommendationSvc := &mocks.RecommendationServiceMock{}
+			tt.setupMocks(repo, userRepo, recommendationSvc)
+
+			useCase := NewFollowUseCase(repo, userRepo, &mocks.BlogRepositoryMock{}, recommendationSvc)
+			err := useCase.Follow(context.Background(), tt.followerID, "author123")
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				repo.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			repo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFollowUseCase_Unfollow(t *testing.T) {
+	repo := &mocks.FollowRepositoryMock{}
+	userRepo := &mocks.UserRepository{}
+	recommendationSvc := &mocks.RecommendationServiceMock{}
+	repo.On("Unfollow", "reader123", "author123").Return(true, nil)
+	userRepo.On("AdjustFollowCounts", mock.Anything, "reader123", "author123", -1).Return(nil)
+	recommendationSvc.On("UpdateUserInterests", "reader123").Return(nil).Maybe()
+
+	useCase := NewFollowUseCase(repo, userRepo, &mocks.BlogRepositoryMock{}, recommendationSvc)
+	err := useCase.Unfollow(context.Background(), "reader123", "author123")
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestFollowUseCase_GetAuthorProfile(t *testing.T) {
+	repo := &mocks.FollowRepositoryMock{}
+	userRepo := &mocks.UserRepository{}
+	userRepo.On("GetUserByID", mock.Anything, "author123").
+		Return(models.User{ID: "author123", Username: "author", Email: "author@example.com", FollowersCount: 12, FollowingCount: 3}, nil)
+	repo.On("IsFollowing", "reader123", "author123").Return(true, nil)
+
+	useCase := NewFollowUseCase(repo, userRepo, &mocks.BlogRepositoryMock{}, &mocks.RecommendationServiceMock{})
+	profile, err := useCase.GetAuthorProfile(context.Background(), "author123", "reader123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuthorProfile{ID: "author123", Username: "author", FollowersCount: 12, FollowingCount: 3, IsFollowing: true}, profile)
+
+	// The author looking at their own profile is not asked about
+	profile, err = useCase.GetAuthorProfile(context.Background(), "author123", "author123")
+
+	assert.NoError(t, err)
+	assert.False(t, profile.IsFollowing)
+	repo.AssertNumberOfCalls(t, "IsFollowing", 1)
+}
+
+func TestFollowUseCase_GetFeed(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	page := models.PageRequest{Limit: 2, WithTotal: true}
+
+	t.Run("Success - Following nobody gives an empty feed", func(t *testing.T) {
+		repo := &mocks.FollowRepositoryMock{}
+		blogRepo := &mocks.BlogRepositoryMock{}
+		repo.On("GetFollowing", "reader123").Return([]string{}, nil)
+
+		useCase := NewFollowUseCase(repo, &mocks.UserRepository{}, blogRepo, &mocks.RecommendationServiceMock{})
+		blogs, info, err := useCase.GetFeed("reader123", page)
+
+		assert.NoError(t, err)
+		assert.Empty(t, blogs)
+		assert.Nil(t, info.Next)
+		assert.Equal(t, int64(0), *info.Total)
+		blogRepo.AssertNotCalled(t, "GetBlogsByAuthorsCursor", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Posts of followed authors", func(t *testing.T) {
+		repo := &mocks.FollowRepositoryMock{}
+		blogRepo := &mocks.BlogRepositoryMock{}
+		following := []string{"author123", "author456"}
+		feed := []models.Blog{
+			{ID: "blog2", AuthorID: "author456", CreatedAt: now},
+			{ID: "blog1", AuthorID: "author123", CreatedAt: now.Add(-time.Hour)},
+		}
+		repo.On("GetFollowing", "reader123").Return(following, nil)
+		blogRepo.On("GetBlogsByAuthorsCursor", following, page).Return(feed, true, nil)
+		blogRepo.On("CountPublishedBlogsByAuthors", following).Return(int64(5), nil)
+
+		useCase := NewFollowUseCase(repo, &mocks.UserRepository{}, blogRepo, &mocks.RecommendationServiceMock{})
+		blogs, info, err := useCase.GetFeed("reader123", page)
+
+		assert.NoError(t, err)
+		assert.Equal(t, feed, blogs)
+		assert.Equal(t, &models.Cursor{CreatedAt: now.Add(-time.Hour), ID: "blog1"}, info.Next)
+		assert.Nil(t, info.Prev)
+		assert.Equal(t, int64(5), *info.Total)
+		blogRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,218 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"log"
+	"strings"
+)
+
+// recoveryCodeCount is how many recovery codes a user gets at a time
+const recoveryCodeCount = 10
+
+var errInvalidMFACode = errors.New("invalid verification code")
+
+type mfaUseCase struct {
+	userRepo      interfaces.UserRepository
+	otp           interfaces.OTPService
+	hasher        interfaces.Hasher
+	loginThrottle interfaces.LoginThrottle
+	tokenRepo     interfaces.TokenRepository
+	tokenVersions interfaces.TokenVersionStore
+	requiredRoles map[string]bool
+}
+
+// NewMFAUseCase enforces MFA for the required roles: their users cannot turn it off, and admin
+// routes refuse them until they sign in with it
+func NewMFAUseCase(userRepo interfaces.UserRepository, otp interfaces.OTPService, hasher interfaces.Hasher, loginThrottle interfaces.LoginThrottle, tokenRepo interfaces.TokenRepository, tokenVersions interfaces.TokenVersionStore, requiredRoles []string) interfaces.MFAUseCase {
+	required := make(map[string]bool, len(requiredRoles))
+	for _, role := range requiredRoles {
+		required[role] = true
+	}
+
+	return &mfaUseCase{
+		userRepo:      userRepo,
+		otp:           otp,
+		hasher:        hasher,
+		loginThrottle: loginThrottle,
+		tokenRepo:     tokenRepo,
+		tokenVersions: tokenVersions,
+		requiredRoles: required,
+	}
+}
+
+func (m *mfaUseCase) GetStatus(ctx context.Context, userID string) (models.MFAStatus, error) {
+	user, err := m.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.MFAStatus{}, errors.New("user not found")
+	}
+
+	status := models.MFAStatus{Enabled: user.MFAEnabled, Required: m.RequiredFor(user.Role)}
+	if user.MFAEnabled {
+		status.RecoveryCodesLeft = len(user.MFARecoveryCodes)
+	}
+	return status, nil
+}
+
+func (m *mfaUseCase) Enroll(ctx context.Context, userID, password string, client models.ClientInfo) (models.MFAEnrollment, error) {
+	user, err := m.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.MFAEnrollment{}, errors.New("user not found")
+	}
+	if user.MFAEnabled {
+		return models.MFAEnrollment{}, errors.New("MFA is already enabled")
+	}
+	if err := m.checkPassword(user, password, client); err != nil {
+		return models.MFAEnrollment{}, err
+	}
+
+	secret, err := m.otp.GenerateSecret()
+	if err != nil {
+		return models.MFAEnrollment{}, err
+	}
+	if err := m.userRepo.SetMFASecret(ctx, userID, secret); err != nil {
+		return models.MFAEnrollment{}, err
+	}
+
+	return models.MFAEnrollment{Secret: secret, URI: m.otp.ProvisioningURI(secret, user.Email)}, nil
+}
+
+func (m *mfaUseCase) Confirm(ctx context.Context, userID, password, code string, client models.ClientInfo) ([]string, error) {
+	user, err := m.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.MFAEnabled {
+		return nil, errors.New("MFA is already enabled")
+	}
+	if user.MFASecret == "" {
+		return nil, errors.New("MFA enrolment has not been started")
+	}
+	if err := m.checkPassword(user, password, client); err != nil {
+		return nil, err
+	}
+
+	// Only the authenticator proves the secret was set up; there are no recovery codes yet
+	step, ok := m.otp.VerifyCode(user.MFASecret, strings.TrimSpace(code))
+	if !ok {
+		return nil, errInvalidMFACode
+	}
+	if claimed, err := m.userRepo.ClaimMFAStep(ctx, userID, step); err != nil {
+		return nil, err
+	} else if !claimed {
+		return nil, errInvalidMFACode
+	}
+
+	codes, err := m.enableWithNewRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions started with the password alone must sign in again
+	if _, err := m.tokenRepo.DeleteUserTokens(userID, ""); err != nil {
+		return nil, err
+	}
+	if err := m.tokenVersions.Bump(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (m *mfaUseCase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := m.enabledUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySecondFactor(ctx, m.userRepo, m.otp, user, code); err != nil {
+		return nil, err
+	}
+
+	return m.enableWithNewRecoveryCodes(ctx, userID)
+}
+
+func (m *mfaUseCase) Disable(ctx context.Context, userID, code string) error {
+	user, err := m.enabledUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if m.RequiredFor(user.Role) {
+		return errors.New("MFA is required for your role")
+	}
+	if err := verifySecondFactor(ctx, m.userRepo, m.otp, user, code); err != nil {
+		return err
+	}
+
+	return m.userRepo.DisableMFA(ctx, userID)
+}
+
+func (m *mfaUseCase) RequiredFor(role string) bool {
+	return m.requiredRoles[role]
+}
+
+// checkPassword counts the attempt like a login before comparing the password, so the MFA
+// endpoints cannot be used to guess it
+func (m *mfaUseCase) checkPassword(user models.User, password string, client models.ClientInfo) error {
+	if err := m.loginThrottle.AttemptLogin(user.Email, client.IP); err != nil {
+		return err
+	}
+	if !m.hasher.VerifyPassword(user.Password, password) {
+		return errors.New("incorrect password")
+	}
+	if err := m.loginThrottle.LoginSucceeded(user.Email, client.IP); err != nil {
+		log.Printf("Failed to clear failed logins for %s: %v", user.Email, err)
+	}
+	return nil
+}
+
+func (m *mfaUseCase) enabledUser(ctx context.Context, userID string) (models.User, error) {
+	user, err := m.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.User{}, errors.New("user not found")
+	}
+	if !user.MFAEnabled {
+		return models.User{}, errors.New("MFA is not enabled")
+	}
+	return user, nil
+}
+
+// enableWithNewRecoveryCodes stores the hashes of fresh recovery codes and returns the codes
+func (m *mfaUseCase) enableWithNewRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes, salt, err := m.otp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = m.otp.HashRecoveryCode(salt, code)
+	}
+	if err := m.userRepo.EnableMFA(ctx, userID, salt, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// verifySecondFactor accepts a code from the authenticator, once, or an unused recovery code,
+// which is then used up
+func verifySecondFactor(ctx context.Context, userRepo interfaces.UserRepository, otp interfaces.OTPService, user models.User, code string) error {
+	code = strings.TrimSpace(code)
+
+	var ok bool
+	var err error
+	if step, valid := otp.VerifyCode(user.MFASecret, code); valid {
+		ok, err = userRepo.ClaimMFAStep(ctx, user.ID, step)
+	} else {
+		ok, err = userRepo.UseRecoveryCode(ctx, user.ID, otp.HashRecoveryCode(user.MFARecoverySalt, code))
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidMFACode
+	}
+	return nil
+}
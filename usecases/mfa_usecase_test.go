@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMFAUseCase_Enroll(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        models.User
+		password    string
+		expectError string
+	}{
+		{name: "Success - New secret", user: models.User{ID: "user123", Email: "john@example.com", Password: "hashed_password"}, password: "password123"},
+		{name: "Error - Wrong password", user: models.User{ID: "user123", Email: "john@example.com", Password: "hashed_password"}, password: "stolen_token_only", expectError: "incorrect password"},
+		{name: "Error - Already enabled", user: models.User{ID: "user123", MFAEnabled: true}, expectError: "MFA is already enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mocks.UserRepository{}
+			otp := &mocks.OTPServiceMock{}
+			hasher := &mocks.MockHasher{}
+			userRepo.On("GetUserByID", mock.Anything, "user123").Return(tt.user, nil)
+			hasher.On("VerifyPassword", "hashed_password", "password123").Return(true).Maybe()
+			hasher.On("VerifyPassword", "hashed_password", "stolen_token_only").Return(false).Maybe()
+			if tt.expectError == "" {
+				otp.On("GenerateSecret").Return("JBSWY3DPEHPK3PXP", nil)
+				otp.On("ProvisioningURI", "JBSWY3DPEHPK3PXP", "john@example.com").Return("otpauth://totp/Blog%20API:john@example.com?secret=JBSWY3DPEHPK3PXP")
+				userRepo.On("SetMFASecret", mock.Anything, "user123", "JBSWY3DPEHPK3PXP").Return(nil)
+			}
+
+			useCase := NewMFAUseCase(userRepo, otp, hasher, openLoginThrottle(), &mocks.MockTokenRepository{}, &mocks.TokenVersionStoreMock{}, nil)
+			enrollment, err := useCase.Enroll(context.Background(), "user123", tt.password, models.ClientInfo{IP: "203.0.113.7"})
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				userRepo.AssertNotCalled(t, "SetMFASecret", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "JBSWY3DPEHPK3PXP", enrollment.Secret)
+				assert.Contains(t, enrollment.URI, "otpauth://totp/")
+			}
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAUseCase_Confirm(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        models.User
+		password    string
+		codeValid   bool
+		expectError string
+	}{
+		{name: "Success - Turns MFA on", user: models.User{ID: "user123", Password: "hashed_password", MFASecret: "SECRET"}, password: "password123", codeValid: true},
+		{name: "Error - Wrong code", user: models.User{ID: "user123", Password: "hashed_password", MFASecret: "SECRET"}, password: "password123", expectError: "invalid verification code"},
+		{name: "Error - Wrong password", user: models.User{ID: "user123", Password: "hashed_password", MFASecret: "SECRET"}, password: "stolen_token_only", expectError: "incorrect password"},
+		{name: "Error - Not enrolled", user: models.User{ID: "user123"}, expectError: "MFA enrolment has not been started"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mocks.UserRepository{}
+			otp := &mocks.OTPServiceMock{}
+			hasher := &mocks.MockHasher{}
+			tokenRepo := &mocks.MockTokenRepository{}
+			tokenVersions := &mocks.TokenVersionStoreMock{}
+			userRepo.On("GetUserByID", mock.Anything, "user123").Return(tt.user, nil)
+			hasher.On("VerifyPassword", "hashed_password", "password123").Return(true).Maybe()
+			hasher.On("VerifyPassword", "hashed_password", "stolen_token_only").Return(false).Maybe()
+			if tt.codeValid {
+				otp.On("VerifyCode", "SECRET", "123456").Return(int64(100), true)
+				userRepo.On("ClaimMFAStep", mock.Anything, "user123", int64(100)).Return(true, nil)
+				otp.On("GenerateRecoveryCodes", recoveryCodeCount).Return([]string{"abcde-fghij", "klmno-pqrst"}, "salt", nil)
+				otp.On("HashRecoveryCode", "salt", "abcde-fghij").Return("hash1")
+				otp.On("HashRecoveryCode", "salt", "klmno-pqrst").Return("hash2")
+				userRepo.On("EnableMFA", mock.Anything, "user123", "salt", []string{"hash1", "hash2"}).Return(nil)
+				tokenRepo.On("DeleteUserTokens", "user123", "").Return(int64(2), nil)
+				tokenVersions.On("Bump", mock.Anything, "user123").Return(nil)
+			} else {
+				otp.On("VerifyCode", "SECRET", "123456").Return(int64(0), false).Maybe()
+			}
+
+			useCase := NewMFAUseCase(userRepo, otp, hasher, openLoginThrottle(), tokenRepo, tokenVersions, nil)
+			codes, err := useCase.Confirm(context.Background(), "user123", tt.password, " 123456 ", models.ClientInfo{IP: "203.0.113.7"})
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				userRepo.AssertNotCalled(t, "EnableMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"abcde-fghij", "klmno-pqrst"}, codes)
+			}
+			userRepo.AssertExpectations(t)
+			tokenRepo.AssertExpectations(t)
+			tokenVersions.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAUseCase_Disable(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		expectError string
+	}{
+		{name: "Success - With a recovery code", role: "user"},
+		{name: "Error - Required for the role", role: "admin", expectError: "MFA is required for your role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mocks.UserRepository{}
+			otp := &mocks.OTPServiceMock{}
+			userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Role: tt.role, MFAEnabled: true, MFASecret: "SECRET", MFARecoverySalt: "salt"}, nil)
+			if tt.expectError == "" {
+				otp.On("VerifyCode", "SECRET", "ABCDE-FGHIJ").Return(int64(0), false)
+				otp.On("HashRecoveryCode", "salt", "ABCDE-FGHIJ").Return("hash1")
+				userRepo.On("UseRecoveryCode", mock.Anything, "user123", "hash1").Return(true, nil)
+				userRepo.On("DisableMFA", mock.Anything, "user123").Return(nil)
+			}
+
+			useCase := NewMFAUseCase(userRepo, otp, &mocks.MockHasher{}, openLoginThrottle(), &mocks.MockTokenRepository{}, &mocks.TokenVersionStoreMock{}, []string{"admin", "superadmin"})
+			err := useCase.Disable(context.Background(), "user123", "ABCDE-FGHIJ")
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				userRepo.AssertNotCalled(t, "DisableMFA", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAUseCase_GetStatus(t *testing.T) {
+	userRepo := &mocks.UserRepository{}
+	userRepo.On("GetUserByID", mock.Anything, "user123").
+		Return(models.User{ID: "user123", Role: "admin", MFAEnabled: true, MFARecoveryCodes: []string{"hash1", "hash2"}}, nil)
+
+	useCase := NewMFAUseCase(userRepo, &mocks.OTPServiceMock{}, &mocks.MockHasher{}, openLoginThrottle(), &mocks.MockTokenRepository{}, &mocks.TokenVersionStoreMock{}, []string{"admin"})
+	status, err := useCase.GetStatus(context.Background(), "user123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.MFAStatus{Enabled: true, Required: true, RecoveryCodesLeft: 2}, status)
+	assert.False(t, useCase.RequiredFor("user"))
+}
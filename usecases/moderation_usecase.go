@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"log"
+	"strings"
+)
+
+// maxModerationExcerpt caps how much of held content the queue shows
+const maxModerationExcerpt = 280
+
+type moderationUseCase struct {
+	moderator      interfaces.Moderator
+	moderationRepo interfaces.ModerationRepository
+	blogRepo       interfaces.BlogRepository
+	commentRepo    interfaces.CommentRepository
+	userRepo       interfaces.UserRepository
+	emailService   interfaces.EmailService
+}
+
+func NewModerationUseCase(moderator interfaces.Moderator, moderationRepo interfaces.ModerationRepository, blogRepo interfaces.BlogRepository, commentRepo interfaces.CommentRepository, userRepo interfaces.UserRepository, emailService interfaces.EmailService) interfaces.ModerationUseCase {
+	return &moderationUseCase{
+		moderator:      moderator,
+		moderationRepo: moderationRepo,
+		blogRepo:       blogRepo,
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		emailService:   emailService,
+	}
+}
+
+func (m *moderationUseCase) Check(ctx context.Context, content models.ModerationContent) (models.ModerationVerdict, error) {
+	verdict, err := m.moderator.Moderate(ctx, content)
+	if err != nil {
+		return models.ModerationVerdict{}, err
+	}
+	if verdict.Decision == "" {
+		verdict.Decision = models.ModerationAllow
+	}
+	return verdict, nil
+}
+
+// Hold queues held content for review. Rejected content is queued too, so an admin can still
+// overrule the moderator.
+func (m *moderationUseCase) Hold(content models.ModerationContent, verdict models.ModerationVerdict, publish bool) (models.ModerationItem, error) {
+	return m.queue(content, verdict.Reasons, publish, false)
+}
+
+func (m *moderationUseCase) queue(content models.ModerationContent, reasons []string, publish, takenDown bool) (models.ModerationItem, error) {
+	return m.moderationRepo.QueueItem(models.ModerationItem{
+		ContentType: content.Type,
+		ContentID:   content.ID,
+		BlogID:      content.BlogID,
+		AuthorID:    content.AuthorID,
+		Title:       content.Title,
+		Excerpt:     moderationExcerpt(content.Body),
+		Reasons:     reasons,
+		Publish:     publish,
+		TakenDown:   takenDown,
+	})
+}
+
+// Flag takes live content down and queues it for review, marking the item as a takedown. Content
+// already waiting for review keeps its queue item, and with it whether its author asked for it to
+// go live and whether it was taken down; the reasons are added. A live post goes back up if the
+// review clears it.
+func (m *moderationUseCase) Flag(contentType, contentID string, reasons []string) (models.ModerationItem, error) {
+	var content models.ModerationContent
+	var status string
+	publish := false
+
+	switch contentType {
+	case models.ModerationContentBlog:
+		blog, err := m.blogRepo.GetBlogByID(contentID)
+		if err != nil {
+			return models.ModerationItem{}, models.ErrBlogNotFound
+		}
+		content, status, publish = blogModerationContent(blog), blog.ModerationStatus, blog.IsPublished
+	case models.ModerationContentComment:
+		comment, err := m.commentRepo.GetCommentByID(contentID)
+		if err != nil {
+			return models.ModerationItem{}, errors.New("comment not found")
+		}
+		content, status = commentModerationContent(comment), comment.ModerationStatus
+	default:
+		return models.ModerationItem{}, errors.New("unknown moderation content type")
+	}
+
+	if status == models.ModerationStatusPending {
+		if pending, err := m.moderationRepo.GetPendingItem(contentType, contentID); err == nil {
+			publish = pending.Publish
+		}
+	}
+
+	item, err := m.queue(content, reasons, publish, status != models.ModerationStatusPending)
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	if status != models.ModerationStatusPending {
+		if contentType == models.ModerationContentBlog {
+			err = m.blogRepo.SetModerationStatus(contentID, models.ModerationStatusPending, false)
+		} else {
+			err = m.commentRepo.SetModerationStatus(contentID, models.ModerationStatusPending)
+		}
+		if err != nil {
+			return models.ModerationItem{}, err
+		}
+	}
+
+	return item, nil
+}
+
+func (m *moderationUseCase) GetQueue(status string, page, limit int) ([]models.ModerationItem, int64, error) {
+	if status != "" && !isModerationStatus(status) {
+		return nil, 0, errors.New("invalid moderation status")
+	}
+
+	items, err := m.moderationRepo.GetItems(status, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := m.moderationRepo.CountItems(status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+func (m *moderationUseCase) GetItem(itemID string) (models.ModerationItem, error) {
+	return m.moderationRepo.GetItemByID(itemID)
+}
+
+func (m *moderationUseCase) GetPendingItem(contentType, contentID string) (models.ModerationItem, error) {
+	return m.moderationRepo.GetPendingItem(contentType, contentID)
+}
+
+// Review resolves a pending item first, so that of two admins deciding at once only one decision
+// reaches the content. An approved post goes live if its author asked for that.
+func (m *moderationUseCase) Review(ctx context.Context, itemID, reviewerID, status, note string) (models.ModerationItem, error) {
+	if status != models.ModerationStatusApproved && status != models.ModerationStatusRejected {
+		return models.ModerationItem{}, errors.New("status must be approved or rejected")
+	}
+
+	item, err := m.moderationRepo.GetItemByID(itemID)
+	if err != nil {
+		return models.ModerationItem{}, errors.New("moderation item not found")
+	}
+	if item.Status != models.ModerationStatusPending {
+		return models.ModerationItem{}, errors.New("moderation item already reviewed")
+	}
+
+	resolved, err := m.moderationRepo.ResolveItem(itemID, status, reviewerID, strings.TrimSpace(note))
+	if err != nil {
+		return models.ModerationItem{}, errors.New("moderation item already reviewed")
+	}
+
+	switch resolved.ContentType {
+	case models.ModerationContentBlog:
+		publish := status == models.ModerationStatusApproved && resolved.Publish
+		err = m.blogRepo.SetModerationStatus(resolved.ContentID, status, publish)
+	case models.ModerationContentComment:
+		err = m.commentRepo.SetModerationStatus(resolved.ContentID, status)
+	default:
+		err = errors.New("unknown moderation content type")
+	}
+	if err != nil {
+		return models.ModerationItem{}, err
+	}
+
+	m.notifyAuthor(ctx, resolved)
+
+	return resolved, nil
+}
+
+// notifyAuthor emails the author the outcome of a review. The decision stands either way, so
+// failures are only logged.
+func (m *moderationUseCase) notifyAuthor(ctx context.Context, item models.ModerationItem) {
+	author, err := m.userRepo.GetUserByID(ctx, item.AuthorID)
+	if err != nil {
+		log.Printf("Failed to look up author %s of moderated %s %s: %v", item.AuthorID, item.ContentType, item.ContentID, err)
+		return
+	}
+
+	title := item.Title
+	if title == "" {
+		title = item.Excerpt
+	}
+
+	if err := m.emailService.SendModerationResultEmail(author.Username, author.Email, title, item.Status, item.ReviewNote); err != nil {
+		log.Printf("Failed to send moderation result email to %s: %v", author.Email, err)
+	}
+}
+
+func isModerationStatus(status string) bool {
+	return status == models.ModerationStatusPending || status == models.ModerationStatusApproved || status == models.ModerationStatusRejected
+}
+
+// moderationExcerpt is the start of the content, cut at maxModerationExcerpt characters
+func moderationExcerpt(body string) string {
+	runes := []rune(strings.TrimSpace(body))
+	if len(runes) > maxModerationExcerpt {
+		return string(runes[:maxModerationExcerpt]) + "…"
+	}
+	return string(runes)
+}
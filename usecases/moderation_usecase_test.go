@@ -0,0 +1,186 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestModerationUseCase_Review(t *testing.T) {
+	pendingBlog := models.ModerationItem{ID: "item123", ContentType: models.ModerationContentBlog, ContentID: "blog123", AuthorID: "user123", Title: "Deals", Status: models.ModerationStatusPending, Publish: true}
+	pendingComment := models.ModerationItem{ID: "item456", ContentType: models.ModerationContentComment, ContentID: "comment123", BlogID: "blog123", AuthorID: "user123", Excerpt: "buy now", Status: models.ModerationStatusPending}
+	author := models.User{ID: "user123", Username: "gopher", Email: "gopher@example.com"}
+
+	resolved := func(item models.ModerationItem, status, note string) models.ModerationItem {
+		item.Status, item.ReviewerID, item.ReviewNote = status, "admin123", note
+		return item
+	}
+
+	tests := []struct {
+		name        string
+		itemID      string
+		status      string
+		note        string
+		setupMocks  func(*mocks.ModerationRepositoryMock, *mocks.BlogRepositoryMock, *mocks.CommentRepositoryMock, *mocks.UserRepository, *mocks.MockEmailService)
+		expectError bool
+	}{
+		{
+			name:   "Success - Approving a post publishes it and tells the author",
+			itemID: "item123",
+			status: models.ModerationStatusApproved,
+			setupMocks: func(repo *mocks.ModerationRepositoryMock, blogRepo *mocks.BlogRepositoryMock, commentRepo *mocks.CommentRepositoryMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetItemByID", "item123").Return(pendingBlog, nil)
+				repo.On("ResolveItem", "item123", models.ModerationStatusApproved, "admin123", "").Return(resolved(pendingBlog, models.ModerationStatusApproved, ""), nil)
+				blogRepo.On("SetModerationStatus", "blog123", models.ModerationStatusApproved, true).Return(nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(author, nil)
+				email.On("SendModerationResultEmail", "gopher", "gopher@example.com", "Deals", models.ModerationStatusApproved, "").Return(nil)
+			},
+		},
+		{
+			name:   "Success - Rejecting a comment keeps it hidden; a failed email is not an error",
+			itemID: "item456",
+			status: models.ModerationStatusRejected,
+			note:   "  No ads, please  ",
+			setupMocks: func(repo *mocks.ModerationRepositoryMock, blogRepo *mocks.BlogRepositoryMock, commentRepo *mocks.CommentRepositoryMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetItemByID", "item456").Return(pendingComment, nil)
+				repo.On("ResolveItem", "item456", models.ModerationStatusRejected, "admin123", "No ads, please").Return(resolved(pendingComment, models.ModerationStatusRejected, "No ads, please"), nil)
+				commentRepo.On("SetModerationStatus", "comment123", models.ModerationStatusRejected).Return(nil)
+				userRepo.On("GetUserByID", mock.Anything, "user123").Return(author, nil)
+				email.On("SendModerationResultEmail", "gopher", "gopher@example.com", "buy now", models.ModerationStatusRejected, "No ads, please").Return(errors.New("smtp down"))
+			},
+		},
+		{
+			name:   "Error - Invalid status",
+			itemID: "item123",
+			status: models.ModerationStatusPending,
+			setupMocks: func(repo *mocks.ModerationRepositoryMock, blogRepo *mocks.BlogRepositoryMock, commentRepo *mocks.CommentRepositoryMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+			},
+			expectError: true,
+		},
+		{
+			name:   "Error - Already reviewed",
+			itemID: "item123",
+			status: models.ModerationStatusRejected,
+			setupMocks: func(repo *mocks.ModerationRepositoryMock, blogRepo *mocks.BlogRepositoryMock, commentRepo *mocks.CommentRepositoryMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetItemByID", "item123").Return(resolved(pendingBlog, models.ModerationStatusApproved, ""), nil)
+			},
+			expectError: true,
+		},
+		{
+			name:   "Error - Another admin resolved it first",
+			itemID: "item123",
+			status: models.ModerationStatusApproved,
+			setupMocks: func(repo *mocks.ModerationRepositoryMock, blogRepo *mocks.BlogRepositoryMock, commentRepo *mocks.CommentRepositoryMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetItemByID", "item123").Return(pendingBlog, nil)
+				repo.On("ResolveItem", "item123", models.ModerationStatusApproved, "admin123", "").Return(models.ModerationItem{}, errors.New("mongo: no documents in result"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.ModerationRepositoryMock{}
+			blogRepo := &mocks.BlogRepositoryMock{}
+			commentRepo := &mocks.CommentRepositoryMock{}
+			userRepo := &mocks.UserRepository{}
+			email := &mocks.MockEmailService{}
+			tt.setupMocks(repo, blogRepo, commentRepo, userRepo, email)
+
+			useCase := NewModerationUseCase(&mocks.ModeratorMock{}, repo, blogRepo, commentRepo, userRepo, email)
+			item, err := useCase.Review(context.Background(), tt.itemID, "admin123", tt.status, tt.note)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				blogRepo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+				commentRepo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.status, item.Status)
+			}
+
+			repo.AssertExpectations(t)
+			blogRepo.AssertExpectations(t)
+			commentRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			email.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModerationUseCase_Hold(t *testing.T) {
+	repo := &mocks.ModerationRepositoryMock{}
+	body := strings.Repeat("é", maxModerationExcerpt+5)
+	repo.On("QueueItem", models.ModerationItem{
+		ContentType: models.ModerationContentComment,
+		ContentID:   "comment123",
+		BlogID:      "blog123",
+		AuthorID:    "user123",
+		Excerpt:     strings.Repeat("é", maxModerationExcerpt) + "…",
+		Reasons:     []string{"Repeats the same character"},
+	}).Return(models.ModerationItem{ID: "item123"}, nil)
+
+	useCase := NewModerationUseCase(&mocks.ModeratorMock{}, repo, &mocks.BlogRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.UserRepository{}, &mocks.MockEmailService{})
+	item, err := useCase.Hold(
+		models.ModerationContent{Type: models.ModerationContentComment, ID: "comment123", BlogID: "blog123", AuthorID: "user123", Body: body},
+		models.ModerationVerdict{Decision: models.ModerationReview, Reasons: []string{"Repeats the same character"}},
+		false,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "item123", item.ID)
+	repo.AssertExpectations(t)
+}
+
+func TestModerationUseCase_GetQueue_InvalidStatus(t *testing.T) {
+	repo := &mocks.ModerationRepositoryMock{}
+
+	useCase := NewModerationUseCase(&mocks.ModeratorMock{}, repo, &mocks.BlogRepositoryMock{}, &mocks.CommentRepositoryMock{}, &mocks.UserRepository{}, &mocks.MockEmailService{})
+	_, _, err := useCase.GetQueue("spam", 1, 10)
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "GetItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationUseCase_Flag(t *testing.T) {
+	reasons := []string{"Reported by 3 readers"}
+
+	t.Run("takes a live post down and publishes it again if approved", func(t *testing.T) {
+		repo := &mocks.ModerationRepositoryMock{}
+		blogRepo := &mocks.BlogRepositoryMock{}
+		blogRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "user123", Title: "Deals", Content: "Buy now", IsPublished: true}, nil)
+		repo.On("QueueItem", models.ModerationItem{ContentType: models.ModerationContentBlog, ContentID: "blog123", AuthorID: "user123", Title: "Deals", Excerpt: "Buy now", Reasons: reasons, Publish: true, TakenDown: true}).
+			Return(models.ModerationItem{ID: "item123"}, nil)
+		blogRepo.On("SetModerationStatus", "blog123", models.ModerationStatusPending, false).Return(nil)
+
+		useCase := NewModerationUseCase(&mocks.ModeratorMock{}, repo, blogRepo, &mocks.CommentRepositoryMock{}, &mocks.UserRepository{}, &mocks.MockEmailService{})
+		item, err := useCase.Flag(models.ModerationContentBlog, "blog123", reasons)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "item123", item.ID)
+		repo.AssertExpectations(t)
+		blogRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps the queue item of a comment already waiting for review", func(t *testing.T) {
+		repo := &mocks.ModerationRepositoryMock{}
+		commentRepo := &mocks.CommentRepositoryMock{}
+		commentRepo.On("GetCommentByID", "comment123").Return(models.Comment{ID: "comment123", BlogID: "blog123", AuthorID: "user123", Content: "buy now", ModerationStatus: models.ModerationStatusPending}, nil)
+		repo.On("GetPendingItem", models.ModerationContentComment, "comment123").Return(models.ModerationItem{ID: "item123"}, nil)
+		repo.On("QueueItem", models.ModerationItem{ContentType: models.ModerationContentComment, ContentID: "comment123", BlogID: "blog123", AuthorID: "user123", Excerpt: "buy now", Reasons: reasons}).
+			Return(models.ModerationItem{ID: "item123"}, nil)
+
+		useCase := NewModerationUseCase(&mocks.ModeratorMock{}, repo, &mocks.BlogRepositoryMock{}, commentRepo, &mocks.UserRepository{}, &mocks.MockEmailService{})
+		_, err := useCase.Flag(models.ModerationContentComment, "comment123", reasons)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		commentRepo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything)
+	})
+}
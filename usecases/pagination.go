@@ -0,0 +1,29 @@
+package usecases
+
+import "blog-api/Domain/models"
+
+// pageInfo links a fetched page to its neighbours. hasMore reports whether the repository found
+// items beyond the page in the direction it was fetched; cursorAt returns the cursor of item i.
+func pageInfo(page models.PageRequest, hasMore bool, n int, cursorAt func(i int) models.Cursor) models.PageInfo {
+	var info models.PageInfo
+	if n == 0 {
+		return info
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+
+	// A backward page always has the page it was reached from after it
+	if hasMore || backward {
+		next := cursorAt(n - 1)
+		info.Next = &next
+	}
+
+	// A forward page has something before it unless it is the first page
+	if (backward && hasMore) || (!backward && page.Cursor != nil) {
+		prev := cursorAt(0)
+		prev.Backward = true
+		info.Prev = &prev
+	}
+
+	return info
+}
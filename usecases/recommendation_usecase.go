@@ -49,21 +49,43 @@ func (r *recommendationUseCase) TrackUserAction(userID, blogID, action string) e
 	return nil
 }
 
-// GetUserRecommendations retrieves personalized recommendations for a user
-func (r *recommendationUseCase) GetUserRecommendations(userID string, limit int, category string) (models.RecommendationResponse, error) {
+// recommendationBatchSize is how many recommendations are generated at once, so later pages
+// are served from the same stored batch as the first one
+const recommendationBatchSize = 50
+
+// GetUserRecommendations retrieves a page of personalized recommendations for a user
+func (r *recommendationUseCase) GetUserRecommendations(userID string, category string, page models.PageRequest) (models.RecommendationResponse, error) {
 	// Get stored recommendations
-	recommendations, err := r.recommendationRepo.GetUserRecommendations(userID, limit, category)
+	recommendations, hasMore, err := r.recommendationRepo.GetUserRecommendationsByCursor(userID, category, page)
 	if err != nil {
 		return models.RecommendationResponse{}, err
 	}
 
-	// If no stored recommendations or they're old, generate new ones
-	if len(recommendations) == 0 || time.Since(recommendations[0].GeneratedAt) > 24*time.Hour {
-		newRecommendations, err := r.recommendationSvc.GenerateUserRecommendations(userID, limit)
+	// If no stored recommendations or they're old, generate new ones. Only the first page
+	// regenerates; replacing the batch mid-scroll would invalidate the client's cursor.
+	if page.Cursor == nil && (len(recommendations) == 0 || time.Since(recommendations[0].GeneratedAt) > 24*time.Hour) {
+		if err := r.recommendationRepo.DeleteUserRecommendations(userID); err != nil {
+			return models.RecommendationResponse{}, err
+		}
+		if _, err := r.recommendationSvc.GenerateUserRecommendations(userID, recommendationBatchSize); err != nil {
+			return models.RecommendationResponse{}, err
+		}
+
+		recommendations, hasMore, err = r.recommendationRepo.GetUserRecommendationsByCursor(userID, category, page)
+		if err != nil {
+			return models.RecommendationResponse{}, err
+		}
+	}
+
+	info := pageInfo(page, hasMore, len(recommendations), func(i int) models.Cursor {
+		return models.Cursor{Score: recommendations[i].Score, ID: recommendations[i].ID}
+	})
+	if page.WithTotal {
+		total, err := r.recommendationRepo.CountUserRecommendations(userID, category)
 		if err != nil {
 			return models.RecommendationResponse{}, err
 		}
-		recommendations = newRecommendations
+		info.Total = &total
 	}
 
 	// Convert to response format
@@ -88,6 +110,7 @@ func (r *recommendationUseCase) GetUserRecommendations(userID string, limit int,
 		Recommendations: blogRecommendations,
 		GeneratedAt:     time.Now(),
 		TotalCount:      len(blogRecommendations),
+		Page:            info,
 	}, nil
 }
 
@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxReportDetails caps the free text a reader can add to a report
+	maxReportDetails = 1000
+	// maxSuspensionDays caps how long one decision can suspend an author for
+	maxSuspensionDays = 365
+)
+
+type reportUseCase struct {
+	reportRepo    interfaces.ReportRepository
+	blogRepo      interfaces.BlogRepository
+	commentRepo   interfaces.CommentRepository
+	userRepo      interfaces.UserRepository
+	tokenRepo     interfaces.TokenRepository
+	tokenVersions interfaces.TokenVersionStore
+	moderationUC  interfaces.ModerationUseCase
+	emailService  interfaces.EmailService
+	hideThreshold int64
+	now           func() time.Time
+}
+
+// NewReportUseCase creates the report use case. Content is taken down for review once
+// hideThreshold readers reported it; zero turns that off.
+func NewReportUseCase(reportRepo interfaces.ReportRepository, blogRepo interfaces.BlogRepository, commentRepo interfaces.CommentRepository, userRepo interfaces.UserRepository, tokenRepo interfaces.TokenRepository, tokenVersions interfaces.TokenVersionStore, moderationUC interfaces.ModerationUseCase, emailService interfaces.EmailService, hideThreshold int) interfaces.ReportUseCase {
+	return &reportUseCase{
+		reportRepo:    reportRepo,
+		blogRepo:      blogRepo,
+		commentRepo:   commentRepo,
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		tokenVersions: tokenVersions,
+		moderationUC:  moderationUC,
+		emailService:  emailService,
+		hideThreshold: int64(hideThreshold),
+		now:           time.Now,
+	}
+}
+
+// ReportBlog files a report about a published post
+func (r *reportUseCase) ReportBlog(blogID, reporterID, reason, details string) (models.Report, error) {
+	blog, err := r.blogRepo.GetBlogByID(blogID)
+	if err != nil || !blog.IsPublished || models.IsModerationHeld(blog.ModerationStatus) {
+		return models.Report{}, models.ErrBlogNotFound
+	}
+	if blog.AuthorID == reporterID {
+		return models.Report{}, errors.New("you cannot report your own post")
+	}
+
+	return r.file(models.Report{
+		ContentType: models.ModerationContentBlog,
+		ContentID:   blog.ID,
+		BlogID:      blog.ID,
+		AuthorID:    blog.AuthorID,
+	}, reporterID, reason, details)
+}
+
+// ReportComment files a report about a visible comment under the given post
+func (r *reportUseCase) ReportComment(blogID, commentID, reporterID, reason, details string) (models.Report, error) {
+	comment, err := r.commentRepo.GetCommentByID(commentID)
+	if err != nil || comment.BlogID != blogID || comment.IsDeleted || models.IsModerationHeld(comment.ModerationStatus) {
+		return models.Report{}, errors.New("comment not found")
+	}
+	if comment.AuthorID == reporterID {
+		return models.Report{}, errors.New("you cannot report your own comment")
+	}
+
+	return r.file(models.Report{
+		ContentType: models.ModerationContentComment,
+		ContentID:   comment.ID,
+		BlogID:      comment.BlogID,
+		AuthorID:    comment.AuthorID,
+	}, reporterID, reason, details)
+}
+
+// file stores the report and takes the content down once it has enough open reports. The report
+// stands even if that fails, so the failure is only logged.
+func (r *reportUseCase) file(report models.Report, reporterID, reason, details string) (models.Report, error) {
+	if !models.IsReportReason(reason) {
+		return models.Report{}, fmt.Errorf("reason must be one of %s", strings.Join(models.ReportReasons, ", "))
+	}
+	details = strings.TrimSpace(details)
+	if utf8.RuneCountInString(details) > maxReportDetails {
+		return models.Report{}, fmt.Errorf("details must be at most %d characters", maxReportDetails)
+	}
+
+	report.ReporterID = reporterID
+	report.Reason = reason
+	report.Details = details
+	report.Status = models.ReportStatusOpen
+	report.CreatedAt = r.now()
+
+	created, err := r.reportRepo.CreateReport(report)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	if r.hideThreshold > 0 {
+		r.hideIfReportedEnough(created)
+	}
+
+	return created, nil
+}
+
+func (r *reportUseCase) hideIfReportedEnough(report models.Report) {
+	count, err := r.reportRepo.CountOpenReports(report.ContentType, report.ContentID)
+	if err != nil {
+		log.Printf("Failed to count reports of %s %s: %v", report.ContentType, report.ContentID, err)
+		return
+	}
+	if count < r.hideThreshold {
+		return
+	}
+
+	reasons := []string{fmt.Sprintf("Reported by %d readers", count)}
+	if _, err := r.moderationUC.Flag(report.ContentType, report.ContentID, reasons); err != nil {
+		log.Printf("Failed to hide reported %s %s: %v", report.ContentType, report.ContentID, err)
+	}
+}
+
+func (r *reportUseCase) GetReports(status string, page, limit int) ([]models.Report, int64, error) {
+	if status != "" && status != models.ReportStatusOpen && status != models.ReportStatusResolved {
+		return nil, 0, errors.New("invalid report status")
+	}
+
+	reports, err := r.reportRepo.GetReports(status, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.reportRepo.CountReports(status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reports, total, nil
+}
+
+func (r *reportUseCase) GetReport(reportID string) (models.Report, error) {
+	return r.reportRepo.GetReportByID(reportID)
+}
+
+// Resolve acts on the content and its author before closing the reports, so that a failed action
+// leaves them open to be resolved again. Hiding goes through the moderation queue, which tells the
+// author the content was rejected; dismissing approves content the reports took down, but leaves
+// content moderation held back when it was written waiting for review. Suspending also signs the
+// author out everywhere.
+func (r *reportUseCase) Resolve(ctx context.Context, reportID, resolverID, action, note string, suspendDays int) (models.Report, error) {
+	switch action {
+	case models.ReportActionDismiss, models.ReportActionHide, models.ReportActionWarn:
+	case models.ReportActionSuspend:
+		if suspendDays < 1 || suspendDays > maxSuspensionDays {
+			return models.Report{}, fmt.Errorf("suspend_days must be between 1 and %d", maxSuspensionDays)
+		}
+	default:
+		return models.Report{}, errors.New("action must be dismiss, hide, warn or suspend")
+	}
+	note = strings.TrimSpace(note)
+
+	report, err := r.reportRepo.GetReportByID(reportID)
+	if err != nil {
+		return models.Report{}, errors.New("report not found")
+	}
+	if report.Status != models.ReportStatusOpen {
+		return models.Report{}, errors.New("report already resolved")
+	}
+
+	var author models.User
+	if action == models.ReportActionWarn || action == models.ReportActionSuspend {
+		author, err = r.userRepo.GetUserByID(ctx, report.AuthorID)
+		if err != nil {
+			return models.Report{}, errors.New("author not found")
+		}
+		if action == models.ReportActionSuspend && author.Role == "superadmin" {
+			return models.Report{}, errors.New("superadmin cannot be suspended")
+		}
+	}
+
+	if action == models.ReportActionDismiss {
+		err = r.restore(ctx, report, resolverID, note)
+	} else {
+		err = r.hide(ctx, report, resolverID, note)
+	}
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	// The author hears why; the admin's note if there is one, else what readers reported
+	reason := note
+	if reason == "" {
+		reason = "Reported for " + report.Reason
+	}
+
+	switch action {
+	case models.ReportActionWarn:
+		if err := r.userRepo.AddWarning(ctx, author.ID); err != nil {
+			return models.Report{}, err
+		}
+		if err := r.emailService.SendWarningEmail(author.Username, author.Email, reason); err != nil {
+			log.Printf("Failed to send warning email to %s: %v", author.Email, err)
+		}
+	case models.ReportActionSuspend:
+		until := r.now().AddDate(0, 0, suspendDays)
+		if err := r.userRepo.Suspend(ctx, author.ID, until, reason); err != nil {
+			return models.Report{}, err
+		}
+		// Sessions the author has open end now rather than when their tokens expire
+		if _, err := r.tokenRepo.DeleteUserTokens(author.ID, ""); err != nil {
+			return models.Report{}, err
+		}
+		if err := r.tokenVersions.Bump(ctx, author.ID); err != nil {
+			return models.Report{}, err
+		}
+		if err := r.emailService.SendSuspensionEmail(author.Username, author.Email, reason, until); err != nil {
+			log.Printf("Failed to send suspension email to %s: %v", author.Email, err)
+		}
+	}
+
+	resolved, err := r.reportRepo.ResolveReports(report.ContentType, report.ContentID, resolverID, action, note)
+	if err != nil {
+		return models.Report{}, err
+	}
+	if resolved == 0 {
+		return models.Report{}, errors.New("report already resolved")
+	}
+
+	return r.reportRepo.GetReportByID(reportID)
+}
+
+// hide rejects the reported content through the moderation queue
+func (r *reportUseCase) hide(ctx context.Context, report models.Report, resolverID, note string) error {
+	item, err := r.moderationUC.Flag(report.ContentType, report.ContentID, []string{"Reported for " + report.Reason})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.moderationUC.Review(ctx, item.ID, resolverID, models.ModerationStatusRejected, note)
+	return err
+}
+
+// restore approves the reported content if reports took it down and it is still waiting for review
+func (r *reportUseCase) restore(ctx context.Context, report models.Report, resolverID, note string) error {
+	item, err := r.moderationUC.GetPendingItem(report.ContentType, report.ContentID)
+	if err != nil || !item.TakenDown {
+		return nil
+	}
+
+	_, err = r.moderationUC.Review(ctx, item.ID, resolverID, models.ModerationStatusApproved, note)
+	return err
+}
@@ -0,0 +1,321 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportUseCase_ReportBlog(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	live := models.Blog{ID: "blog123", AuthorID: "author123", IsPublished: true}
+	report := models.Report{
+		ContentType: models.ModerationContentBlog,
+		ContentID:   "blog123",
+		BlogID:      "blog123",
+		AuthorID:    "author123",
+		ReporterID:  "reader123",
+		Reason:      models.ReportReasonSpam,
+		Details:     "Only links to a shop",
+		Status:      models.ReportStatusOpen,
+		CreatedAt:   now,
+	}
+
+	tests := []struct {
+		name        string
+		reporterID  string
+		reason      string
+		details     string
+		setupMocks  func(*mocks.ReportRepositoryMock, *mocks.BlogRepositoryMock, *mocks.ModerationUseCaseMock)
+		expectError string
+	}{
+		{
+			name:       "Success - Below the threshold the post stays up",
+			reporterID: "reader123",
+			reason:     models.ReportReasonSpam,
+			details:    "  Only links to a shop ",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("CreateReport", report).Return(report, nil)
+				repo.On("CountOpenReports", models.ModerationContentBlog, "blog123").Return(int64(2), nil)
+			},
+		},
+		{
+			name:       "Success - Reaching the threshold takes the post down",
+			reporterID: "reader123",
+			reason:     models.ReportReasonSpam,
+			details:    "Only links to a shop",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("CreateReport", report).Return(report, nil)
+				repo.On("CountOpenReports", models.ModerationContentBlog, "blog123").Return(int64(3), nil)
+				moderationUC.On("Flag", models.ModerationContentBlog, "blog123", []string{"Reported by 3 readers"}).Return(models.ModerationItem{ID: "item123"}, nil)
+			},
+		},
+		{
+			name:       "Success - Failing to hide does not lose the report",
+			reporterID: "reader123",
+			reason:     models.ReportReasonSpam,
+			details:    "Only links to a shop",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("CreateReport", report).Return(report, nil)
+				repo.On("CountOpenReports", models.ModerationContentBlog, "blog123").Return(int64(4), nil)
+				moderationUC.On("Flag", models.ModerationContentBlog, "blog123", []string{"Reported by 4 readers"}).Return(models.ModerationItem{}, errors.New("db down"))
+			},
+		},
+		{
+			name:       "Error - Already reported",
+			reporterID: "reader123",
+			reason:     models.ReportReasonSpam,
+			details:    "Only links to a shop",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+				repo.On("CreateReport", report).Return(models.Report{}, errors.New("you have already reported this"))
+			},
+			expectError: "you have already reported this",
+		},
+		{
+			name:       "Error - Unknown reason",
+			reporterID: "reader123",
+			reason:     "boring",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+			},
+			expectError: "reason must be one of spam, harassment, hate, misinformation, other",
+		},
+		{
+			name:       "Error - Own post",
+			reporterID: "author123",
+			reason:     models.ReportReasonSpam,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(live, nil)
+			},
+			expectError: "you cannot report your own post",
+		},
+		{
+			name:       "Error - Held posts cannot be reported",
+			reporterID: "reader123",
+			reason:     models.ReportReasonSpam,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, blogRepo *mocks.BlogRepositoryMock, moderationUC *mocks.ModerationUseCaseMock) {
+				blogRepo.On("GetBlogByID", "blog123").Return(models.Blog{ID: "blog123", AuthorID: "author123", ModerationStatus: models.ModerationStatusPending}, nil)
+			},
+			expectError: "blog not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.ReportRepositoryMock{}
+			blogRepo := &mocks.BlogRepositoryMock{}
+			moderationUC := &mocks.ModerationUseCaseMock{}
+			tt.setupMocks(repo, blogRepo, moderationUC)
+
+			useCase := NewReportUseCase(repo, blogRepo, &mocks.CommentRepositoryMock{}, &mocks.UserRepository{}, &mocks.MockTokenRepository{}, &mocks.TokenVersionStoreMock{}, moderationUC, &mocks.MockEmailService{}, 3)
+			useCase.(*reportUseCase).now = func() time.Time { return now }
+			created, err := useCase.ReportBlog("blog123", tt.reporterID, tt.reason, tt.details)
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				repo.AssertNotCalled(t, "CountOpenReports", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, report, created)
+			}
+
+			repo.AssertExpectations(t)
+			blogRepo.AssertExpectations(t)
+			moderationUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReportUseCase_ReportComment_NotInBlog(t *testing.T) {
+	commentRepo := &mocks.CommentRepositoryMock{}
+	commentRepo.On("GetCommentByID", "comment123").Return(models.Comment{ID: "comment123", BlogID: "blog456", AuthorID: "author123"}, nil)
+	repo := &mocks.ReportRepositoryMock{}
+
+	useCase := NewReportUseCase(repo, &mocks.BlogRepositoryMock{}, commentRepo, &mocks.UserRepository{}, &mocks.MockTokenRepository{}, &mocks.TokenVersionStoreMock{}, &mocks.ModerationUseCaseMock{}, &mocks.MockEmailService{}, 3)
+	_, err := useCase.ReportComment("blog123", "comment123", "reader123", models.ReportReasonHarassment, "")
+
+	assert.EqualError(t, err, "comment not found")
+	repo.AssertNotCalled(t, "CreateReport", mock.Anything)
+}
+
+func TestReportUseCase_Resolve(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	open := models.Report{ID: "report123", ContentType: models.ModerationContentComment, ContentID: "comment123", BlogID: "blog123", AuthorID: "author123", Reason: models.ReportReasonHarassment, Status: models.ReportStatusOpen}
+	resolved := open
+	resolved.Status = models.ReportStatusResolved
+	author := models.User{ID: "author123", Username: "troll", Email: "troll@example.com", Role: "user"}
+
+	// Hiding goes through the moderation queue
+	expectHide := func(moderationUC *mocks.ModerationUseCaseMock, note string) {
+		moderationUC.On("Flag", models.ModerationContentComment, "comment123", []string{"Reported for harassment"}).Return(models.ModerationItem{ID: "item123"}, nil)
+		moderationUC.On("Review", mock.Anything, "item123", "admin123", models.ModerationStatusRejected, note).Return(models.ModerationItem{ID: "item123", Status: models.ModerationStatusRejected}, nil)
+	}
+
+	tests := []struct {
+		name          string
+		action        string
+		note          string
+		suspendDays   int
+		setupMocks    func(*mocks.ReportRepositoryMock, *mocks.ModerationUseCaseMock, *mocks.UserRepository, *mocks.MockEmailService)
+		expectSignOut bool
+		expectError   string
+	}{
+		{
+			name:   "Success - Hide",
+			action: models.ReportActionHide,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionHide, "").Return(int64(3), nil)
+				expectHide(moderationUC, "")
+				repo.On("GetReportByID", "report123").Return(resolved, nil).Once()
+			},
+		},
+		{
+			name:   "Success - Warn hides the comment and tells the author why",
+			action: models.ReportActionWarn,
+			note:   " Be civil ",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				userRepo.On("GetUserByID", mock.Anything, "author123").Return(author, nil)
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionWarn, "Be civil").Return(int64(1), nil)
+				expectHide(moderationUC, "Be civil")
+				userRepo.On("AddWarning", mock.Anything, "author123").Return(nil)
+				email.On("SendWarningEmail", "troll", "troll@example.com", "Be civil").Return(nil)
+				repo.On("GetReportByID", "report123").Return(resolved, nil).Once()
+			},
+		},
+		{
+			name:        "Success - Suspend for a number of days",
+			action:      models.ReportActionSuspend,
+			suspendDays: 7,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				userRepo.On("GetUserByID", mock.Anything, "author123").Return(author, nil)
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionSuspend, "").Return(int64(1), nil)
+				expectHide(moderationUC, "")
+				userRepo.On("Suspend", mock.Anything, "author123", now.AddDate(0, 0, 7), "Reported for harassment").Return(nil)
+				email.On("SendSuspensionEmail", "troll", "troll@example.com", "Reported for harassment", now.AddDate(0, 0, 7)).Return(errors.New("smtp down"))
+				repo.On("GetReportByID", "report123").Return(resolved, nil).Once()
+			},
+			expectSignOut: true,
+		},
+		{
+			name:   "Success - Dismiss puts content the reports took down back up",
+			action: models.ReportActionDismiss,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionDismiss, "").Return(int64(5), nil)
+				moderationUC.On("GetPendingItem", models.ModerationContentComment, "comment123").Return(models.ModerationItem{ID: "item123", TakenDown: true}, nil)
+				moderationUC.On("Review", mock.Anything, "item123", "admin123", models.ModerationStatusApproved, "").Return(models.ModerationItem{ID: "item123"}, nil)
+				repo.On("GetReportByID", "report123").Return(resolved, nil).Once()
+			},
+		},
+		{
+			name:   "Success - Dismiss leaves content held when written waiting for review",
+			action: models.ReportActionDismiss,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionDismiss, "").Return(int64(1), nil)
+				moderationUC.On("GetPendingItem", models.ModerationContentComment, "comment123").Return(models.ModerationItem{ID: "item123"}, nil)
+				repo.On("GetReportByID", "report123").Return(resolved, nil).Once()
+			},
+		},
+		{
+			name:   "Success - Dismiss leaves visible content alone",
+			action: models.ReportActionDismiss,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionDismiss, "").Return(int64(1), nil)
+				moderationUC.On("GetPendingItem", models.ModerationContentComment, "comment123").Return(models.ModerationItem{}, errors.New("mongo: no documents in result"))
+				repo.On("GetReportByID", "report123").Return(resolved, nil).Once()
+			},
+		},
+		{
+			name:   "Error - Suspension without a duration",
+			action: models.ReportActionSuspend,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+			},
+			expectError: "suspend_days must be between 1 and 365",
+		},
+		{
+			name:   "Error - Unknown action",
+			action: "ban",
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+			},
+			expectError: "action must be dismiss, hide, warn or suspend",
+		},
+		{
+			name:        "Error - Superadmins cannot be suspended",
+			action:      models.ReportActionSuspend,
+			suspendDays: 1,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				userRepo.On("GetUserByID", mock.Anything, "author123").Return(models.User{ID: "author123", Role: "superadmin"}, nil)
+			},
+			expectError: "superadmin cannot be suspended",
+		},
+		{
+			name:   "Error - Failed hide leaves the reports open",
+			action: models.ReportActionHide,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				moderationUC.On("Flag", models.ModerationContentComment, "comment123", []string{"Reported for harassment"}).Return(models.ModerationItem{}, errors.New("comment not found"))
+			},
+			expectError: "comment not found",
+		},
+		{
+			name:   "Error - Another admin resolved it first",
+			action: models.ReportActionHide,
+			setupMocks: func(repo *mocks.ReportRepositoryMock, moderationUC *mocks.ModerationUseCaseMock, userRepo *mocks.UserRepository, email *mocks.MockEmailService) {
+				repo.On("GetReportByID", "report123").Return(open, nil).Once()
+				expectHide(moderationUC, "")
+				repo.On("ResolveReports", models.ModerationContentComment, "comment123", "admin123", models.ReportActionHide, "").Return(int64(0), nil)
+			},
+			expectError: "report already resolved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.ReportRepositoryMock{}
+			moderationUC := &mocks.ModerationUseCaseMock{}
+			userRepo := &mocks.UserRepository{}
+			email := &mocks.MockEmailService{}
+			tokenRepo := &mocks.MockTokenRepository{}
+			tokenVersions := &mocks.TokenVersionStoreMock{}
+			tt.setupMocks(repo, moderationUC, userRepo, email)
+			if tt.expectSignOut {
+				tokenRepo.On("DeleteUserTokens", "author123", "").Return(int64(2), nil)
+				tokenVersions.On("Bump", mock.Anything, "author123").Return(nil)
+			}
+
+			useCase := NewReportUseCase(repo, &mocks.BlogRepositoryMock{}, &mocks.CommentRepositoryMock{}, userRepo, tokenRepo, tokenVersions, moderationUC, email, 3)
+			useCase.(*reportUseCase).now = func() time.Time { return now }
+			report, err := useCase.Resolve(context.Background(), "report123", "admin123", tt.action, tt.note, tt.suspendDays)
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.ReportStatusResolved, report.Status)
+			}
+
+			repo.AssertExpectations(t)
+			moderationUC.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			email.AssertExpectations(t)
+			tokenRepo.AssertExpectations(t)
+			tokenVersions.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxSearchTerms    = 10
+	maxSnippets       = 3
+	snippetContext    = 60
+	fallbackSnippetSz = 160
+)
+
+// searchTerms splits user input into plain words. Quotes and leading minus signs are
+// dropped so the input cannot use MongoDB text search phrase or negation operators.
+func searchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+
+	for _, field := range strings.Fields(query) {
+		term := strings.TrimLeft(strings.ReplaceAll(field, `"`, ""), "-")
+		key := strings.ToLower(term)
+		if term == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		terms = append(terms, term)
+
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+
+	return terms
+}
+
+// highlightSnippets cuts short excerpts around the first matches of the terms.
+// Content is HTML-escaped and matches are wrapped in <mark> so clients can render it directly.
+func highlightSnippets(text string, terms []string) []string {
+	if text == "" || len(terms) == 0 {
+		return []string{}
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	pattern := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	matches := pattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		end := runeBoundary(text, fallbackSnippetSz)
+		snippet := html.EscapeString(text[:end])
+		if end < len(text) {
+			snippet += "…"
+		}
+		return []string{snippet}
+	}
+
+	var snippets []string
+	for i := 0; i < len(matches) && len(snippets) < maxSnippets; {
+		start := runeBoundary(text, matches[i][0]-snippetContext)
+		end := runeBoundary(text, matches[i][1]+snippetContext)
+
+		// Fold following matches that fall inside this window into the same snippet
+		var b strings.Builder
+		if start > 0 {
+			b.WriteString("…")
+		}
+		pos := start
+		for i < len(matches) && matches[i][0] < end {
+			if matches[i][1] > end {
+				end = runeBoundary(text, matches[i][1])
+			}
+			b.WriteString(html.EscapeString(text[pos:matches[i][0]]))
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(text[matches[i][0]:matches[i][1]]))
+			b.WriteString("</mark>")
+			pos = matches[i][1]
+			i++
+		}
+		b.WriteString(html.EscapeString(text[pos:end]))
+		if end < len(text) {
+			b.WriteString("…")
+		}
+
+		snippets = append(snippets, b.String())
+	}
+
+	return snippets
+}
+
+// runeBoundary clamps a byte offset into text and moves it back to the start of a UTF-8 character
+func runeBoundary(text string, offset int) int {
+	if offset <= 0 {
+		return 0
+	}
+	if offset >= len(text) {
+		return len(text)
+	}
+	for offset > 0 && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	return offset
+}
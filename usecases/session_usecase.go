@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+)
+
+type sessionUseCase struct {
+	tokenRepo     interfaces.TokenRepository
+	tokenService  interfaces.TokenService
+	userRepo      interfaces.UserRepository
+	tokenVersions interfaces.TokenVersionStore
+}
+
+func NewSessionUseCase(tokenRepo interfaces.TokenRepository, tokenService interfaces.TokenService, userRepo interfaces.UserRepository, tokenVersions interfaces.TokenVersionStore) interfaces.SessionUseCase {
+	return &sessionUseCase{
+		tokenRepo:     tokenRepo,
+		tokenService:  tokenService,
+		userRepo:      userRepo,
+		tokenVersions: tokenVersions,
+	}
+}
+
+// GetSessions lists the user's sessions, most recently used first
+func (s *sessionUseCase) GetSessions(userID string) ([]models.Session, error) {
+	tokens, err := s.tokenRepo.GetActiveTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.Session, len(tokens))
+	for i, token := range tokens {
+		sessions[i] = toSession(token)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends one of the user's sessions. Other users' sessions are reported as missing.
+func (s *sessionUseCase) RevokeSession(userID, sessionID string) error {
+	tokens, err := s.tokenRepo.GetActiveTokens(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if tokenFamily(token) == sessionID {
+			return s.tokenRepo.DeleteTokenFamily(sessionID)
+		}
+	}
+
+	return errors.New("session not found")
+}
+
+// RevokeOtherSessions keeps the session of the given refresh token, which must be the user's own
+// and still live, and ends the rest
+func (s *sessionUseCase) RevokeOtherSessions(userID, refreshToken string) (int64, error) {
+	claims, err := s.tokenService.VerifyRefreshToken(refreshToken)
+	if err != nil || claims.UserID != userID {
+		return 0, errors.New("invalid refresh token")
+	}
+
+	current, err := s.tokenRepo.GetToken(claims.TokenID)
+	if err != nil || current == nil || current.RotatedAt != nil || !s.tokenService.VerifyToken(current.Token, refreshToken) {
+		return 0, errors.New("invalid refresh token")
+	}
+
+	return s.revokeUserTokens(context.TODO(), userID, tokenFamily(current))
+}
+
+func (s *sessionUseCase) RevokeAllSessions(ctx context.Context, userID string) (int64, error) {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return 0, errors.New("user not found")
+	}
+
+	return s.revokeUserTokens(ctx, userID, "")
+}
+
+// revokeUserTokens deletes the refresh tokens and then revokes every access token too. That
+// includes the caller's own, which its kept refresh token replaces.
+func (s *sessionUseCase) revokeUserTokens(ctx context.Context, userID, keepFamilyID string) (int64, error) {
+	revoked, err := s.tokenRepo.DeleteUserTokens(userID, keepFamilyID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.tokenVersions.Bump(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
+func toSession(token *models.Token) models.Session {
+	session := models.Session{
+		ID:         tokenFamily(token),
+		IP:         token.IP,
+		UserAgent:  token.Device,
+		CreatedAt:  token.LoginAt,
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+	}
+	// Tokens issued before sessions were tracked only know when they were created
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = token.CreatedAt
+	}
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = token.CreatedAt
+	}
+	return session
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSessionUseCase_GetSessions(t *testing.T) {
+	tokenRepo := &mocks.MockTokenRepository{}
+	loginAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	usedAt := time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC)
+	legacyAt := time.Date(2025, 5, 20, 8, 0, 0, 0, time.UTC)
+	tokenRepo.On("GetActiveTokens", "user123").Return([]*models.Token{
+		{ID: "token2", FamilyID: "family1", IP: "203.0.113.7", Device: "Firefox", LoginAt: loginAt, LastUsedAt: usedAt, CreatedAt: usedAt},
+		{ID: "legacy1", CreatedAt: legacyAt},
+	}, nil)
+
+	useCase := NewSessionUseCase(tokenRepo, &mocks.MockTokenService{}, &mocks.UserRepository{}, &mocks.TokenVersionStoreMock{})
+	sessions, err := useCase.GetSessions("user123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Session{
+		{ID: "family1", IP: "203.0.113.7", UserAgent: "Firefox", CreatedAt: loginAt, LastUsedAt: usedAt},
+		// A token issued before sessions were tracked is its own session
+		{ID: "legacy1", CreatedAt: legacyAt, LastUsedAt: legacyAt},
+	}, sessions)
+}
+
+func TestSessionUseCase_RevokeSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		sessionID   string
+		expectError string
+	}{
+		{name: "Success - Own session", sessionID: "family1"},
+		{name: "Error - Someone else's session", sessionID: "family9", expectError: "session not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenRepo := &mocks.MockTokenRepository{}
+			tokenRepo.On("GetActiveTokens", "user123").Return([]*models.Token{{ID: "token2", FamilyID: "family1"}}, nil)
+			if tt.expectError == "" {
+				tokenRepo.On("DeleteTokenFamily", "family1").Return(nil)
+			}
+
+			useCase := NewSessionUseCase(tokenRepo, &mocks.MockTokenService{}, &mocks.UserRepository{}, &mocks.TokenVersionStoreMock{})
+			err := useCase.RevokeSession("user123", tt.sessionID)
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				tokenRepo.AssertNotCalled(t, "DeleteTokenFamily", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			tokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionUseCase_RevokeOtherSessions(t *testing.T) {
+	rotatedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		claims      *models.UserRefreshClaims
+		stored      *models.Token
+		expectError string
+	}{
+		{
+			name:   "Success - Keeps the current session",
+			claims: &models.UserRefreshClaims{UserID: "user123", TokenID: "token2"},
+			stored: &models.Token{ID: "token2", Token: "hashed_token", FamilyID: "family1"},
+		},
+		{
+			name:        "Error - Another user's refresh token",
+			claims:      &models.UserRefreshClaims{UserID: "user456", TokenID: "token2"},
+			expectError: "invalid refresh token",
+		},
+		{
+			name:        "Error - A rotated refresh token",
+			claims:      &models.UserRefreshClaims{UserID: "user123", TokenID: "token1"},
+			stored:      &models.Token{ID: "token1", Token: "hashed_token", FamilyID: "family1", RotatedAt: &rotatedAt},
+			expectError: "invalid refresh token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenRepo := &mocks.MockTokenRepository{}
+			tokenService := &mocks.MockTokenService{}
+			tokenService.On("VerifyRefreshToken", "refresh.jwt.token").Return(tt.claims, nil)
+			if tt.stored != nil {
+				tokenRepo.On("GetToken", tt.claims.TokenID).Return(tt.stored, nil)
+				tokenService.On("VerifyToken", "hashed_token", "refresh.jwt.token").Return(true).Maybe()
+			}
+			tokenVersions := &mocks.TokenVersionStoreMock{}
+			if tt.expectError == "" {
+				tokenRepo.On("DeleteUserTokens", "user123", "family1").Return(int64(2), nil)
+				tokenVersions.On("Bump", mock.Anything, "user123").Return(nil)
+			}
+
+			useCase := NewSessionUseCase(tokenRepo, tokenService, &mocks.UserRepository{}, tokenVersions)
+			revoked, err := useCase.RevokeOtherSessions("user123", "refresh.jwt.token")
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				tokenRepo.AssertNotCalled(t, "DeleteUserTokens", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), revoked)
+			}
+			tokenRepo.AssertExpectations(t)
+			tokenVersions.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionUseCase_RevokeAllSessions(t *testing.T) {
+	tokenRepo := &mocks.MockTokenRepository{}
+	userRepo := &mocks.UserRepository{}
+	userRepo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123"}, nil)
+	userRepo.On("GetUserByID", mock.Anything, "missing").Return(models.User{}, errors.New("mongo: no documents in result"))
+	tokenRepo.On("DeleteUserTokens", "user123", "").Return(int64(3), nil)
+	tokenVersions := &mocks.TokenVersionStoreMock{}
+	tokenVersions.On("Bump", mock.Anything, "user123").Return(nil)
+
+	useCase := NewSessionUseCase(tokenRepo, &mocks.MockTokenService{}, userRepo, tokenVersions)
+	revoked, err := useCase.RevokeAllSessions(context.Background(), "user123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), revoked)
+
+	_, err = useCase.RevokeAllSessions(context.Background(), "missing")
+
+	assert.EqualError(t, err, "user not found")
+	tokenRepo.AssertNumberOfCalls(t, "DeleteUserTokens", 1)
+	tokenVersions.AssertNumberOfCalls(t, "Bump", 1)
+}
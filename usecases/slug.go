@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	maxSlugLength = 80
+	// Numbered suffixes tried before falling back to the blog ID
+	maxSlugSuffix = 10
+	fallbackSlug  = "post"
+)
+
+// transliterations covers letters that do not decompose into an ASCII base letter
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// slugify turns a title into a lowercase, hyphen-separated ASCII slug.
+// Accents are stripped, common non-Latin letters are transliterated and anything else becomes a separator.
+// Letters are transliterated before accents are stripped, since letters such as 'й' decompose into
+// a base letter that transliterates differently.
+func slugify(title string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range norm.NFC.String(strings.ToLower(title)) {
+		if unicode.Is(unicode.Mn, r) {
+			// Combining accent that does not compose with the letter before it
+			continue
+		}
+
+		part, known := transliterations[r]
+		if !known {
+			part, known = transliterateDecomposed(r)
+		}
+
+		if !known {
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if part == "" {
+			// Silent letters such as the Cyrillic hard and soft signs
+			continue
+		}
+
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteString(part)
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		return fallbackSlug
+	}
+	return slug
+}
+
+// transliterateDecomposed strips the accents off r and transliterates what is left
+func transliterateDecomposed(r rune) (string, bool) {
+	if r < unicode.MaxASCII {
+		return string(r), unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	var base strings.Builder
+	for _, d := range norm.NFD.String(string(r)) {
+		if unicode.Is(unicode.Mn, d) {
+			// Combining accent left over from decomposition
+			continue
+		}
+		part, known := transliterations[d]
+		if !known && d < unicode.MaxASCII && (unicode.IsLetter(d) || unicode.IsDigit(d)) {
+			part, known = string(d), true
+		}
+		if !known {
+			return "", false
+		}
+		base.WriteString(part)
+	}
+	return base.String(), base.Len() > 0
+}
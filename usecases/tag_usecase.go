@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"context"
+	"errors"
+	"strings"
+)
+
+type tagUseCase struct {
+	tagRepo    interfaces.TagRepository
+	blogRepo   interfaces.BlogRepository
+	aiProvider interfaces.AIProvider
+}
+
+func NewTagUseCase(tagRepo interfaces.TagRepository, blogRepo interfaces.BlogRepository, aiProvider interfaces.AIProvider) interfaces.TagUseCase {
+	return &tagUseCase{
+		tagRepo:    tagRepo,
+		blogRepo:   blogRepo,
+		aiProvider: aiProvider,
+	}
+}
+
+func (u *tagUseCase) GetTags() ([]models.Tag, error) {
+	return u.tagRepo.GetTags()
+}
+
+// SuggestTags asks the AI provider for tags, pointing it at the vocabulary tags the post already
+// mentions. When the provider fails or suggests nothing, the mentioned tags and the post's most
+// frequent words are used instead. Either way suggestions are mapped onto canonical tags.
+func (u *tagUseCase) SuggestTags(ctx context.Context, title, content string) (models.TagSuggestion, error) {
+	if strings.TrimSpace(title) == "" && strings.TrimSpace(content) == "" {
+		return models.TagSuggestion{}, errors.New("title or content is required")
+	}
+
+	vocabulary, err := u.tagRepo.GetTags()
+	if err != nil {
+		return models.TagSuggestion{}, err
+	}
+
+	titleWords := tagWords(title)
+	contentWords := tagWords(content)
+	mentioned := mentionedTags(append(append([]string{}, titleWords...), contentWords...), vocabulary)
+
+	generation, err := u.aiProvider.Generate(ctx, models.AIGenerationRequest{
+		Type:     models.AISuggestionTypeTags,
+		Keywords: mentioned,
+		Title:    title,
+		Content:  content,
+	})
+	if err == nil {
+		if tags := canonicalize(normalizeTags(generation.Tags), vocabulary); len(tags) > 0 {
+			return models.TagSuggestion{Tags: limitTags(tags), Source: models.TagSuggestionSourceAI}, nil
+		}
+	}
+
+	tags := canonicalize(append(mentioned, frequentWords(titleWords, contentWords, vocabulary)...), vocabulary)
+	return models.TagSuggestion{Tags: limitTags(tags), Source: models.TagSuggestionSourceKeywords}, nil
+}
+
+// MergeTags makes target canonical for the sources and rewrites the blogs using any of them.
+// A target that is itself an alias resolves to its canonical tag first. Merging a tag into
+// itself only tidies up spellings of it on blogs.
+func (u *tagUseCase) MergeTags(sources []string, target string) (models.TagMergeResult, error) {
+	target = normalizeTag(target)
+	if target == "" {
+		return models.TagMergeResult{}, errors.New("target tag is required")
+	}
+
+	known, err := u.tagRepo.FindTags([]string{target})
+	if err != nil {
+		return models.TagMergeResult{}, err
+	}
+	target = canonicalize([]string{target}, known)[0]
+
+	var merged []string
+	for _, source := range normalizeTags(sources) {
+		if source != target {
+			merged = append(merged, source)
+		}
+	}
+
+	var result models.TagMergeResult
+	if len(merged) > 0 {
+		tag, err := u.tagRepo.MergeTags(merged, target)
+		if err != nil {
+			return models.TagMergeResult{}, err
+		}
+		result.Tag = tag
+	} else {
+		result.Tag = models.Tag{Name: target, Aliases: []string{}}
+		for _, tag := range known {
+			if tag.Name == target {
+				result.Tag = tag
+			}
+		}
+	}
+
+	updated, err := u.blogRepo.ReplaceTags(merged, target)
+	if err != nil {
+		return models.TagMergeResult{}, err
+	}
+	result.BlogsUpdated = updated
+
+	return result, nil
+}
+
+func limitTags(tags []string) []string {
+	if len(tags) > maxSuggestedTags {
+		return tags[:maxSuggestedTags]
+	}
+	return tags
+}
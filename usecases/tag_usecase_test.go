@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"blog-api/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTagUseCase_SuggestTags(t *testing.T) {
+	vocabulary := []models.Tag{
+		{Name: "go", Aliases: []string{"golang", "go-lang"}},
+		{Name: "mongodb", Aliases: []string{"mongo"}},
+	}
+	title := "Golang and Mongo"
+	content := "Goroutines talk to Mongo through the driver. Goroutines are cheap; the driver pools connections."
+
+	tests := []struct {
+		name         string
+		title        string
+		setupMocks   func(*mocks.TagRepositoryMock, *mocks.AIProviderMock)
+		expectError  bool
+		expectedTags models.TagSuggestion
+	}{
+		{
+			name:  "Success - AI tags mapped onto the vocabulary",
+			title: title,
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, provider *mocks.AIProviderMock) {
+				tagRepo.On("GetTags").Return(vocabulary, nil)
+				provider.On("Generate", mock.Anything, models.AIGenerationRequest{
+					Type:     models.AISuggestionTypeTags,
+					Keywords: []string{"go", "mongodb"},
+					Title:    title,
+					Content:  content,
+				}).Return(models.AIGeneration{Type: models.AISuggestionTypeTags, Tags: []string{"Golang", "#go", "Databases", "mongo"}}, nil)
+			},
+			expectedTags: models.TagSuggestion{Tags: []string{"go", "databases", "mongodb"}, Source: models.TagSuggestionSourceAI},
+		},
+		{
+			name:  "Success - Keyword extraction when the AI fails",
+			title: title,
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, provider *mocks.AIProviderMock) {
+				tagRepo.On("GetTags").Return(vocabulary, nil)
+				provider.On("Generate", mock.Anything, mock.Anything).Return(models.AIGeneration{}, errors.New("AI service request failed"))
+			},
+			expectedTags: models.TagSuggestion{Tags: []string{"go", "mongodb", "goroutines", "driver", "talk"}, Source: models.TagSuggestionSourceKeywords},
+		},
+		{
+			name:  "Success - Keyword extraction when the AI suggests nothing",
+			title: "",
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, provider *mocks.AIProviderMock) {
+				tagRepo.On("GetTags").Return([]models.Tag{}, nil)
+				provider.On("Generate", mock.Anything, mock.Anything).Return(models.AIGeneration{Type: models.AISuggestionTypeTags}, nil)
+			},
+			expectedTags: models.TagSuggestion{Tags: []string{"goroutines", "driver", "talk", "mongo", "cheap"}, Source: models.TagSuggestionSourceKeywords},
+		},
+		{
+			name:  "Error - Vocabulary unavailable",
+			title: title,
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, provider *mocks.AIProviderMock) {
+				tagRepo.On("GetTags").Return(nil, errors.New("database error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagRepo := &mocks.TagRepositoryMock{}
+			provider := &mocks.AIProviderMock{}
+			tt.setupMocks(tagRepo, provider)
+
+			useCase := NewTagUseCase(tagRepo, &mocks.BlogRepositoryMock{}, provider)
+			result, err := useCase.SuggestTags(context.Background(), tt.title, content)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTags, result)
+			}
+
+			tagRepo.AssertExpectations(t)
+			provider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagUseCase_SuggestTags_RequiresText(t *testing.T) {
+	useCase := NewTagUseCase(&mocks.TagRepositoryMock{}, &mocks.BlogRepositoryMock{}, &mocks.AIProviderMock{})
+
+	_, err := useCase.SuggestTags(context.Background(), " ", "")
+	assert.Error(t, err)
+}
+
+func TestTagUseCase_MergeTags(t *testing.T) {
+	goTag := models.Tag{Name: "go", Aliases: []string{"golang"}}
+
+	tests := []struct {
+		name           string
+		sources        []string
+		target         string
+		setupMocks     func(*mocks.TagRepositoryMock, *mocks.BlogRepositoryMock)
+		expectError    bool
+		expectedResult models.TagMergeResult
+	}{
+		{
+			name:    "Success - Sources become aliases",
+			sources: []string{"Golang", " go-lang ", "go"},
+			target:  "Go",
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, blogRepo *mocks.BlogRepositoryMock) {
+				tagRepo.On("FindTags", []string{"go"}).Return([]models.Tag{goTag}, nil)
+				merged := models.Tag{Name: "go", Aliases: []string{"golang", "go-lang"}}
+				tagRepo.On("MergeTags", []string{"golang", "go-lang"}, "go").Return(merged, nil)
+				blogRepo.On("ReplaceTags", []string{"golang", "go-lang"}, "go").Return(int64(7), nil)
+			},
+			expectedResult: models.TagMergeResult{Tag: models.Tag{Name: "go", Aliases: []string{"golang", "go-lang"}}, BlogsUpdated: 7},
+		},
+		{
+			name:    "Success - An alias as target resolves to its canonical tag",
+			sources: []string{"go-lang"},
+			target:  "golang",
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, blogRepo *mocks.BlogRepositoryMock) {
+				tagRepo.On("FindTags", []string{"golang"}).Return([]models.Tag{goTag}, nil)
+				tagRepo.On("MergeTags", []string{"go-lang"}, "go").Return(goTag, nil)
+				blogRepo.On("ReplaceTags", []string{"go-lang"}, "go").Return(int64(1), nil)
+			},
+			expectedResult: models.TagMergeResult{Tag: goTag, BlogsUpdated: 1},
+		},
+		{
+			name:    "Success - Merging a tag into itself tidies its spellings",
+			sources: []string{"Go"},
+			target:  "go",
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, blogRepo *mocks.BlogRepositoryMock) {
+				tagRepo.On("FindTags", []string{"go"}).Return([]models.Tag{goTag}, nil)
+				blogRepo.On("ReplaceTags", []string(nil), "go").Return(int64(3), nil)
+			},
+			expectedResult: models.TagMergeResult{Tag: goTag, BlogsUpdated: 3},
+		},
+		{
+			name:        "Error - Empty target",
+			sources:     []string{"golang"},
+			target:      " # ",
+			setupMocks:  func(tagRepo *mocks.TagRepositoryMock, blogRepo *mocks.BlogRepositoryMock) {},
+			expectError: true,
+		},
+		{
+			name:    "Error - Rewriting blogs fails",
+			sources: []string{"golang"},
+			target:  "go",
+			setupMocks: func(tagRepo *mocks.TagRepositoryMock, blogRepo *mocks.BlogRepositoryMock) {
+				tagRepo.On("FindTags", []string{"go"}).Return([]models.Tag{}, nil)
+				tagRepo.On("MergeTags", []string{"golang"}, "go").Return(goTag, nil)
+				blogRepo.On("ReplaceTags", []string{"golang"}, "go").Return(int64(0), errors.New("database error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagRepo := &mocks.TagRepositoryMock{}
+			blogRepo := &mocks.BlogRepositoryMock{}
+			tt.setupMocks(tagRepo, blogRepo)
+
+			useCase := NewTagUseCase(tagRepo, blogRepo, &mocks.AIProviderMock{})
+			result, err := useCase.MergeTags(tt.sources, tt.target)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			tagRepo.AssertExpectations(t)
+			blogRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFrequentWords(t *testing.T) {
+	vocabulary := []models.Tag{{Name: "k8s", Aliases: []string{"kb"}}}
+
+	words := frequentWords(tagWords("Go, AI and UX"), tagWords("An ML kb is on my PC"), vocabulary)
+
+	assert.Equal(t, []string{"go", "ai", "ux", "ml", "kb"}, words)
+}
+
+func TestCanonicalTags(t *testing.T) {
+	tagRepo := &mocks.TagRepositoryMock{}
+	tagRepo.On("FindTags", []string{"go", "golang", "web dev", "go-lang"}).Return([]models.Tag{
+		{Name: "go", Aliases: []string{"golang", "go-lang"}},
+	}, nil)
+
+	tags, err := canonicalTags(tagRepo, []string{"Go", "golang", " #Web   Dev", "", "GO-LANG"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "web dev"}, tags)
+	tagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"blog-api/Domain/interfaces"
+	"blog-api/Domain/models"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxSuggestedTags caps how many tags are suggested for a post
+const maxSuggestedTags = 5
+
+// titleWordWeight is how many content occurrences a title word counts as
+const titleWordWeight = 3
+
+// tagStopWords are common words that never make useful tags
+var tagStopWords = map[string]bool{
+	"about": true, "after": true, "again": true, "all": true, "also": true, "and": true, "any": true,
+	"are": true, "because": true, "been": true, "before": true, "being": true, "but": true, "can": true,
+	"could": true, "did": true, "does": true, "doing": true, "each": true, "few": true, "for": true,
+	"from": true, "get": true, "had": true, "has": true, "have": true, "her": true, "here": true,
+	"his": true, "how": true, "into": true, "its": true, "just": true, "like": true, "make": true,
+	"more": true, "most": true, "much": true, "not": true, "now": true, "off": true, "one": true,
+	"only": true, "other": true, "our": true, "out": true, "over": true, "own": true, "same": true,
+	"she": true, "should": true, "some": true, "such": true, "than": true, "that": true, "the": true,
+	"their": true, "them": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"those": true, "through": true, "too": true, "under": true, "use": true, "using": true, "very": true,
+	"was": true, "way": true, "were": true, "what": true, "when": true, "where": true, "which": true,
+	"while": true, "who": true, "why": true, "will": true, "with": true, "would": true, "you": true,
+	"your": true,
+}
+
+// knownShortTags are tags under three letters that are still worth suggesting; the vocabulary's
+// own names and aliases are kept whatever their length too
+var knownShortTags = map[string]bool{
+	"ai": true, "ar": true, "c": true, "cd": true, "ci": true, "db": true, "go": true, "js": true,
+	"ml": true, "os": true, "qa": true, "r": true, "ts": true, "ui": true, "ux": true, "vr": true,
+}
+
+// normalizeTag lower-cases a tag, drops a leading "#" and collapses inner whitespace
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.TrimLeft(strings.TrimSpace(tag), "#")), " "))
+}
+
+// normalizeTags normalizes every tag, dropping empty ones and duplicates but keeping the order
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// canonicalize maps normalized tags that are aliases in the vocabulary to their canonical tag,
+// dropping the duplicates that leaves
+func canonicalize(tags []string, vocabulary []models.Tag) []string {
+	canonical := make(map[string]string)
+	for _, tag := range vocabulary {
+		canonical[tag.Name] = tag.Name
+		for _, alias := range tag.Aliases {
+			canonical[alias] = tag.Name
+		}
+	}
+
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if name, ok := canonical[tag]; ok {
+			tag = name
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// canonicalTags normalizes free-form tags and resolves aliases through the vocabulary. Blogs
+// without tags are left as they are.
+func canonicalTags(tagRepo interfaces.TagRepository, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	normalized := normalizeTags(tags)
+	vocabulary, err := tagRepo.FindTags(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return canonicalize(normalized, vocabulary), nil
+}
+
+// tagWords splits text into lower-case words, keeping the "+", "#" and "-" of names like
+// "c++", "c#" and "go-lang"
+func tagWords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' && r != '-'
+	})
+
+	cleaned := words[:0]
+	for _, word := range words {
+		if word = strings.Trim(word, "-"); word != "" {
+			cleaned = append(cleaned, word)
+		}
+	}
+	return cleaned
+}
+
+// mentionedTags returns the vocabulary tags whose name or an alias appears as whole words in
+// the text, in vocabulary order
+func mentionedTags(words []string, vocabulary []models.Tag) []string {
+	text := " " + strings.Join(words, " ") + " "
+
+	mentioned := []string{}
+	for _, tag := range vocabulary {
+		for _, name := range append([]string{tag.Name}, tag.Aliases...) {
+			if strings.Contains(text, " "+name+" ") {
+				mentioned = append(mentioned, tag.Name)
+				break
+			}
+		}
+	}
+
+	return mentioned
+}
+
+// frequentWords ranks the words of a post by how often they occur, title words counting
+// titleWordWeight times. Stop words and words under three letters are skipped, unless they are
+// known tags or in the vocabulary; ties keep the order words first appear in.
+func frequentWords(titleWords, contentWords []string, vocabulary []models.Tag) []string {
+	known := make(map[string]bool)
+	for _, tag := range vocabulary {
+		known[tag.Name] = true
+		for _, alias := range tag.Aliases {
+			known[alias] = true
+		}
+	}
+
+	counts := make(map[string]int)
+	var order []string
+
+	add := func(words []string, weight int) {
+		for _, word := range words {
+			short := len([]rune(word)) < 3 && !knownShortTags[word] && !known[word]
+			if short || tagStopWords[word] || isNumber(word) {
+				continue
+			}
+			if counts[word] == 0 {
+				order = append(order, word)
+			}
+			counts[word] += weight
+		}
+	}
+	add(titleWords, titleWordWeight)
+	add(contentWords, 1)
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]] > counts[order[j]]
+	})
+
+	return order
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
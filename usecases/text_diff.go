@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"strings"
+)
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+// maxDiffCells bounds the LCS table diffLines builds, so two long texts cannot take quadratic
+// memory and time. Past it the differing lines are shown replaced as a whole.
+const maxDiffCells = 1 << 20
+
+// diffLines computes a line-level diff using the longest common subsequence of the two texts.
+// Lines shared at the start and end are matched first, so edits within a long text stay cheap.
+func diffLines(from, to []string) []models.DiffLine {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]models.DiffLine, 0, len(from)+len(to))
+	for _, line := range from[:prefix] {
+		lines = append(lines, models.DiffLine{Op: models.DiffEqual, Text: line})
+	}
+	lines = append(lines, diffMiddle(from[prefix:len(from)-suffix], to[prefix:len(to)-suffix])...)
+	for _, line := range from[len(from)-suffix:] {
+		lines = append(lines, models.DiffLine{Op: models.DiffEqual, Text: line})
+	}
+
+	return lines
+}
+
+// diffMiddle diffs the lines between the common prefix and suffix
+func diffMiddle(from, to []string) []models.DiffLine {
+	lines := make([]models.DiffLine, 0, len(from)+len(to))
+	if (len(from)+1)*(len(to)+1) > maxDiffCells {
+		for _, line := range from {
+			lines = append(lines, models.DiffLine{Op: models.DiffDelete, Text: line})
+		}
+		for _, line := range to {
+			lines = append(lines, models.DiffLine{Op: models.DiffInsert, Text: line})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the LCS length of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, models.DiffLine{Op: models.DiffEqual, Text: from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, models.DiffLine{Op: models.DiffDelete, Text: from[i]})
+			i++
+		default:
+			lines = append(lines, models.DiffLine{Op: models.DiffInsert, Text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, models.DiffLine{Op: models.DiffDelete, Text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, models.DiffLine{Op: models.DiffInsert, Text: to[j]})
+	}
+
+	return lines
+}
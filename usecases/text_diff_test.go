@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"blog-api/Domain/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     []string
+		to       []string
+		expected []models.DiffLine
+	}{
+		{
+			name: "Changed middle line",
+			from: []string{"intro", "old", "outro"},
+			to:   []string{"intro", "new", "outro"},
+			expected: []models.DiffLine{
+				{Op: models.DiffEqual, Text: "intro"},
+				{Op: models.DiffDelete, Text: "old"},
+				{Op: models.DiffInsert, Text: "new"},
+				{Op: models.DiffEqual, Text: "outro"},
+			},
+		},
+		{
+			name: "Line kept between edits",
+			from: []string{"a", "keep", "b"},
+			to:   []string{"c", "keep", "d"},
+			expected: []models.DiffLine{
+				{Op: models.DiffDelete, Text: "a"},
+				{Op: models.DiffInsert, Text: "c"},
+				{Op: models.DiffEqual, Text: "keep"},
+				{Op: models.DiffDelete, Text: "b"},
+				{Op: models.DiffInsert, Text: "d"},
+			},
+		},
+		{
+			name:     "Appended to empty",
+			from:     []string{},
+			to:       []string{"first"},
+			expected: []models.DiffLine{{Op: models.DiffInsert, Text: "first"}},
+		},
+		{
+			name:     "Identical",
+			from:     []string{"same"},
+			to:       []string{"same"},
+			expected: []models.DiffLine{{Op: models.DiffEqual, Text: "same"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffLines(tt.from, tt.to))
+		})
+	}
+}
+
+func TestDiffLines_LargeTextsFallBackToReplace(t *testing.T) {
+	from := make([]string, 0, 2002)
+	to := make([]string, 0, 2002)
+	from = append(from, "intro")
+	to = append(to, "intro")
+	for i := 0; i < 2000; i++ {
+		from = append(from, fmt.Sprintf("old %d", i))
+		to = append(to, fmt.Sprintf("new %d", i))
+	}
+	from = append(from, "outro")
+	to = append(to, "outro")
+
+	lines := diffLines(from, to)
+
+	assert.Len(t, lines, 4002)
+	assert.Equal(t, models.DiffLine{Op: models.DiffEqual, Text: "intro"}, lines[0])
+	assert.Equal(t, models.DiffLine{Op: models.DiffDelete, Text: "old 0"}, lines[1])
+	assert.Equal(t, models.DiffLine{Op: models.DiffInsert, Text: "new 0"}, lines[2001])
+	assert.Equal(t, models.DiffLine{Op: models.DiffEqual, Text: "outro"}, lines[4001])
+}
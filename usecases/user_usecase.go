@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"net/mail"
+	"sync"
 	"time"
 )
 
@@ -16,35 +17,55 @@ func isValidEmail(email string) bool {
 	return err == nil
 }
 
+// errInvalidCredentials answers a missing account and a wrong password alike
+var errInvalidCredentials = errors.New("invalid email or password")
+
+// suspendedError tells a suspended user until when
+func suspendedError(user models.User) error {
+	return fmt.Errorf("account suspended until %s", user.SuspendedUntil.UTC().Format(time.RFC3339))
+}
+
 type OutPutToken struct {
 	Access_token  string
 	Refresh_token string
+	// MFA_token is set instead of the others when the login still needs a second factor
+	MFA_token string `json:"-"`
 }
 
 type UserUsecaseInterface interface {
 	Register(user models.User) error
-	Login(user models.User) (OutPutToken, error)
+	Login(user models.User, client models.ClientInfo) (OutPutToken, error)
+	VerifyMFA(mfaToken, code string, client models.ClientInfo) (OutPutToken, error)
 	Promote(email string) error
 	Demote(email string) error
-	RefreshToken(token string) (string, error)
+	RefreshToken(token string, client models.ClientInfo) (OutPutToken, error)
 	Logout(refresh_token string) error
 	VerifyEmail(tokenStr string) error
-	RequestPasswordReset(email string) error
+	RequestPasswordReset(email string, client models.ClientInfo) error
 	ResetPassword(resetToken, newPassword string) error
 	UpdateProfile(ctx context.Context, id string, user models.User) (models.User, error)
 	GetProfile(ctx context.Context, id string) (models.User, error)
+	UnlockAccount(ctx context.Context, id string) error
 }
 
 type userUsecase struct {
-	repo         interfaces.UserRepository
-	hasher       interfaces.Hasher
-	tokenService interfaces.TokenService
-	tokenRepo    interfaces.TokenRepository
-	emailService interfaces.EmailService
+	repo          interfaces.UserRepository
+	hasher        interfaces.Hasher
+	tokenService  interfaces.TokenService
+	tokenRepo     interfaces.TokenRepository
+	emailService  interfaces.EmailService
+	tokenVersions interfaces.TokenVersionStore
+	loginThrottle interfaces.LoginThrottle
+	otp           interfaces.OTPService
+
+	// dummyHash is checked against for unknown emails, so they take as long to refuse as a wrong
+	// password
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
-func NewUserUsecase(repo interfaces.UserRepository, hasher interfaces.Hasher, tokenService interfaces.TokenService, tokenRepo interfaces.TokenRepository, emailService interfaces.EmailService) *userUsecase {
-	return &userUsecase{repo: repo, hasher: hasher, tokenService: tokenService, tokenRepo: tokenRepo, emailService: emailService}
+func NewUserUsecase(repo interfaces.UserRepository, hasher interfaces.Hasher, tokenService interfaces.TokenService, tokenRepo interfaces.TokenRepository, emailService interfaces.EmailService, tokenVersions interfaces.TokenVersionStore, loginThrottle interfaces.LoginThrottle, otp interfaces.OTPService) *userUsecase {
+	return &userUsecase{repo: repo, hasher: hasher, tokenService: tokenService, tokenRepo: tokenRepo, emailService: emailService, tokenVersions: tokenVersions, loginThrottle: loginThrottle, otp: otp}
 }
 
 func (uc *userUsecase) Logout(refreshToken string) error {
@@ -73,16 +94,22 @@ func (uc *userUsecase) Logout(refreshToken string) error {
 	if !uc.tokenService.VerifyToken(dbToken.Token, refreshToken) {
 		return errors.New("invalid refresh token")
 	}
+	if dbToken.RotatedAt != nil {
+		return uc.revokeFamily(dbToken)
+	}
 
 	// 5️⃣ Delete token from DB (logout)
 	return uc.tokenRepo.DeleteToken(dbToken.ID)
 }
 
-func (uc *userUsecase) RefreshToken(tokenStr string) (string, error) {
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token in the
+// same family. The old one stops working; if it is ever presented again it must have leaked, so
+// the whole family is revoked and the user has to log in again.
+func (uc *userUsecase) RefreshToken(tokenStr string, client models.ClientInfo) (OutPutToken, error) {
 	// 1️⃣ Verify JWT refresh token (signature, format)
 	token, err := uc.tokenService.VerifyRefreshToken(tokenStr)
 	if err != nil {
-		return "", err
+		return OutPutToken{}, err
 	}
 
 	// 2️⃣ Expiry check
@@ -91,30 +118,93 @@ func (uc *userUsecase) RefreshToken(tokenStr string) (string, error) {
 			// optional: log error, but don't return it
 			log.Printf("failed to delete expired token: %v", err)
 		}
-		return "", errors.New("the refresh token expired")
+		return OutPutToken{}, errors.New("the refresh token expired")
 	}
 
 	// 3️⃣ Fetch token from DB
 	dbToken, err := uc.tokenRepo.GetToken(token.TokenID)
 	if err != nil {
-		return "", err
+		return OutPutToken{}, err
 	}
 	if dbToken == nil {
-		return "", errors.New("refresh token not found")
+		return OutPutToken{}, errors.New("refresh token not found")
 	}
 
 	// 4️⃣ Verify stored hashed token matches the raw token string
 	if !uc.tokenService.VerifyToken(dbToken.Token, tokenStr) {
-		return "", errors.New("invalid refresh token")
+		return OutPutToken{}, errors.New("invalid refresh token")
+	}
+
+	// 5️⃣ A token that was already exchanged is being replayed
+	if dbToken.RotatedAt != nil {
+		return OutPutToken{}, uc.revokeFamily(dbToken)
+	}
+
+	// 6️⃣ Suspended users are not let back in
+	user, err := uc.repo.GetUserByID(context.TODO(), token.UserID)
+	if err != nil {
+		return OutPutToken{}, errors.New("user not found")
+	}
+	if user.IsSuspended(time.Now()) {
+		return OutPutToken{}, suspendedError(user)
 	}
 
-	// 6️⃣ Generate new access token
-	accessToken, err := uc.tokenService.GenerateAccessToken(token.UserID, token.Email, token.Role)
+	// 7️⃣ Invalidate the old token; losing a race with another refresh counts as reuse
+	rotated, err := uc.tokenRepo.RotateToken(dbToken.ID)
 	if err != nil {
-		return "", err
+		return OutPutToken{}, err
+	}
+	if !rotated {
+		return OutPutToken{}, uc.revokeFamily(dbToken)
+	}
+
+	// 8️⃣ Issue new tokens with the user's current role
+	// Once MFA is on, every session of the user was started with it
+	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Email, user.Role, user.TokenVersion, user.MFAEnabled)
+	if err != nil {
+		return OutPutToken{}, err
+	}
+	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return OutPutToken{}, err
+	}
+	refreshTokenStr := refreshToken.Token
+	refreshToken.Token = uc.tokenService.HashToken(refreshTokenStr)
+	refreshToken.FamilyID = tokenFamily(dbToken)
+	refreshToken.LoginAt = dbToken.LoginAt
+	if refreshToken.LoginAt.IsZero() {
+		refreshToken.LoginAt = dbToken.CreatedAt
+	}
+	recordClient(refreshToken, client)
+	if err := uc.tokenRepo.CreateToken(refreshToken); err != nil {
+		return OutPutToken{}, err
 	}
-	return accessToken, nil
 
+	return OutPutToken{Access_token: accessToken, Refresh_token: refreshTokenStr}, nil
+}
+
+// revokeFamily deletes every refresh token descended from the same login as the replayed one
+func (uc *userUsecase) revokeFamily(token *models.Token) error {
+	if err := uc.tokenRepo.DeleteTokenFamily(tokenFamily(token)); err != nil {
+		log.Printf("Failed to revoke token family %s: %v", tokenFamily(token), err)
+	}
+	return errors.New("refresh token reuse detected, please log in again")
+}
+
+// recordClient notes on a refresh token where and when it was issued, for the session list
+func recordClient(token *models.Token, client models.ClientInfo) {
+	token.IP = client.IP
+	token.Device = client.UserAgent
+	token.LastUsedAt = time.Now()
+}
+
+// tokenFamily returns the family of a refresh token. Tokens issued before rotation have none and
+// start their own.
+func tokenFamily(token *models.Token) string {
+	if token.FamilyID != "" {
+		return token.FamilyID
+	}
+	return token.ID
 }
 
 func (uc *userUsecase) Register(user models.User) error {
@@ -181,33 +271,110 @@ func (uc *userUsecase) Register(user models.User) error {
 
 }
 
-func (uc *userUsecase) Login(user models.User) (OutPutToken, error) {
+func (uc *userUsecase) Login(user models.User, client models.ClientInfo) (OutPutToken, error) {
+	// Counted before the password is checked and cleared once it matches
+	if err := uc.loginThrottle.AttemptLogin(user.Email, client.IP); err != nil {
+		return OutPutToken{}, err
+	}
+
 	existing_user, err := uc.repo.FindByEmail(user.Email)
 	if err != nil {
-		return OutPutToken{}, errors.New("user not found")
+		uc.hasher.VerifyPassword(uc.dummyPasswordHash(), user.Password)
+		return OutPutToken{}, errInvalidCredentials
+	}
+	if !uc.hasher.VerifyPassword(existing_user.Password, user.Password) {
+		return OutPutToken{}, errInvalidCredentials
+	}
+
+	// The password alone is not enough; with MFA on, the attempt stays counted until the second factor
+	if !existing_user.MFAEnabled {
+		uc.loginSucceeded(existing_user.Email, client.IP)
 	}
 
+	// Only told to whoever knows the password
 	if !existing_user.Verified {
 		return OutPutToken{}, errors.New("user not verified")
 	}
 
-	if !uc.hasher.VerifyPassword(existing_user.Password, user.Password) {
-		return OutPutToken{}, errors.New("incorrect password")
+	if existing_user.IsSuspended(time.Now()) {
+		return OutPutToken{}, suspendedError(*existing_user)
+	}
+
+	if existing_user.MFAEnabled {
+		mfaToken, err := uc.tokenService.GenerateMFAToken(existing_user.ID)
+		if err != nil {
+			return OutPutToken{}, err
+		}
+		return OutPutToken{MFA_token: mfaToken}, nil
+	}
+
+	return uc.startSession(*existing_user, client)
+}
+
+// VerifyMFA finishes a login with a code from the user's authenticator or a recovery code
+func (uc *userUsecase) VerifyMFA(mfaToken, code string, client models.ClientInfo) (OutPutToken, error) {
+	userID, err := uc.tokenService.VerifyMFAToken(mfaToken)
+	if err != nil {
+		return OutPutToken{}, errors.New("invalid or expired MFA token")
 	}
+	user, err := uc.repo.GetUserByID(context.TODO(), userID)
+	if err != nil || !user.MFAEnabled {
+		return OutPutToken{}, errors.New("invalid or expired MFA token")
+	}
+
+	// Codes are guessed no faster than passwords: each one is counted before it is checked
+	if err := uc.loginThrottle.AttemptLogin(user.Email, client.IP); err != nil {
+		return OutPutToken{}, err
+	}
+	if err := verifySecondFactor(context.TODO(), uc.repo, uc.otp, user, code); err != nil {
+		return OutPutToken{}, err
+	}
+	uc.loginSucceeded(user.Email, client.IP)
+
+	if user.IsSuspended(time.Now()) {
+		return OutPutToken{}, suspendedError(user)
+	}
+
+	return uc.startSession(user, client)
+}
+
+// dummyPasswordHash hashes a throwaway password once, at the hasher's usual cost
+func (uc *userUsecase) dummyPasswordHash() string {
+	uc.dummyHashOnce.Do(func() {
+		hash, err := uc.hasher.HashPassword("not the password of any account")
+		if err != nil {
+			log.Printf("Failed to hash the password for unknown emails: %v", err)
+			return
+		}
+		uc.dummyHash = hash
+	})
+	return uc.dummyHash
+}
 
-	access_token, err := uc.tokenService.GenerateAccessToken(existing_user.ID, existing_user.Email, existing_user.Role)
+func (uc *userUsecase) loginSucceeded(email, ip string) {
+	if err := uc.loginThrottle.LoginSucceeded(email, ip); err != nil {
+		log.Printf("Failed to clear failed logins for %s: %v", email, err)
+	}
+}
+
+// startSession issues the tokens of a new login
+func (uc *userUsecase) startSession(user models.User, client models.ClientInfo) (OutPutToken, error) {
+	access_token, err := uc.tokenService.GenerateAccessToken(user.ID, user.Email, user.Role, user.TokenVersion, user.MFAEnabled)
 	if err != nil {
 		return OutPutToken{}, err
 	}
-	refresh_token, err := uc.tokenService.GenerateRefreshToken(existing_user.ID, existing_user.Email, existing_user.Role)
+	refresh_token, err := uc.tokenService.GenerateRefreshToken(user.ID, user.Email, user.Role)
 	if err != nil {
 		return OutPutToken{}, err
 	}
 	refresh_tokenStr := refresh_token.Token
 	refresh_token.Token = uc.tokenService.HashToken(refresh_token.Token)
+	refresh_token.FamilyID = refresh_token.ID
+	refresh_token.LoginAt = time.Now()
+	recordClient(refresh_token, client)
 	uc.tokenRepo.CreateToken(refresh_token)
 
-	return OutPutToken{access_token, refresh_tokenStr}, err
+	return OutPutToken{Access_token: access_token, Refresh_token: refresh_tokenStr}, err
 }
 
 func (uc *userUsecase) Promote(email string) error {
@@ -218,8 +385,12 @@ func (uc *userUsecase) Promote(email string) error {
 	if user.Role == "superadmin" {
 		return errors.New("superadmin cannot be promoted")
 	}
-	err = uc.repo.UpdateRole(email, "admin")
-	return err
+	if err := uc.repo.UpdateRole(email, "admin"); err != nil {
+		return err
+	}
+
+	// Access tokens carry the role, so the old ones must go
+	return uc.tokenVersions.Bump(context.TODO(), user.ID)
 }
 
 func (uc *userUsecase) Demote(email string) error {
@@ -230,8 +401,12 @@ func (uc *userUsecase) Demote(email string) error {
 	if user.Role == "superadmin" {
 		return errors.New("superadmin cannot be demoted")
 	}
-	err = uc.repo.UpdateRole(email, "user")
-	return err
+	if err := uc.repo.UpdateRole(email, "user"); err != nil {
+		return err
+	}
+
+	// Access tokens carry the role, so the old ones must go
+	return uc.tokenVersions.Bump(context.TODO(), user.ID)
 }
 
 func (uc *userUsecase) VerifyEmail(tokenStr string) error {
@@ -256,14 +431,31 @@ func (uc *userUsecase) VerifyEmail(tokenStr string) error {
 	return err
 }
 
-func (uc *userUsecase) RequestPasswordReset(email string) error {
-	// 1️⃣ Find the user
+func (uc *userUsecase) RequestPasswordReset(email string, client models.ClientInfo) error {
+	// 1️⃣ Throttle, counting unknown addresses too
+	if err := uc.loginThrottle.AllowPasswordReset(email, client.IP); err != nil {
+		return err
+	}
+
+	// 2️⃣ Find the user; an unknown address gets the same answer as a known one
 	user, err := uc.repo.FindByEmail(email)
 	if err != nil {
-		return errors.New("user not found")
+		return nil
 	}
 
-	// 2️⃣ Generate token (reuse tokenService)
+	// 3️⃣ Send in the background, so a known address answers as fast as an unknown one and a
+	// failure does not give it away either
+	go func() {
+		if err := uc.sendPasswordReset(*user); err != nil {
+			log.Printf("Failed to send password reset email to %s: %v", user.Email, err)
+		}
+	}()
+
+	return nil
+}
+
+func (uc *userUsecase) sendPasswordReset(user models.User) error {
+	// Generate token (reuse tokenService)
 	exp := time.Minute * 15
 	resetToken, err := uc.tokenService.GenerateRandomJWT(exp)
 	if err != nil {
@@ -273,13 +465,12 @@ func (uc *userUsecase) RequestPasswordReset(email string) error {
 	resetToken.Token = uc.tokenService.HashToken(tokenStr)
 	resetToken.Email = user.Email
 
-	// 3️⃣ Store in DB as a token entity
+	// Store in DB as a token entity
 	if err := uc.tokenRepo.CreateToken(resetToken); err != nil {
 		return err
 	}
 
 	return uc.emailService.SendPasswordResetEmail(user.Username, user.Email, tokenStr)
-
 }
 
 func (uc *userUsecase) ResetPassword(resetToken, newPassword string) error {
@@ -311,5 +502,27 @@ func (uc *userUsecase) ResetPassword(resetToken, newPassword string) error {
 	}
 
 	// 4️⃣ Delete token after use
-	return uc.tokenRepo.DeleteToken(db_token.ID)
+	if err := uc.tokenRepo.DeleteToken(db_token.ID); err != nil {
+		return err
+	}
+
+	// 5️⃣ Whoever knew the old password is signed out everywhere
+	user, err := uc.repo.FindByEmail(db_token.Email)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.tokenRepo.DeleteUserTokens(user.ID, ""); err != nil {
+		return err
+	}
+	return uc.tokenVersions.Bump(context.TODO(), user.ID)
+}
+
+// UnlockAccount clears the failed logins that locked the account
+func (uc *userUsecase) UnlockAccount(ctx context.Context, id string) error {
+	user, err := uc.repo.GetUserByID(ctx, id)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	return uc.loginThrottle.UnlockAccount(user.Email)
 }
@@ -3,6 +3,7 @@ package usecases
 import (
 	"blog-api/Domain/models"
 	"blog-api/mocks" // generated mocks
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -17,11 +18,22 @@ func setup() (*mocks.UserRepository, *mocks.MockHasher, *mocks.MockTokenService,
 	tokenSvc := new(mocks.MockTokenService)
 	tokenRepo := new(mocks.MockTokenRepository)
 	emailService := new(mocks.MockEmailService)
+	tokenVersions := new(mocks.TokenVersionStoreMock)
+	tokenVersions.On("Bump", mock.Anything, mock.Anything).Return(nil).Maybe()
 
-	uc := NewUserUsecase(repo, hasher, tokenSvc, tokenRepo, emailService)
+	uc := NewUserUsecase(repo, hasher, tokenSvc, tokenRepo, emailService, tokenVersions, openLoginThrottle(), new(mocks.OTPServiceMock))
 	return repo, hasher, tokenSvc, tokenRepo, emailService, uc
 }
 
+// openLoginThrottle never throttles
+func openLoginThrottle() *mocks.LoginThrottleMock {
+	throttle := new(mocks.LoginThrottleMock)
+	throttle.On("AttemptLogin", mock.Anything, mock.Anything).Return(nil).Maybe()
+	throttle.On("LoginSucceeded", mock.Anything, mock.Anything).Return(nil).Maybe()
+	throttle.On("AllowPasswordReset", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return throttle
+}
+
 func TestRegister_EmailSuccess_UserSaved(t *testing.T) {
 	repo, hasher, tokenSvc, tokenRepo, emailService, uc := setup()
 
@@ -60,19 +72,21 @@ func TestRegister_EmailSuccess_UserSaved(t *testing.T) {
 }
 
 func TestLogin_UserNotVerified(t *testing.T) {
-	repo, _, _, _, _, uc := setup()
+	repo, hasher, _, _, _, uc := setup()
 
 	existing := models.User{
 		Email:    "john@example.com",
+		Password: "hashed_password",
 		Verified: false,
 	}
 
 	repo.On("FindByEmail", "john@example.com").Return(existing, nil)
+	hasher.On("VerifyPassword", existing.Password, "password123").Return(true)
 
 	_, err := uc.Login(models.User{
 		Email:    "john@example.com",
 		Password: "password123",
-	})
+	}, models.ClientInfo{})
 
 	assert.EqualError(t, err, "user not verified")
 }
@@ -89,32 +103,66 @@ func TestLogin_success(t *testing.T) {
 
 	repo.On("FindByEmail", "john@example.com").Return(existing, nil)
 	mockhasher.On("VerifyPassword", existing.Password, "password123").Return(true)
-	token_service.On("GenerateAccessToken", "123456789", "john@example.com", "user").Return("access_token", nil)
+	token_service.On("GenerateAccessToken", "123456789", "john@example.com", "user", 0, false).Return("access_token", nil)
 	token_service.On("GenerateRefreshToken", "123456789", "john@example.com", "user").Return(&models.Token{Token: "refresh_token"}, nil)
 	token_service.On("HashToken", "refresh_token").Return("hashed_refresh_token")
-	tokenRepo.On("CreateToken", &models.Token{Token: "hashed_refresh_token"}).Return(nil)
+	tokenRepo.On("CreateToken", mock.MatchedBy(func(token *models.Token) bool {
+		return token.Token == "hashed_refresh_token" && token.IP == "203.0.113.7" && token.Device == "Firefox" && !token.LoginAt.IsZero()
+	})).Return(nil)
 
 	tokens, err := uc.Login(models.User{
 		Email:    "john@example.com",
 		Password: "password123",
-	})
+	}, models.ClientInfo{IP: "203.0.113.7", UserAgent: "Firefox"})
 
 	assert.NoError(t, err)
 	assert.Equal(t, "access_token", tokens.Access_token)
 	assert.Equal(t, "refresh_token", tokens.Refresh_token)
 }
 
-func TestLogin_UserNotFound(t *testing.T) {
-	repo, _, _, _, _, uc := setup()
+func TestLogin_Suspended(t *testing.T) {
+	repo, hasher, token_service, _, _, uc := setup()
 
-	repo.On("FindByEmail", "john@example.com").Return(models.User{}, errors.New("User not found"))
+	until := time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC)
+	existing := models.User{
+		ID:             "123456789",
+		Email:          "john@example.com",
+		Verified:       true,
+		Password:       "hashed_password",
+		Role:           "user",
+		SuspendedUntil: &until,
+	}
+
+	repo.On("FindByEmail", "john@example.com").Return(existing, nil)
+	hasher.On("VerifyPassword", existing.Password, "password123").Return(true)
 
 	_, err := uc.Login(models.User{
 		Email:    "john@example.com",
 		Password: "password123",
-	})
+	}, models.ClientInfo{})
 
-	assert.EqualError(t, err, "user not found")
+	assert.EqualError(t, err, "account suspended until 2030-01-02T15:00:00Z")
+	token_service.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLogin_UserNotFound(t *testing.T) {
+	repo, hasher, _, _, _, uc := setup()
+
+	repo.On("FindByEmail", "john@example.com").Return(models.User{}, errors.New("User not found"))
+	hasher.On("HashPassword", mock.Anything).Return("dummy_hash", nil).Once()
+	hasher.On("VerifyPassword", "dummy_hash", "password123").Return(false)
+
+	for i := 0; i < 2; i++ {
+		_, err := uc.Login(models.User{
+			Email:    "john@example.com",
+			Password: "password123",
+		}, models.ClientInfo{})
+
+		assert.EqualError(t, err, "invalid email or password")
+	}
+	// An unknown email costs a password check like a known one; the throwaway hash is made once
+	hasher.AssertNumberOfCalls(t, "VerifyPassword", 2)
+	hasher.AssertNumberOfCalls(t, "HashPassword", 1)
 }
 func TestLogin_PasswordIncorrect(t *testing.T) {
 	repo, hasher, _, _, _, uc := setup()
@@ -133,9 +181,248 @@ func TestLogin_PasswordIncorrect(t *testing.T) {
 	_, err := uc.Login(models.User{
 		Email:    "john@example.com",
 		Password: "password123",
-	})
+	}, models.ClientInfo{})
+
+	assert.EqualError(t, err, "invalid email or password")
+}
+
+func TestLogin_Throttling(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		throttled   bool
+		userFound   bool
+		expectError string
+	}{
+		{name: "Error - Locked out", throttled: true, expectError: "too many attempts, try again later"},
+		{name: "Error - Unknown account stays counted", expectError: "invalid email or password"},
+		{name: "Error - Wrong password stays counted", userFound: true, expectError: "invalid email or password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.UserRepository)
+			hasher := new(mocks.MockHasher)
+			throttle := new(mocks.LoginThrottleMock)
+			uc := NewUserUsecase(repo, hasher, new(mocks.MockTokenService), new(mocks.MockTokenRepository), new(mocks.MockEmailService), new(mocks.TokenVersionStoreMock), throttle, new(mocks.OTPServiceMock))
+
+			if tt.throttled {
+				throttle.On("AttemptLogin", "john@example.com", "203.0.113.7").Return(&models.ThrottledError{RetryAt: retryAt})
+			} else {
+				throttle.On("AttemptLogin", "john@example.com", "203.0.113.7").Return(nil)
+			}
+			if tt.userFound {
+				repo.On("FindByEmail", "john@example.com").Return(models.User{Email: "john@example.com", Password: "hashed_password", Verified: true}, nil)
+				hasher.On("VerifyPassword", "hashed_password", "password123").Return(false)
+			} else {
+				repo.On("FindByEmail", "john@example.com").Return(models.User{}, errors.New("mongo: no documents in result"))
+				hasher.On("HashPassword", mock.Anything).Return("dummy_hash", nil)
+				hasher.On("VerifyPassword", "dummy_hash", "password123").Return(false)
+			}
+
+			_, err := uc.Login(models.User{Email: "john@example.com", Password: "password123"}, models.ClientInfo{IP: "203.0.113.7"})
+
+			assert.EqualError(t, err, tt.expectError)
+			if tt.throttled {
+				var throttledErr *models.ThrottledError
+				assert.ErrorAs(t, err, &throttledErr)
+				assert.Equal(t, retryAt, throttledErr.RetryAt)
+				repo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+			}
+			throttle.AssertExpectations(t)
+			throttle.AssertNotCalled(t, "LoginSucceeded", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestLogin_MFAChallenge(t *testing.T) {
+	repo, hasher, tokenSvc, tokenRepo, _, uc := setup()
+
+	existing := models.User{ID: "123456789", Email: "john@example.com", Verified: true, Password: "hashed_password", Role: "admin", MFAEnabled: true}
+	repo.On("FindByEmail", "john@example.com").Return(existing, nil)
+	hasher.On("VerifyPassword", existing.Password, "password123").Return(true)
+	tokenSvc.On("GenerateMFAToken", "123456789").Return("mfa.jwt.token", nil)
 
-	assert.EqualError(t, err, "incorrect password")
+	tokens, err := uc.Login(models.User{Email: "john@example.com", Password: "password123"}, models.ClientInfo{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, OutPutToken{MFA_token: "mfa.jwt.token"}, tokens)
+	tokenSvc.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	tokenRepo.AssertNotCalled(t, "CreateToken", mock.Anything)
+}
+
+func TestVerifyMFA(t *testing.T) {
+	user := models.User{ID: "123456789", Email: "john@example.com", Role: "admin", MFAEnabled: true, MFASecret: "SECRET", MFARecoverySalt: "salt"}
+
+	tests := []struct {
+		name        string
+		code        string
+		totpStep    int64
+		claimed     bool
+		recovery    bool
+		expectError string
+	}{
+		{name: "Success - Authenticator code", code: "123456", totpStep: 100, claimed: true},
+		{name: "Success - Recovery code", code: "ABCDE-FGHIJ", recovery: true},
+		{name: "Error - Code already used", code: "123456", totpStep: 100, claimed: false, expectError: "invalid verification code"},
+		{name: "Error - Wrong code", code: "000000", expectError: "invalid verification code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.UserRepository)
+			tokenSvc := new(mocks.MockTokenService)
+			tokenRepo := new(mocks.MockTokenRepository)
+			throttle := new(mocks.LoginThrottleMock)
+			otp := new(mocks.OTPServiceMock)
+			uc := NewUserUsecase(repo, new(mocks.MockHasher), tokenSvc, tokenRepo, new(mocks.MockEmailService), new(mocks.TokenVersionStoreMock), throttle, otp)
+
+			tokenSvc.On("VerifyMFAToken", "mfa.jwt.token").Return("123456789", nil)
+			repo.On("GetUserByID", mock.Anything, "123456789").Return(user, nil)
+			throttle.On("AttemptLogin", "john@example.com", "203.0.113.7").Return(nil)
+			if tt.totpStep != 0 {
+				otp.On("VerifyCode", "SECRET", tt.code).Return(tt.totpStep, true)
+				repo.On("ClaimMFAStep", mock.Anything, "123456789", tt.totpStep).Return(tt.claimed, nil)
+			} else {
+				otp.On("VerifyCode", "SECRET", tt.code).Return(int64(0), false)
+				otp.On("HashRecoveryCode", "salt", tt.code).Return("hashed_" + tt.code)
+				repo.On("UseRecoveryCode", mock.Anything, "123456789", "hashed_"+tt.code).Return(tt.recovery, nil)
+			}
+			if tt.expectError == "" {
+				throttle.On("LoginSucceeded", "john@example.com", "203.0.113.7").Return(nil)
+				tokenSvc.On("GenerateAccessToken", "123456789", "john@example.com", "admin", 0, true).Return("access_token", nil)
+				tokenSvc.On("GenerateRefreshToken", "123456789", "john@example.com", "admin").Return(&models.Token{ID: "token1", Token: "refresh_token"}, nil)
+				tokenSvc.On("HashToken", "refresh_token").Return("hashed_refresh_token")
+				tokenRepo.On("CreateToken", mock.MatchedBy(func(token *models.Token) bool {
+					return token.FamilyID == "token1" && token.IP == "203.0.113.7"
+				})).Return(nil)
+			}
+
+			tokens, err := uc.VerifyMFA("mfa.jwt.token", tt.code, models.ClientInfo{IP: "203.0.113.7"})
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				tokenSvc.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access_token", tokens.Access_token)
+				assert.Equal(t, "refresh_token", tokens.Refresh_token)
+			}
+			throttle.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVerifyMFA_Throttled(t *testing.T) {
+	repo := new(mocks.UserRepository)
+	tokenSvc := new(mocks.MockTokenService)
+	throttle := new(mocks.LoginThrottleMock)
+	otp := new(mocks.OTPServiceMock)
+	uc := NewUserUsecase(repo, new(mocks.MockHasher), tokenSvc, new(mocks.MockTokenRepository), new(mocks.MockEmailService), new(mocks.TokenVersionStoreMock), throttle, otp)
+
+	tokenSvc.On("VerifyMFAToken", "mfa.jwt.token").Return("123456789", nil)
+	repo.On("GetUserByID", mock.Anything, "123456789").Return(models.User{ID: "123456789", Email: "john@example.com", MFAEnabled: true, MFASecret: "SECRET"}, nil)
+	throttle.On("AttemptLogin", "john@example.com", "203.0.113.7").Return(&models.ThrottledError{RetryAt: time.Now().Add(time.Minute)})
+
+	_, err := uc.VerifyMFA("mfa.jwt.token", "123456", models.ClientInfo{IP: "203.0.113.7"})
+
+	assert.EqualError(t, err, "too many attempts, try again later")
+	// The code is not even looked at once the attempt could not be counted
+	otp.AssertNotCalled(t, "VerifyCode", mock.Anything, mock.Anything)
+}
+
+func TestVerifyMFA_InvalidChallenge(t *testing.T) {
+	_, _, tokenSvc, _, _, uc := setup()
+
+	tokenSvc.On("VerifyMFAToken", "expired.jwt.token").Return("", errors.New("invalid token"))
+
+	_, err := uc.VerifyMFA("expired.jwt.token", "123456", models.ClientInfo{})
+
+	assert.EqualError(t, err, "invalid or expired MFA token")
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	tests := []struct {
+		name        string
+		throttled   bool
+		userFound   bool
+		expectEmail bool
+		emailErr    error
+		expectError string
+	}{
+		{name: "Success - Known address gets the email", userFound: true, expectEmail: true},
+		{name: "Success - Failed email is not reported", userFound: true, expectEmail: true, emailErr: errors.New("smtp down")},
+		{name: "Success - Unknown address gets the same answer"},
+		{name: "Error - Asked too often", throttled: true, expectError: "too many attempts, try again later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.UserRepository)
+			tokenSvc := new(mocks.MockTokenService)
+			tokenRepo := new(mocks.MockTokenRepository)
+			emailService := new(mocks.MockEmailService)
+			throttle := new(mocks.LoginThrottleMock)
+			uc := NewUserUsecase(repo, new(mocks.MockHasher), tokenSvc, tokenRepo, emailService, new(mocks.TokenVersionStoreMock), throttle, new(mocks.OTPServiceMock))
+
+			if tt.throttled {
+				throttle.On("AllowPasswordReset", "john@example.com", "203.0.113.7").Return(&models.ThrottledError{RetryAt: time.Now().Add(time.Minute)})
+			} else {
+				throttle.On("AllowPasswordReset", "john@example.com", "203.0.113.7").Return(nil)
+			}
+			if tt.userFound {
+				repo.On("FindByEmail", "john@example.com").Return(models.User{Username: "john", Email: "john@example.com"}, nil)
+			} else {
+				repo.On("FindByEmail", "john@example.com").Return(models.User{}, errors.New("mongo: no documents in result"))
+			}
+			if tt.expectEmail {
+				tokenSvc.On("GenerateRandomJWT", 15*time.Minute).Return(&models.Token{Token: "reset_token"}, nil)
+				tokenSvc.On("HashToken", "reset_token").Return("hashed_reset_token")
+				tokenRepo.On("CreateToken", mock.Anything).Return(nil)
+			}
+			sent := make(chan struct{})
+			emailService.On("SendPasswordResetEmail", "john", "john@example.com", "reset_token").
+				Return(tt.emailErr).
+				Run(func(mock.Arguments) { close(sent) }).
+				Maybe()
+
+			err := uc.RequestPasswordReset("john@example.com", models.ClientInfo{IP: "203.0.113.7"})
+			if tt.expectEmail {
+				// The email goes out in the background
+				select {
+				case <-sent:
+				case <-time.After(time.Second):
+					t.Fatal("password reset email was not sent")
+				}
+			}
+
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				repo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.expectEmail {
+				emailService.AssertNotCalled(t, "SendPasswordResetEmail", mock.Anything, mock.Anything, mock.Anything)
+			}
+			emailService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUnlockAccount(t *testing.T) {
+	repo := new(mocks.UserRepository)
+	throttle := new(mocks.LoginThrottleMock)
+	uc := NewUserUsecase(repo, new(mocks.MockHasher), new(mocks.MockTokenService), new(mocks.MockTokenRepository), new(mocks.MockEmailService), new(mocks.TokenVersionStoreMock), throttle, new(mocks.OTPServiceMock))
+
+	repo.On("GetUserByID", mock.Anything, "user123").Return(models.User{ID: "user123", Email: "john@example.com"}, nil)
+	repo.On("GetUserByID", mock.Anything, "missing").Return(models.User{}, errors.New("mongo: no documents in result"))
+	throttle.On("UnlockAccount", "john@example.com").Return(nil)
+
+	assert.NoError(t, uc.UnlockAccount(context.Background(), "user123"))
+	assert.EqualError(t, uc.UnlockAccount(context.Background(), "missing"), "user not found")
+	throttle.AssertNumberOfCalls(t, "UnlockAccount", 1)
 }
 
 func TestPromote_Success(t *testing.T) {
@@ -197,6 +484,45 @@ func TestDemote_super_admin(t *testing.T) {
 	assert.EqualError(t, err, "superadmin cannot be demoted")
 }
 
+func TestRoleChange_RevokesAccessTokens(t *testing.T) {
+	repo := new(mocks.UserRepository)
+	tokenVersions := new(mocks.TokenVersionStoreMock)
+	uc := NewUserUsecase(repo, new(mocks.MockHasher), new(mocks.MockTokenService), new(mocks.MockTokenRepository), new(mocks.MockEmailService), tokenVersions, new(mocks.LoginThrottleMock), new(mocks.OTPServiceMock))
+
+	repo.On("FindByEmail", "john@example.com").Return(models.User{ID: "user123", Email: "john@example.com", Role: "user"}, nil)
+	repo.On("UpdateRole", "john@example.com", "admin").Return(nil)
+	repo.On("UpdateRole", "john@example.com", "user").Return(nil)
+	tokenVersions.On("Bump", mock.Anything, "user123").Return(nil)
+
+	assert.NoError(t, uc.Promote("john@example.com"))
+	assert.NoError(t, uc.Demote("john@example.com"))
+	tokenVersions.AssertNumberOfCalls(t, "Bump", 2)
+}
+
+func TestResetPassword_RevokesAllTokens(t *testing.T) {
+	repo := new(mocks.UserRepository)
+	hasher := new(mocks.MockHasher)
+	tokenSvc := new(mocks.MockTokenService)
+	tokenRepo := new(mocks.MockTokenRepository)
+	tokenVersions := new(mocks.TokenVersionStoreMock)
+	uc := NewUserUsecase(repo, hasher, tokenSvc, tokenRepo, new(mocks.MockEmailService), tokenVersions, new(mocks.LoginThrottleMock), new(mocks.OTPServiceMock))
+
+	tokenSvc.On("VerifyJWT", "reset.jwt.token").Return(models.TokenClaims{TokenID: "reset1"}, nil)
+	tokenRepo.On("GetToken", "reset1").Return(&models.Token{ID: "reset1", Email: "john@example.com", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	hasher.On("HashPassword", "newpassword123").Return("hashed_password")
+	repo.On("UpdatePass", "john@example.com", "hashed_password").Return(nil)
+	tokenRepo.On("DeleteToken", "reset1").Return(nil)
+	repo.On("FindByEmail", "john@example.com").Return(models.User{ID: "user123", Email: "john@example.com"}, nil)
+	tokenRepo.On("DeleteUserTokens", "user123", "").Return(int64(2), nil)
+	tokenVersions.On("Bump", mock.Anything, "user123").Return(nil)
+
+	err := uc.ResetPassword("reset.jwt.token", "newpassword123")
+
+	assert.NoError(t, err)
+	tokenRepo.AssertExpectations(t)
+	tokenVersions.AssertExpectations(t)
+}
+
 func TestRefreshToken_ExpiredToken(t *testing.T) {
 	_, _, mockTokenService, mockTokenRepo, _, uc := setup()
 
@@ -215,7 +541,7 @@ func TestRefreshToken_ExpiredToken(t *testing.T) {
 
 	mockTokenRepo.On("DeleteToken", "token123").Return(nil)
 
-	_, err := uc.RefreshToken(refreshStr)
+	_, err := uc.RefreshToken(refreshStr, models.ClientInfo{})
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, "the refresh token expired")
@@ -225,10 +551,11 @@ func TestRefreshToken_ExpiredToken(t *testing.T) {
 }
 
 func TestRefreshToken_Success(t *testing.T) {
-	_, mockHasher, mockTokenService, mockTokenRepo, _, uc := setup()
+	repo, mockHasher, mockTokenService, mockTokenRepo, _, uc := setup()
 
 	refreshStr := "refresh.jwt.token"
 	validTime := time.Now().Add(time.Hour)
+	loginAt := time.Now().Add(-48 * time.Hour)
 
 	mockTokenService.On("VerifyRefreshToken", refreshStr).Return(
 		&models.UserRefreshClaims{
@@ -242,24 +569,112 @@ func TestRefreshToken_Success(t *testing.T) {
 
 	mockTokenRepo.On("GetToken", "token123").Return(
 		&models.Token{
-			ID:    "token123",
-			Token: "hashed_token",
+			ID:       "token123",
+			Token:    "hashed_token",
+			FamilyID: "family123",
+			LoginAt:  loginAt,
 		}, nil,
 	)
 
 	mockTokenService.On("VerifyToken", "hashed_token", refreshStr).Return(true)
 
-	mockTokenService.On("GenerateAccessToken", "123", "test@example.com", "user").Return("new_access_token", nil)
+	repo.On("GetUserByID", mock.Anything, "123").Return(models.User{ID: "123", Email: "test@example.com", Role: "user"}, nil)
+
+	mockTokenRepo.On("RotateToken", "token123").Return(true, nil)
+	mockTokenService.On("GenerateAccessToken", "123", "test@example.com", "user", 0, false).Return("new_access_token", nil)
+	mockTokenService.On("GenerateRefreshToken", "123", "test@example.com", "user").Return(&models.Token{ID: "token456", Token: "new_refresh_token"}, nil)
+	mockTokenService.On("HashToken", "new_refresh_token").Return("hashed_new_refresh_token")
+	mockTokenRepo.On("CreateToken", mock.MatchedBy(func(token *models.Token) bool {
+		return token.ID == "token456" && token.Token == "hashed_new_refresh_token" && token.FamilyID == "family123" &&
+			token.LoginAt.Equal(loginAt) && token.IP == "198.51.100.4" && token.Device == "curl/8.0"
+	})).Return(nil)
 
-	token, err := uc.RefreshToken(refreshStr)
+	tokens, err := uc.RefreshToken(refreshStr, models.ClientInfo{IP: "198.51.100.4", UserAgent: "curl/8.0"})
 
 	assert.NoError(t, err)
-	assert.Equal(t, "new_access_token", token)
+	assert.Equal(t, "new_access_token", tokens.Access_token)
+	assert.Equal(t, "new_refresh_token", tokens.Refresh_token)
 
 	mockTokenService.AssertExpectations(t)
 	mockTokenRepo.AssertExpectations(t)
 	mockHasher.AssertExpectations(t)
 }
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	_, _, mockTokenService, mockTokenRepo, _, uc := setup()
+
+	refreshStr := "refresh.jwt.token"
+	rotatedAt := time.Now().Add(-time.Minute)
+
+	mockTokenService.On("VerifyRefreshToken", refreshStr).Return(
+		&models.UserRefreshClaims{
+			UserID:    "123",
+			TokenID:   "token123",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil,
+	)
+	mockTokenRepo.On("GetToken", "token123").Return(&models.Token{ID: "token123", Token: "hashed_token", FamilyID: "family123", RotatedAt: &rotatedAt}, nil)
+	mockTokenService.On("VerifyToken", "hashed_token", refreshStr).Return(true)
+	mockTokenRepo.On("DeleteTokenFamily", "family123").Return(nil)
+
+	_, err := uc.RefreshToken(refreshStr, models.ClientInfo{})
+
+	assert.EqualError(t, err, "refresh token reuse detected, please log in again")
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "GenerateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefreshToken_ConcurrentRefreshRevokesFamily(t *testing.T) {
+	repo, _, mockTokenService, mockTokenRepo, _, uc := setup()
+
+	refreshStr := "refresh.jwt.token"
+
+	mockTokenService.On("VerifyRefreshToken", refreshStr).Return(
+		&models.UserRefreshClaims{
+			UserID:    "123",
+			TokenID:   "token123",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil,
+	)
+	// A token issued before rotation has no family of its own yet
+	mockTokenRepo.On("GetToken", "token123").Return(&models.Token{ID: "token123", Token: "hashed_token"}, nil)
+	mockTokenService.On("VerifyToken", "hashed_token", refreshStr).Return(true)
+	repo.On("GetUserByID", mock.Anything, "123").Return(models.User{ID: "123"}, nil)
+	mockTokenRepo.On("RotateToken", "token123").Return(false, nil)
+	mockTokenRepo.On("DeleteTokenFamily", "token123").Return(nil)
+
+	_, err := uc.RefreshToken(refreshStr, models.ClientInfo{})
+
+	assert.EqualError(t, err, "refresh token reuse detected, please log in again")
+	mockTokenRepo.AssertExpectations(t)
+	mockTokenService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefreshToken_Suspended(t *testing.T) {
+	repo, _, mockTokenService, mockTokenRepo, _, uc := setup()
+
+	refreshStr := "refresh.jwt.token"
+	until := time.Now().Add(24 * time.Hour)
+
+	mockTokenService.On("VerifyRefreshToken", refreshStr).Return(
+		&models.UserRefreshClaims{
+			UserID:    "123",
+			Email:     "test@example.com",
+			Role:      "user",
+			TokenID:   "token123",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil,
+	)
+	mockTokenRepo.On("GetToken", "token123").Return(&models.Token{ID: "token123", Token: "hashed_token"}, nil)
+	mockTokenService.On("VerifyToken", "hashed_token", refreshStr).Return(true)
+	repo.On("GetUserByID", mock.Anything, "123").Return(models.User{ID: "123", SuspendedUntil: &until}, nil)
+
+	_, err := uc.RefreshToken(refreshStr, models.ClientInfo{})
+
+	assert.ErrorContains(t, err, "account suspended until")
+	mockTokenService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestLogout_Success(t *testing.T) {
 	_, _, mockTokenService, mockTokenRepo, _, uc := setup()
 